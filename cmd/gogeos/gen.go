@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// errUsage reports invalid command-line arguments that were already printed
+var errUsage = errors.New("invalid arguments")
+
+// genOptions controls the synthetic datasets produced by the gen command
+type genOptions struct {
+	count        int
+	seed         int64
+	datasets     int
+	minVertices  int
+	maxVertices  int
+	minSize      float64
+	maxSize      float64
+	irregularity float64
+	overlap      float64
+	extent       [4]float64
+	format       string
+	precision    int
+	output       string
+}
+
+// runGen implements "gogeos gen". Every dataset is generated from its own
+// seed (seed, seed+1, ...), so a corpus is reproducible file by file and a
+// single dataset can be regenerated without the others.
+func runGen(args []string) error {
+	opts := genOptions{}
+	var extent string
+
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: gogeos gen [flags]")
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Generates seeded random polygons. The same flags always produce the same output.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	fs.IntVar(&opts.count, "n", 1000, "number of polygons per dataset")
+	fs.Int64Var(&opts.seed, "seed", 1, "random seed of the first dataset")
+	fs.IntVar(&opts.datasets, "datasets", 1, "number of datasets; more than one requires -o to name a directory")
+	fs.IntVar(&opts.minVertices, "min-vertices", 4, "minimum number of distinct vertices per polygon")
+	fs.IntVar(&opts.maxVertices, "max-vertices", 32, "maximum number of distinct vertices per polygon")
+	fs.Float64Var(&opts.minSize, "min-size", 1, "minimum polygon radius")
+	fs.Float64Var(&opts.maxSize, "max-size", 10, "maximum polygon radius")
+	fs.Float64Var(&opts.irregularity, "irregularity", 0.5, "how jagged polygons are, in [0, 1) where 0 is regular")
+	fs.Float64Var(&opts.overlap, "overlap", 0.1, "fraction of polygons placed to overlap an earlier one")
+	fs.StringVar(&extent, "extent", "0,0,1000,1000", "area polygon centers are placed in, as minX,minY,maxX,maxY")
+	fs.StringVar(&opts.format, "format", "wkt", "output format: wkt (one polygon per line) or geojson")
+	fs.IntVar(&opts.precision, "precision", 6, "number of decimals written per ordinate")
+	fs.StringVar(&opts.output, "o", "", "output file, or directory when generating several datasets (default stdout)")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		// The flag set has already reported the problem and printed usage
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("gen: unexpected argument %q", fs.Arg(0))
+	}
+
+	var err error
+	if opts.extent, err = parseExtent(extent); err != nil {
+		return err
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	if opts.datasets == 1 {
+		if opts.output == "" {
+			w := bufio.NewWriter(os.Stdout)
+			if err := opts.generate(w, opts.seed); err != nil {
+				return err
+			}
+			return w.Flush()
+		}
+		return opts.generateFile(opts.output, opts.seed)
+	}
+
+	if err := os.MkdirAll(opts.output, 0o755); err != nil {
+		return err
+	}
+	for i := 0; i < opts.datasets; i++ {
+		name := filepath.Join(opts.output, fmt.Sprintf("dataset-%03d.%s", i, opts.format))
+		if err := opts.generateFile(name, opts.seed+int64(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks that the options describe a dataset that can be generated
+func (o *genOptions) validate() error {
+	switch {
+	case o.count < 0:
+		return fmt.Errorf("gen: -n must not be negative, got %d", o.count)
+	case o.datasets < 1:
+		return fmt.Errorf("gen: -datasets must be at least 1, got %d", o.datasets)
+	case o.datasets > 1 && o.output == "":
+		return errors.New("gen: -o must name a directory when generating several datasets")
+	case o.minVertices < 3:
+		return fmt.Errorf("gen: -min-vertices must be at least 3, got %d", o.minVertices)
+	case o.maxVertices < o.minVertices:
+		return fmt.Errorf("gen: -max-vertices (%d) is less than -min-vertices (%d)", o.maxVertices, o.minVertices)
+	case !(o.minSize > 0) || o.maxSize < o.minSize || math.IsInf(o.maxSize, 0):
+		return fmt.Errorf("gen: invalid size range %g to %g", o.minSize, o.maxSize)
+	case !(o.irregularity >= 0 && o.irregularity < 1):
+		return fmt.Errorf("gen: -irregularity must be in [0, 1), got %g", o.irregularity)
+	case !(o.overlap >= 0 && o.overlap <= 1):
+		return fmt.Errorf("gen: -overlap must be in [0, 1], got %g", o.overlap)
+	case o.format != "wkt" && o.format != "geojson":
+		return fmt.Errorf("gen: unknown format %q", o.format)
+	case o.precision < 0 || o.precision > 17:
+		return fmt.Errorf("gen: -precision must be in [0, 17], got %d", o.precision)
+	}
+	return nil
+}
+
+// parseExtent parses "minX,minY,maxX,maxY"
+func parseExtent(value string) ([4]float64, error) {
+	var extent [4]float64
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return extent, fmt.Errorf("gen: -extent must be minX,minY,maxX,maxY, got %q", value)
+	}
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return extent, fmt.Errorf("gen: invalid -extent value %q", part)
+		}
+		extent[i] = f
+	}
+	if extent[0] > extent[2] || extent[1] > extent[3] {
+		return extent, fmt.Errorf("gen: -extent minimums must not exceed maximums, got %q", value)
+	}
+	return extent, nil
+}
+
+// generateFile writes one dataset to a file
+func (o *genOptions) generateFile(name string, seed int64) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err := o.generate(w, seed); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// generate writes one dataset drawn from the given seed
+func (o *genOptions) generate(w io.Writer, seed int64) error {
+	rng := rand.New(rand.NewSource(seed))
+	centers := make([][2]float64, 0, o.count)
+
+	enc := &ringEncoder{w: w, precision: o.precision, geojson: o.format == "geojson"}
+	enc.begin()
+	for i := 0; i < o.count; i++ {
+		ring := o.polygon(rng, &centers)
+		enc.polygon(i, ring)
+	}
+	enc.end()
+	return enc.err
+}
+
+// polygon draws a random star-shaped polygon around a center. Each vertex
+// gets its own sector of the circle with a jittered angle, so the ring never
+// crosses itself, winds counter-clockwise, and no two neighbouring vertices
+// are half a turn or more apart. The result is a valid polygon that contains
+// its center, without a GEOS round trip.
+func (o *genOptions) polygon(rng *rand.Rand, centers *[][2]float64) []float64 {
+	n := o.minVertices + rng.Intn(o.maxVertices-o.minVertices+1)
+	radius := o.minSize + rng.Float64()*(o.maxSize-o.minSize)
+	// Every vertex lies at least this far from the center
+	inner := radius * (1 - o.irregularity)
+	sector := 2 * math.Pi / float64(n)
+
+	var cx, cy float64
+	if len(*centers) > 0 && rng.Float64() < o.overlap {
+		// Neighbouring vertices are less than 1.5 sectors apart, so every edge
+		// is at least inner*cos(0.75*sector) from the center. Placing the
+		// center of an earlier polygon closer than that puts it inside this
+		// polygon, and the two are guaranteed to overlap.
+		prev := (*centers)[rng.Intn(len(*centers))]
+		angle := rng.Float64() * 2 * math.Pi
+		dist := rng.Float64() * inner * math.Cos(0.75*sector)
+		cx, cy = prev[0]+dist*math.Cos(angle), prev[1]+dist*math.Sin(angle)
+	} else {
+		cx = o.extent[0] + rng.Float64()*(o.extent[2]-o.extent[0])
+		cy = o.extent[1] + rng.Float64()*(o.extent[3]-o.extent[1])
+	}
+	*centers = append(*centers, [2]float64{cx, cy})
+
+	ring := make([]float64, 0, 2*(n+1))
+	for i := 0; i < n; i++ {
+		angle := (float64(i) + 0.5*rng.Float64()) * sector
+		r := inner + rng.Float64()*(radius-inner)
+		ring = append(ring, cx+r*math.Cos(angle), cy+r*math.Sin(angle))
+	}
+	return append(ring, ring[0], ring[1])
+}
+
+// ringEncoder writes polygons as WKT lines or as a GeoJSON FeatureCollection,
+// keeping the first write error
+type ringEncoder struct {
+	w         io.Writer
+	precision int
+	geojson   bool
+	err       error
+	buf       []byte
+}
+
+// begin writes the header of the dataset
+func (e *ringEncoder) begin() {
+	if e.geojson {
+		e.write([]byte(`{"type":"FeatureCollection","features":[`))
+	}
+}
+
+// end writes the trailer of the dataset
+func (e *ringEncoder) end() {
+	if e.geojson {
+		e.write([]byte("]}\n"))
+	}
+}
+
+// polygon writes a polygon with a single ring of interleaved X/Y values
+func (e *ringEncoder) polygon(id int, ring []float64) {
+	b := e.buf[:0]
+	if e.geojson {
+		if id > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, "\n"+`{"type":"Feature","id":`...)
+		b = strconv.AppendInt(b, int64(id), 10)
+		b = append(b, `,"properties":{},"geometry":{"type":"Polygon","coordinates":[[`...)
+		for i := 0; i < len(ring); i += 2 {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, '[')
+			b = strconv.AppendFloat(b, ring[i], 'f', e.precision, 64)
+			b = append(b, ',')
+			b = strconv.AppendFloat(b, ring[i+1], 'f', e.precision, 64)
+			b = append(b, ']')
+		}
+		b = append(b, "]]}}"...)
+	} else {
+		b = append(b, "POLYGON(("...)
+		for i := 0; i < len(ring); i += 2 {
+			if i > 0 {
+				b = append(b, ", "...)
+			}
+			b = strconv.AppendFloat(b, ring[i], 'f', e.precision, 64)
+			b = append(b, ' ')
+			b = strconv.AppendFloat(b, ring[i+1], 'f', e.precision, 64)
+		}
+		b = append(b, "))\n"...)
+	}
+	e.buf = b
+	e.write(b)
+}
+
+// write writes b unless an earlier write failed
+func (e *ringEncoder) write(b []byte) {
+	if e.err == nil {
+		_, e.err = e.w.Write(b)
+	}
+}
@@ -0,0 +1,55 @@
+// Command gogeos provides command-line tools that support working with the
+// GoGEOS library.
+//
+// Usage:
+//
+//	gogeos <command> [flags]
+//
+// Commands:
+//
+//	gen    generate seeded synthetic polygon datasets
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "gen":
+		err = runGen(os.Args[2:])
+	case "help", "-h", "-help", "--help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "gogeos: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if errors.Is(err, errUsage) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gogeos:", err)
+		os.Exit(1)
+	}
+}
+
+// usage prints the list of commands
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: gogeos <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	fmt.Fprintln(os.Stderr, "  gen    generate seeded synthetic polygon datasets")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run 'gogeos <command> -h' for the flags of a command.")
+}
@@ -0,0 +1,163 @@
+// Package geodesic measures distances and areas on the WGS84 ellipsoid for
+// coordinates in longitude/latitude degrees, in pure Go. It gives lengths in
+// meters and areas in square meters where planar measures of lon/lat
+// coordinates only give meaningless degrees.
+//
+// Distances are exact geodesic distances computed with Vincenty's inverse
+// formula. Areas are computed on the authalic sphere, the sphere with the
+// same surface area as the ellipsoid, after mapping latitudes to authalic
+// latitudes; this mapping preserves area, and the result is within a small
+// fraction of a percent of the ellipsoidal area for polygons of any
+// practical size.
+package geodesic
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// SemiMajorAxis is the WGS84 equatorial radius in meters
+	SemiMajorAxis = 6378137.0
+
+	// Flattening is the WGS84 flattening
+	Flattening = 1 / 298.257223563
+
+	// SemiMinorAxis is the WGS84 polar radius in meters
+	SemiMinorAxis = SemiMajorAxis * (1 - Flattening)
+)
+
+var (
+	// eccentricity is the first eccentricity of the ellipsoid
+	eccentricity = math.Sqrt(Flattening * (2 - Flattening))
+
+	// authalicQ is q at the pole, used to map latitudes to authalic latitudes
+	authalicQ = authalic(1)
+
+	// AuthalicRadius is the radius in meters of the sphere with the same
+	// surface area as the WGS84 ellipsoid
+	AuthalicRadius = SemiMajorAxis * math.Sqrt(authalicQ/2)
+)
+
+// ErrNoConvergence is returned by Distance for nearly antipodal points, for
+// which Vincenty's formula does not converge.
+var ErrNoConvergence = errors.New("geodesic distance did not converge; the points are nearly antipodal")
+
+// Distance returns the length in meters of the shortest path on the WGS84
+// ellipsoid between two points given in longitude/latitude degrees.
+//
+// Example:
+//
+//	// Istanbul to Ankara
+//	meters, err := geodesic.Distance(28.9784, 41.0082, 32.8597, 39.9334)
+func Distance(lon1, lat1, lon2, lat2 float64) (float64, error) {
+	const maxIterations = 200
+
+	a, b, f := SemiMajorAxis, SemiMinorAxis, Flattening
+	l := radians(lon2 - lon1)
+	u1 := math.Atan((1 - f) * math.Tan(radians(lat1)))
+	u2 := math.Atan((1 - f) * math.Tan(radians(lat2)))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	lambda := l
+	var sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM float64
+	for i := 0; ; i++ {
+		if i == maxIterations {
+			return 0, ErrNoConvergence
+		}
+
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0, nil // coincident points
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0 // both points on the equator
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+
+		c := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+		previous := lambda
+		lambda = l + (1-c)*f*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-previous) < 1e-12 {
+			break
+		}
+	}
+
+	u2b := cos2Alpha * (a*a - b*b) / (b * b)
+	bigA := 1 + u2b/16384*(4096+u2b*(-768+u2b*(320-175*u2b)))
+	bigB := u2b / 1024 * (256 + u2b*(-128+u2b*(74-47*u2b)))
+	deltaSigma := bigB * sinSigma * (cos2SigmaM + bigB/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		bigB/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return b * bigA * (sigma - deltaSigma), nil
+}
+
+// RingArea returns the signed area in square meters enclosed by a closed
+// ring given as longitude and latitude slices of equal length. The area is
+// positive for counter-clockwise rings. Edges follow geodesics, except that
+// edges spanning more than 90° of longitude are first split into equal steps
+// of longitude, so longitudes are read as given, without wrapping at the
+// antimeridian, like planar operations read them.
+//
+// Example:
+//
+//	// A one degree square at the equator, about 12309 km²
+//	area := geodesic.RingArea([]float64{0, 1, 1, 0, 0}, []float64{0, 0, 1, 1, 0})
+func RingArea(lon, lat []float64) float64 {
+	n := min(len(lon), len(lat))
+	if n < 3 {
+		return 0
+	}
+
+	excess := 0.0
+	for i := 0; i < n-1; i++ {
+		startLon, startLat := lon[i], lat[i]
+		dLon, dLat := lon[i+1]-lon[i], lat[i+1]-lat[i]
+
+		steps := max(1, math.Ceil(math.Abs(dLon)/90))
+		for step := 1.0; step <= steps; step++ {
+			endLon, endLat := lon[i]+dLon*step/steps, lat[i]+dLat*step/steps
+			excess += edgeExcess(startLon, startLat, endLon, endLat)
+			startLon, startLat = endLon, endLat
+		}
+	}
+
+	// The excess sum is positive for clockwise rings
+	return -excess * AuthalicRadius * AuthalicRadius
+}
+
+// edgeExcess returns the spherical excess of the quadrilateral between an
+// edge on the authalic sphere and the equator, signed by the direction of
+// the edge
+func edgeExcess(lon1, lat1, lon2, lat2 float64) float64 {
+	t1 := math.Tan(authalicLatitude(lat1) / 2)
+	t2 := math.Tan(authalicLatitude(lat2) / 2)
+	tanHalfDelta := math.Tan(radians(lon2-lon1) / 2)
+	return 2 * math.Atan2(tanHalfDelta*(t1+t2), 1+t1*t2)
+}
+
+// authalicLatitude maps a geodetic latitude in degrees to the authalic
+// latitude in radians
+func authalicLatitude(lat float64) float64 {
+	q := authalic(math.Sin(radians(lat))) / authalicQ
+	return math.Asin(math.Max(-1, math.Min(1, q)))
+}
+
+// authalic returns q(φ) for sin φ, proportional to the area of the ellipsoid
+// between the equator and latitude φ
+func authalic(sinLat float64) float64 {
+	e := eccentricity
+	es := e * sinLat
+	return (1 - e*e) * (sinLat/(1-es*es) - math.Log((1-es)/(1+es))/(2*e))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
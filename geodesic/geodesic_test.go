@@ -0,0 +1,81 @@
+package geodesic
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestDistance tests geodesic distances against reference values
+func TestDistance(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		lon1, lat1, lon2, lat2 float64
+		expected               float64
+	}{
+		{"Coincident", 28.9784, 41.0082, 28.9784, 41.0082, 0},
+		{"Equator quarter", 0, 0, 90, 0, SemiMajorAxis * math.Pi / 2},
+		{"Meridian quadrant", 0, 0, 0, 90, 10001965.7293},
+		// Flinders Peak to Buninyong, from Vincenty's 1975 paper
+		{"Flinders Peak", 144.42486788889, -37.95103341667, 143.92649552778, -37.65282113889, 54972.271},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := Distance(tc.lon1, tc.lat1, tc.lon2, tc.lat2)
+			if err != nil {
+				t.Fatalf("Failed to compute distance: %v", err)
+			}
+			if math.Abs(d-tc.expected) > 1e-3 {
+				t.Errorf("Expected %.4f, got %.4f", tc.expected, d)
+			}
+
+			back, err := Distance(tc.lon2, tc.lat2, tc.lon1, tc.lat1)
+			if err != nil || math.Abs(back-d) > 1e-6 {
+				t.Errorf("Expected symmetric distance %.4f, got %.4f (%v)", d, back, err)
+			}
+		})
+	}
+
+	if _, err := Distance(0, 0, 180, 0); !errors.Is(err, ErrNoConvergence) {
+		t.Errorf("Expected ErrNoConvergence for antipodal points, got %v", err)
+	}
+}
+
+// TestRingArea tests ellipsoidal ring areas
+func TestRingArea(t *testing.T) {
+	// q(φ) terms of the exact area between the equator and a latitude, per
+	// radian of longitude
+	band := func(lat float64) float64 {
+		return SemiMajorAxis * SemiMajorAxis * authalic(math.Sin(radians(lat))) / 2
+	}
+
+	testCases := []struct {
+		name      string
+		lon, lat  []float64
+		expected  float64
+		tolerance float64 // relative
+	}{
+		// Bounded by the equator and two meridians, all geodesics
+		{"Polar wedge", []float64{0, 1, 1, 0, 0}, []float64{0, 0, 90, 90, 0}, radians(1) * band(90), 1e-9},
+		{"Clockwise wedge", []float64{0, 0, 1, 1, 0}, []float64{0, 90, 90, 0, 0}, -radians(1) * band(90), 1e-9},
+		// The geodesic edges of a small cell barely leave its parallels
+		{"Mid-latitude cell", []float64{10, 11, 11, 10, 10}, []float64{45, 45, 46, 46, 45}, radians(1) * (band(46) - band(45)), 1e-4},
+		// The equator edge is split into steps, which stay on the equator
+		{"Quarter hemisphere", []float64{0, 180, 180, 0, 0}, []float64{0, 0, 90, 90, 0}, math.Pi * band(90), 1e-9},
+		{"Degenerate", []float64{0, 1}, []float64{0, 1}, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			area := RingArea(tc.lon, tc.lat)
+			if math.Abs(area-tc.expected) > tc.tolerance*math.Abs(tc.expected) {
+				t.Errorf("Expected %.1f, got %.1f", tc.expected, area)
+			}
+		})
+	}
+
+	if total := 4 * math.Pi * AuthalicRadius * AuthalicRadius; math.Abs(total-5.10065622e14) > 1e6 {
+		t.Errorf("Expected the WGS84 surface area, got %g", total)
+	}
+}
@@ -0,0 +1,270 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"math"
+)
+
+// ExtentAccumulator computes the combined bounding box of a stream of geometries
+// without materializing them into a collection. Geometries can be added one at a
+// time and discarded right after, which keeps memory constant for any number of
+// features.
+//
+// Note: ExtentAccumulator is not thread-safe; use one accumulator per goroutine
+// and merge the results with AddBounds.
+//
+// Example:
+//
+//	acc := service.NewExtentAccumulator()
+//	for _, feature := range features {
+//		if err := acc.Add(feature); err != nil {
+//			return err
+//		}
+//	}
+//	minX, minY, maxX, maxY, ok := acc.Extent()
+type ExtentAccumulator struct {
+	service                *Service
+	minX, minY, maxX, maxY float64
+	count                  int
+}
+
+// NewExtentAccumulator creates an empty extent accumulator bound to the service.
+func (s *Service) NewExtentAccumulator() *ExtentAccumulator {
+	return &ExtentAccumulator{
+		service: s,
+		minX:    math.Inf(1),
+		minY:    math.Inf(1),
+		maxX:    math.Inf(-1),
+		maxY:    math.Inf(-1),
+	}
+}
+
+// Add extends the extent with the bounding box of a geometry.
+// Empty geometries are ignored.
+func (a *ExtentAccumulator) Add(geom *Geometry) error {
+	if geom == nil || geom.geom == nil {
+		return errors.New("invalid geometry")
+	}
+
+	s := a.service
+	c, err := s.acquire(geom)
+	if err != nil {
+		return err
+	}
+	defer c.release()
+
+	if C.GEOSisEmpty_r(c.context, geom.geom) == 1 {
+		return nil
+	}
+
+	var minX, minY, maxX, maxY C.double
+	if C.GEOSGeom_getExtent_r(c.context, geom.geom, &minX, &minY, &maxX, &maxY) == 0 {
+		return c.geosError("failed to get geometry extent")
+	}
+
+	a.AddBounds(float64(minX), float64(minY), float64(maxX), float64(maxY))
+	return nil
+}
+
+// AddBounds extends the extent with a bounding box, e.g. the result of
+// another accumulator.
+func (a *ExtentAccumulator) AddBounds(minX, minY, maxX, maxY float64) {
+	a.minX = math.Min(a.minX, minX)
+	a.minY = math.Min(a.minY, minY)
+	a.maxX = math.Max(a.maxX, maxX)
+	a.maxY = math.Max(a.maxY, maxY)
+	a.count++
+}
+
+// Count returns the number of non-empty geometries and bounds added so far.
+func (a *ExtentAccumulator) Count() int {
+	return a.count
+}
+
+// Extent returns the combined bounding box. ok is false if nothing was added.
+func (a *ExtentAccumulator) Extent() (minX, minY, maxX, maxY float64, ok bool) {
+	if a.count == 0 {
+		return 0, 0, 0, 0, false
+	}
+	return a.minX, a.minY, a.maxX, a.maxY, true
+}
+
+// Envelope returns the combined bounding box as a geometry: a polygon, or a
+// line or point if the extent is degenerate.
+func (a *ExtentAccumulator) Envelope() (*Geometry, error) {
+	if a.count == 0 {
+		return nil, errors.New("no geometries accumulated")
+	}
+
+	s := a.service
+	c, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	envelope := C.GEOSGeom_createRectangle_r(c.context, C.double(a.minX), C.double(a.minY), C.double(a.maxX), C.double(a.maxY))
+	if envelope == nil {
+		return nil, c.geosError("failed to create envelope")
+	}
+
+	return c.newGeometry(envelope), nil
+}
+
+// centroidSum accumulates weighted coordinates for one topological dimension
+type centroidSum struct {
+	x, y, weight float64
+}
+
+// CentroidAccumulator computes the weighted centroid of a stream of geometries
+// without materializing them into a collection. Like the GEOS centroid of a
+// collection, only the geometries of the highest dimension contribute: polygons
+// are weighted by area, lines by length and points by their count, unless an
+// explicit weight is given with AddWeighted.
+//
+// Note: CentroidAccumulator is not thread-safe.
+//
+// Example:
+//
+//	acc := service.NewCentroidAccumulator()
+//	for _, parcel := range parcels {
+//		if err := acc.Add(parcel); err != nil {
+//			return err
+//		}
+//	}
+//	x, y, ok := acc.Centroid()
+type CentroidAccumulator struct {
+	service *Service
+	sums    [3]centroidSum
+	count   int
+}
+
+// NewCentroidAccumulator creates an empty centroid accumulator bound to the service.
+func (s *Service) NewCentroidAccumulator() *CentroidAccumulator {
+	return &CentroidAccumulator{service: s}
+}
+
+// Add adds a geometry weighted by its area, length or point count depending
+// on its dimension. Empty geometries are ignored.
+func (a *CentroidAccumulator) Add(geom *Geometry) error {
+	return a.add(geom, 0, false)
+}
+
+// AddWeighted adds the centroid of a geometry with an explicit weight, such as
+// a population or demand value. The weight must be positive.
+func (a *CentroidAccumulator) AddWeighted(geom *Geometry, weight float64) error {
+	if weight <= 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return errors.New("weight must be a positive finite number")
+	}
+	return a.add(geom, weight, true)
+}
+
+// add computes the centroid and weight of a geometry and adds them to the
+// bucket of the geometry's dimension
+func (a *CentroidAccumulator) add(geom *Geometry, weight float64, explicit bool) error {
+	if geom == nil || geom.geom == nil {
+		return errors.New("invalid geometry")
+	}
+
+	s := a.service
+	c, err := s.acquire(geom)
+	if err != nil {
+		return err
+	}
+	defer c.release()
+
+	if C.GEOSisEmpty_r(c.context, geom.geom) == 1 {
+		return nil
+	}
+
+	dimension := int(C.GEOSGeom_getDimensions_r(c.context, geom.geom))
+	if dimension < 0 || dimension > 2 {
+		return c.geosError("failed to get geometry dimension")
+	}
+
+	if !explicit {
+		var measure C.double
+		switch dimension {
+		case 2:
+			if C.GEOSArea_r(c.context, geom.geom, &measure) == 0 {
+				return c.geosError("failed to calculate area")
+			}
+		case 1:
+			if C.GEOSLength_r(c.context, geom.geom, &measure) == 0 {
+				return c.geosError("failed to calculate length")
+			}
+		default:
+			measure = C.double(C.GEOSGetNumCoordinates_r(c.context, geom.geom))
+		}
+		weight = float64(measure)
+		if weight <= 0 {
+			// Collapsed lines and polygons count as a single point
+			dimension = 0
+			weight = 1
+		}
+	}
+
+	centroid := C.GEOSGetCentroid_r(c.context, geom.geom)
+	if centroid == nil {
+		return c.geosError("failed to calculate centroid")
+	}
+	defer C.GEOSGeom_destroy_r(c.context, centroid)
+
+	var x, y C.double
+	if C.GEOSGeomGetX_r(c.context, centroid, &x) == 0 || C.GEOSGeomGetY_r(c.context, centroid, &y) == 0 {
+		return c.geosError("failed to get centroid coordinates")
+	}
+
+	sum := &a.sums[dimension]
+	sum.x += float64(x) * weight
+	sum.y += float64(y) * weight
+	sum.weight += weight
+	a.count++
+
+	return nil
+}
+
+// Count returns the number of non-empty geometries added so far.
+func (a *CentroidAccumulator) Count() int {
+	return a.count
+}
+
+// Centroid returns the weighted centroid of the highest dimension seen.
+// ok is false if nothing was added.
+func (a *CentroidAccumulator) Centroid() (x, y float64, ok bool) {
+	for dimension := 2; dimension >= 0; dimension-- {
+		sum := a.sums[dimension]
+		if sum.weight > 0 {
+			return sum.x / sum.weight, sum.y / sum.weight, true
+		}
+	}
+	return 0, 0, false
+}
+
+// Point returns the weighted centroid as a point geometry.
+func (a *CentroidAccumulator) Point() (*Geometry, error) {
+	x, y, ok := a.Centroid()
+	if !ok {
+		return nil, errors.New("no geometries accumulated")
+	}
+
+	s := a.service
+	c, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	point := C.GEOSGeom_createPointFromXY_r(c.context, C.double(x), C.double(y))
+	if point == nil {
+		return nil, c.geosError("failed to create point")
+	}
+
+	return c.newGeometry(point), nil
+}
@@ -0,0 +1,114 @@
+package geos
+
+import (
+	"math"
+	"testing"
+)
+
+// TestExtentAccumulator tests streaming extent computation
+func TestExtentAccumulator(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	acc := helper.service.NewExtentAccumulator()
+	if _, _, _, _, ok := acc.Extent(); ok {
+		t.Error("Expected no extent for empty accumulator")
+	}
+	if _, err := acc.Envelope(); err == nil {
+		t.Error("Expected error for empty accumulator envelope")
+	}
+
+	for _, wkt := range []string{
+		"POINT(-1 5)",
+		"LINESTRING(0 0, 3 1)",
+		"POLYGON((1 -2, 2 -2, 2 0, 1 0, 1 -2))",
+		"POINT EMPTY",
+	} {
+		if err := acc.Add(helper.ParseWKT(wkt)); err != nil {
+			t.Fatalf("Failed to add %s: %v", wkt, err)
+		}
+	}
+
+	minX, minY, maxX, maxY, ok := acc.Extent()
+	if !ok {
+		t.Fatal("Expected extent")
+	}
+	if minX != -1 || minY != -2 || maxX != 3 || maxY != 5 {
+		t.Errorf("Unexpected extent (%g %g, %g %g)", minX, minY, maxX, maxY)
+	}
+	if acc.Count() != 3 {
+		t.Errorf("Expected 3 accumulated geometries, got %d", acc.Count())
+	}
+
+	envelope, err := acc.Envelope()
+	if err != nil {
+		t.Fatalf("Failed to create envelope: %v", err)
+	}
+	helper.AssertWithin(helper.ParseWKT("POINT(0 0)"), envelope, true)
+
+	if err := acc.Add(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestCentroidAccumulator tests streaming weighted centroid computation
+func TestCentroidAccumulator(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	acc := helper.service.NewCentroidAccumulator()
+	if _, _, ok := acc.Centroid(); ok {
+		t.Error("Expected no centroid for empty accumulator")
+	}
+
+	// Polygon areas 4 and 12 dominate the point and line
+	for _, wkt := range []string{
+		"POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))",
+		"POLYGON((4 0, 6 0, 6 6, 4 6, 4 0))",
+		"POINT(100 100)",
+		"LINESTRING(-50 0, -40 0)",
+	} {
+		if err := acc.Add(helper.ParseWKT(wkt)); err != nil {
+			t.Fatalf("Failed to add %s: %v", wkt, err)
+		}
+	}
+
+	x, y, ok := acc.Centroid()
+	if !ok {
+		t.Fatal("Expected centroid")
+	}
+	// (1*4 + 5*12) / 16 = 4, (1*4 + 3*12) / 16 = 2.5
+	if math.Abs(x-4) > 1e-9 || math.Abs(y-2.5) > 1e-9 {
+		t.Errorf("Expected centroid (4, 2.5), got (%g, %g)", x, y)
+	}
+
+	point, err := acc.Point()
+	if err != nil {
+		t.Fatalf("Failed to create centroid point: %v", err)
+	}
+	if helper.AssertToWKT(point) == "" {
+		t.Error("Expected centroid WKT")
+	}
+}
+
+// TestCentroidAccumulator_Weighted tests explicit weights
+func TestCentroidAccumulator_Weighted(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	acc := helper.service.NewCentroidAccumulator()
+	if err := acc.AddWeighted(helper.ParseWKT("POINT(0 0)"), 3); err != nil {
+		t.Fatalf("Failed to add weighted point: %v", err)
+	}
+	if err := acc.AddWeighted(helper.ParseWKT("POINT(4 0)"), 1); err != nil {
+		t.Fatalf("Failed to add weighted point: %v", err)
+	}
+	if err := acc.AddWeighted(helper.ParseWKT("POINT(4 0)"), 0); err == nil {
+		t.Error("Expected error for zero weight")
+	}
+
+	x, y, ok := acc.Centroid()
+	if !ok || x != 1 || y != 0 {
+		t.Errorf("Expected weighted centroid (1, 0), got (%g, %g, %t)", x, y, ok)
+	}
+}
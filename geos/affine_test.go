@@ -0,0 +1,67 @@
+package geos
+
+import (
+	"math"
+	"testing"
+)
+
+// TestAffineTransform tests composing and applying affine transformations
+func TestAffineTransform(t *testing.T) {
+	tr := ScaleTransform(2, 3).Then(TranslateTransform(10, 20))
+	x, y := tr.Apply(1, 1)
+	if x != 12 || y != 23 {
+		t.Errorf("Expected (12, 23), got (%g, %g)", x, y)
+	}
+
+	x, y = RotateTransform(math.Pi/2).Apply(1, 0)
+	if math.Abs(x) > 1e-12 || math.Abs(y-1) > 1e-12 {
+		t.Errorf("Expected (0, 1), got (%g, %g)", x, y)
+	}
+
+	x, y = IdentityTransform().Apply(5, 6)
+	if x != 5 || y != 6 {
+		t.Errorf("Expected (5, 6), got (%g, %g)", x, y)
+	}
+}
+
+// TestAffine tests transforming geometries
+func TestAffine(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name      string
+		inputWKT  string
+		transform AffineTransform
+		expected  string
+	}{
+		{"Translate point", "POINT(1 2)", TranslateTransform(10, 20), "POINT (11 22)"},
+		{"Scale polygon", "POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))", ScaleTransform(2, 2), "POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))"},
+		{"Keep Z", "POINT Z (1 2 3)", TranslateTransform(1, 1), "POINT Z (2 3 3)"},
+		{"Multi line", "MULTILINESTRING((0 0, 1 1), (2 2, 3 3))", TranslateTransform(0, 1), "MULTILINESTRING ((0 1, 1 2), (2 3, 3 4))"},
+		{"Empty", "POLYGON EMPTY", TranslateTransform(1, 1), "POLYGON EMPTY"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.inputWKT)
+
+			result, err := helper.service.Affine(geom, tc.transform)
+			if err != nil {
+				t.Fatalf("Failed to transform geometry: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(result, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.Affine(nil, IdentityTransform()); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,307 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// WKBArray is a column of WKB geometries laid out like an Apache Arrow binary
+// array, which is the GeoArrow "geoarrow.wkb" encoding. Its buffers can be
+// shared with Arrow libraries without copying each row: for an arrow Binary
+// array use ValueOffsets() as Offsets, ValueBytes() as Data and
+// NullBitmapBytes() as Validity (after accounting for the array offset).
+//
+// Value i is Data[Offsets[i]:Offsets[i+1]]. Validity is an optional bitmap in
+// least-significant-bit order where a cleared bit marks a null row; a nil
+// Validity means every row is valid.
+type WKBArray struct {
+	Offsets  []int32
+	Data     []byte
+	Validity []byte
+}
+
+// Len returns the number of rows in the array.
+func (a WKBArray) Len() int {
+	if len(a.Offsets) == 0 {
+		return 0
+	}
+	return len(a.Offsets) - 1
+}
+
+// IsNull reports whether row i is null.
+func (a WKBArray) IsNull(i int) bool {
+	if a.Validity == nil {
+		return false
+	}
+	return a.Validity[i/8]&(1<<(i%8)) == 0
+}
+
+// Value returns the WKB bytes of row i. Null rows have no bytes.
+func (a WKBArray) Value(i int) []byte {
+	return a.Data[a.Offsets[i]:a.Offsets[i+1]]
+}
+
+// validate checks that the buffers describe a well formed array
+func (a WKBArray) validate() error {
+	n := a.Len()
+	if n > 0 && a.Offsets[0] < 0 {
+		return errors.New("invalid WKB array: negative offset")
+	}
+	for i := 0; i < n; i++ {
+		if a.Offsets[i+1] < a.Offsets[i] {
+			return fmt.Errorf("invalid WKB array: offsets decrease at row %d", i)
+		}
+	}
+	if n > 0 && int(a.Offsets[n]) > len(a.Data) {
+		return errors.New("invalid WKB array: offsets exceed the data buffer")
+	}
+	if a.Validity != nil && len(a.Validity) < (n+7)/8 {
+		return errors.New("invalid WKB array: validity bitmap is too short")
+	}
+	return nil
+}
+
+// FromWKBArray parses a column of WKB geometries in a single batch, reusing
+// one GEOS reader for every row. Null rows produce nil geometries.
+//
+// Parameters:
+//   - arr: The WKB column, e.g. the buffers of an Arrow binary array
+//
+// Returns:
+//   - []*Geometry: One geometry per row, nil for null rows
+//   - error: An error naming the first row that fails to parse
+//
+// Example:
+//
+//	col := record.Column(geomIndex).(*array.Binary)
+//	geoms, err := service.FromWKBArray(geos.WKBArray{
+//		Offsets:  col.ValueOffsets(),
+//		Data:     col.ValueBytes(),
+//		Validity: col.NullBitmapBytes(),
+//	})
+func (s *Service) FromWKBArray(arr WKBArray) ([]*Geometry, error) {
+	if err := arr.validate(); err != nil {
+		return nil, err
+	}
+
+	c, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	reader, err := c.wkbReader()
+	if err != nil {
+		return nil, err
+	}
+
+	geoms := make([]*Geometry, arr.Len())
+	for i := range geoms {
+		if arr.IsNull(i) {
+			continue
+		}
+		wkb := arr.Value(i)
+		if len(wkb) == 0 {
+			c.discard(geoms[:i]...)
+			return nil, fmt.Errorf("row %d: empty WKB value", i)
+		}
+		g := C.GEOSWKBReader_read_r(c.context, reader, (*C.uchar)(unsafe.Pointer(&wkb[0])), C.size_t(len(wkb)))
+		if g == nil {
+			c.discard(geoms[:i]...)
+			return nil, c.geosError(fmt.Sprintf("row %d: failed to parse WKB geometry", i))
+		}
+		geoms[i] = c.newGeometry(g)
+	}
+
+	if c.journal != nil {
+		inputs := make([]string, len(geoms))
+		for i := range geoms {
+			if !arr.IsNull(i) {
+				inputs[i] = hashBytes(arr.Value(i))
+			}
+		}
+		c.recordHashes("FromWKBArray", nil, inputs, geoms...)
+	}
+
+	return geoms, nil
+}
+
+// ToWKBArray writes geometries into a WKB column in a single batch, reusing
+// one GEOS writer for every row. Geometries are written as little-endian ISO
+// WKB including any Z and M ordinates. Nil geometries become null rows, and
+// Validity is only allocated when there is at least one.
+//
+// Parameters:
+//   - geoms: The geometries to encode
+//
+// Returns:
+//   - WKBArray: The WKB column, ready to be wrapped in an Arrow binary array
+//   - error: An error naming the first row that fails to encode
+//
+// Example:
+//
+//	arr, err := service.ToWKBArray(results)
+//	data := array.NewData(arrow.BinaryTypes.Binary, arr.Len(),
+//		[]*memory.Buffer{memory.NewBufferBytes(arr.Validity),
+//			memory.NewBufferBytes(arrow.Int32Traits.CastToBytes(arr.Offsets)),
+//			memory.NewBufferBytes(arr.Data)}, nil, array.UnknownNullCount, 0)
+func (s *Service) ToWKBArray(geoms []*Geometry) (WKBArray, error) {
+	for i, geom := range geoms {
+		if geom != nil && geom.geom == nil {
+			return WKBArray{}, fmt.Errorf("invalid geometry at index %d", i)
+		}
+	}
+
+	c, err := s.acquire(geoms...)
+	if err != nil {
+		return WKBArray{}, err
+	}
+	defer c.release()
+
+	writer, err := c.wkbWriter(C.GEOS_WKB_ISO, false)
+	if err != nil {
+		return WKBArray{}, err
+	}
+
+	arr := WKBArray{Offsets: make([]int32, 1, len(geoms)+1)}
+	for i, geom := range geoms {
+		if geom == nil {
+			if arr.Validity == nil {
+				arr.Validity = make([]byte, (len(geoms)+7)/8)
+				for j := 0; j < i; j++ {
+					arr.Validity[j/8] |= 1 << (j % 8)
+				}
+			}
+			arr.Offsets = append(arr.Offsets, int32(len(arr.Data)))
+			continue
+		}
+
+		var size C.size_t
+		wkb := C.GEOSWKBWriter_write_r(c.context, writer, geom.geom, &size)
+		if wkb == nil {
+			return WKBArray{}, c.geosError(fmt.Sprintf("row %d: failed to write WKB geometry", i))
+		}
+		arr.Data = append(arr.Data, C.GoBytes(unsafe.Pointer(wkb), C.int(size))...)
+		C.GEOSFree_r(c.context, unsafe.Pointer(wkb))
+
+		if len(arr.Data) > math.MaxInt32 {
+			return WKBArray{}, errors.New("WKB array exceeds 2 GiB; split the batch")
+		}
+		if arr.Validity != nil {
+			arr.Validity[i/8] |= 1 << (i % 8)
+		}
+		arr.Offsets = append(arr.Offsets, int32(len(arr.Data)))
+	}
+
+	return arr, nil
+}
+
+// BoolArray is a column of booleans laid out like an Apache Arrow boolean
+// array: Values and Validity are bitmaps in least-significant-bit order, and a
+// cleared Validity bit marks a null row. A nil Validity means every row is valid.
+type BoolArray struct {
+	Length   int
+	Values   []byte
+	Validity []byte
+}
+
+// Value returns the boolean in row i.
+func (a BoolArray) Value(i int) bool {
+	return a.Values[i/8]&(1<<(i%8)) != 0
+}
+
+// IsNull reports whether row i is null.
+func (a BoolArray) IsNull(i int) bool {
+	if a.Validity == nil {
+		return false
+	}
+	return a.Validity[i/8]&(1<<(i%8)) == 0
+}
+
+// EvaluateWKBArray tests a spatial predicate between every row of a WKB column
+// and one fixed geometry, reading each row as "row <predicate> geom". The fixed
+// geometry is prepared once and rows are parsed and discarded one at a time,
+// so filtering a large column stays fast and allocates no Geometry values.
+// Null rows produce null results, which enables pushdown-style filtering of
+// Arrow or Parquet data in analytics pipelines.
+//
+// Parameters:
+//   - arr: The WKB column to test
+//   - predicate: The relationship to test
+//   - geom: The fixed geometry every row is tested against
+//
+// Returns:
+//   - BoolArray: One result per row, ready to be wrapped in an Arrow boolean array
+//   - error: An error naming the first row that fails to parse or evaluate
+//
+// Example:
+//
+//	aoi, _ := service.ParseGeometry(GeometryInput{WKT: aoiWKT})
+//	mask, err := service.EvaluateWKBArray(column, geos.PredicateWithin, aoi)
+//	// mask.Value(i) is true when row i lies within the area of interest
+func (s *Service) EvaluateWKBArray(arr WKBArray, predicate SpatialPredicate, geom *Geometry) (BoolArray, error) {
+	if geom == nil || geom.geom == nil {
+		return BoolArray{}, errors.New("invalid geometry")
+	}
+	if !predicate.valid() {
+		return BoolArray{}, fmt.Errorf("unknown spatial predicate: %s", predicate)
+	}
+	if err := arr.validate(); err != nil {
+		return BoolArray{}, err
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return BoolArray{}, err
+	}
+	defer c.release()
+
+	prepared := C.GEOSPrepare_r(c.context, geom.geom)
+	if prepared == nil {
+		return BoolArray{}, c.geosError("failed to prepare geometry")
+	}
+	defer C.GEOSPreparedGeom_destroy_r(c.context, prepared)
+
+	reader, err := c.wkbReader()
+	if err != nil {
+		return BoolArray{}, err
+	}
+
+	n := arr.Len()
+	result := BoolArray{Length: n, Values: make([]byte, (n+7)/8)}
+	if arr.Validity != nil {
+		result.Validity = append([]byte(nil), arr.Validity[:(n+7)/8]...)
+	}
+
+	for i := 0; i < n; i++ {
+		if arr.IsNull(i) {
+			continue
+		}
+		wkb := arr.Value(i)
+		if len(wkb) == 0 {
+			return BoolArray{}, fmt.Errorf("row %d: empty WKB value", i)
+		}
+		g := C.GEOSWKBReader_read_r(c.context, reader, (*C.uchar)(unsafe.Pointer(&wkb[0])), C.size_t(len(wkb)))
+		if g == nil {
+			return BoolArray{}, c.geosError(fmt.Sprintf("row %d: failed to parse WKB geometry", i))
+		}
+		match := c.evaluatePrepared(prepared, predicate, g)
+		C.GEOSGeom_destroy_r(c.context, g)
+		if match == 2 {
+			return BoolArray{}, c.geosError(fmt.Sprintf("row %d: GEOS %s operation failed", i, predicate))
+		}
+		if match == 1 {
+			result.Values[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,163 @@
+package geos
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// TestWKBArrayRoundTrip tests batch conversion to and from a WKB column
+func TestWKBArrayRoundTrip(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	wkts := []string{
+		"POINT (1 2)",
+		"",
+		"LINESTRING Z (0 0 1, 1 1 2)",
+		"POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))",
+	}
+	geoms := make([]*Geometry, len(wkts))
+	for i, wkt := range wkts {
+		if wkt != "" {
+			geoms[i] = helper.ParseWKT(wkt)
+		}
+	}
+
+	arr, err := helper.service.ToWKBArray(geoms)
+	if err != nil {
+		t.Fatalf("Failed to write WKB array: %v", err)
+	}
+	if arr.Len() != len(geoms) {
+		t.Fatalf("Expected %d rows, got %d", len(geoms), arr.Len())
+	}
+	if !arr.IsNull(1) || arr.IsNull(0) || arr.IsNull(3) {
+		t.Errorf("Unexpected validity bitmap %08b", arr.Validity)
+	}
+
+	// Row 0 is a little-endian ISO WKB point
+	point := arr.Value(0)
+	if len(point) != 21 || point[0] != 1 || binary.LittleEndian.Uint32(point[1:]) != 1 ||
+		math.Float64frombits(binary.LittleEndian.Uint64(point[13:])) != 2 {
+		t.Errorf("Unexpected WKB for row 0: %x", point)
+	}
+
+	parsed, err := helper.service.FromWKBArray(arr)
+	if err != nil {
+		t.Fatalf("Failed to read WKB array: %v", err)
+	}
+	for i, want := range wkts {
+		if want == "" {
+			if parsed[i] != nil {
+				t.Errorf("Expected nil geometry for null row %d", i)
+			}
+			continue
+		}
+		wkt, err := helper.service.ToWKTWithOptions(parsed[i], WKTOptions{Trim: true})
+		if err != nil {
+			t.Fatalf("Failed to convert to WKT: %v", err)
+		}
+		if wkt != want {
+			t.Errorf("Row %d: expected %s, got %s", i, want, wkt)
+		}
+	}
+
+	// Without nulls no validity bitmap is allocated
+	arr, err = helper.service.ToWKBArray(geoms[:1])
+	if err != nil {
+		t.Fatalf("Failed to write WKB array: %v", err)
+	}
+	if arr.Validity != nil {
+		t.Error("Expected no validity bitmap without nulls")
+	}
+}
+
+// TestWKBArrayMalformed tests rejection of malformed columns
+func TestWKBArrayMalformed(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name string
+		arr  WKBArray
+	}{
+		{"Offsets past data", WKBArray{Offsets: []int32{0, 10}, Data: []byte{1, 2}}},
+		{"Decreasing offsets", WKBArray{Offsets: []int32{0, 2, 1}, Data: []byte{1, 2}}},
+		{"Short validity", WKBArray{Offsets: make([]int32, 10), Validity: []byte{0xff}}},
+		{"Empty value", WKBArray{Offsets: []int32{0, 0}}},
+		{"Garbage bytes", WKBArray{Offsets: []int32{0, 3}, Data: []byte{1, 99, 0}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := helper.service.FromWKBArray(tc.arr); err == nil {
+				t.Error("Expected error for malformed array")
+			}
+		})
+	}
+
+	if arr := (WKBArray{}); arr.Len() != 0 {
+		t.Errorf("Expected empty array, got %d rows", arr.Len())
+	}
+}
+
+// TestEvaluateWKBArray tests bulk predicate evaluation over a WKB column
+func TestEvaluateWKBArray(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	aoi := helper.ParseWKT("POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))")
+	rows := []*Geometry{
+		helper.ParseWKT("POINT(5 5)"),
+		helper.ParseWKT("POINT(20 20)"),
+		nil,
+		helper.ParseWKT("LINESTRING(5 5, 15 5)"),
+		helper.ParseWKT("POLYGON((-1 -1, 11 -1, 11 11, -1 11, -1 -1))"),
+	}
+
+	arr, err := helper.service.ToWKBArray(rows)
+	if err != nil {
+		t.Fatalf("Failed to write WKB array: %v", err)
+	}
+
+	testCases := []struct {
+		predicate SpatialPredicate
+		expected  []bool
+	}{
+		{PredicateIntersects, []bool{true, false, false, true, true}},
+		{PredicateDisjoint, []bool{false, true, false, false, false}},
+		{PredicateWithin, []bool{true, false, false, false, false}},
+		{PredicateContains, []bool{false, false, false, false, true}},
+		{PredicateCrosses, []bool{false, false, false, true, false}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.predicate.String(), func(t *testing.T) {
+			mask, err := helper.service.EvaluateWKBArray(arr, tc.predicate, aoi)
+			if err != nil {
+				t.Fatalf("Failed to evaluate predicate: %v", err)
+			}
+			if mask.Length != len(rows) {
+				t.Fatalf("Expected %d results, got %d", len(rows), mask.Length)
+			}
+			for i, want := range tc.expected {
+				if rows[i] == nil {
+					if !mask.IsNull(i) {
+						t.Errorf("Expected null result for null row %d", i)
+					}
+					continue
+				}
+				if mask.IsNull(i) || mask.Value(i) != want {
+					t.Errorf("Row %d: expected %t, got %t (null %t)", i, want, mask.Value(i), mask.IsNull(i))
+				}
+			}
+		})
+	}
+
+	if _, err := helper.service.EvaluateWKBArray(arr, SpatialPredicate(99), aoi); err == nil {
+		t.Error("Expected error for unknown predicate")
+	}
+	if _, err := helper.service.EvaluateWKBArray(arr, PredicateIntersects, nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,106 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestBufferWithParams tests buffer style options
+func TestBufferWithParams(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	line := helper.ParseWKT("LINESTRING(0 0, 10 0)")
+
+	testCases := []struct {
+		name    string
+		opts    BufferOptions
+		point   string
+		contain bool
+	}{
+		{"Flat caps end at the line", BufferOptions{EndCapStyle: CapFlat}, "POINT(-0.5 0)", false},
+		{"Round caps extend past the line", BufferOptions{}, "POINT(-0.5 0)", true},
+		{"Round caps are rounded", BufferOptions{}, "POINT(-0.9 0.9)", false},
+		{"Square caps fill the corner", BufferOptions{EndCapStyle: CapSquare}, "POINT(-0.9 0.9)", true},
+		{"Single sided keeps the left side", BufferOptions{SingleSided: true}, "POINT(5 0.5)", true},
+		{"Single sided drops the right side", BufferOptions{SingleSided: true}, "POINT(5 -0.5)", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buffered, err := helper.service.BufferWithParams(line, 1.0, tc.opts)
+			if err != nil {
+				t.Fatalf("Failed to create buffer: %v", err)
+			}
+			helper.AssertWithin(helper.ParseWKT(tc.point), buffered, tc.contain)
+		})
+	}
+}
+
+// TestBufferWithParams_QuadrantSegments tests that more segments produce more vertices
+func TestBufferWithParams_QuadrantSegments(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	point := helper.PointGeometry()
+
+	coarse, err := helper.service.BufferWithParams(point, 1.0, BufferOptions{QuadrantSegments: 2})
+	if err != nil {
+		t.Fatalf("Failed to create buffer: %v", err)
+	}
+	fine, err := helper.service.BufferWithParams(point, 1.0, BufferOptions{QuadrantSegments: 32})
+	if err != nil {
+		t.Fatalf("Failed to create buffer: %v", err)
+	}
+
+	coarseSize, _ := helper.service.EstimateSize(coarse, FormatWKB)
+	fineSize, _ := helper.service.EstimateSize(fine, FormatWKB)
+	// 4*2+1 versus 4*32+1 ring vertices
+	if coarseSize != 9+4+9*16 || fineSize != 9+4+129*16 {
+		t.Errorf("Unexpected buffer sizes %d and %d", coarseSize, fineSize)
+	}
+}
+
+// TestBufferWithParams_Joins tests join styles on a corner
+func TestBufferWithParams_Joins(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	corner := helper.ParseWKT("LINESTRING(0 0, 10 0, 10 10)")
+	outside := helper.ParseWKT("POINT(10.9 -0.9)")
+
+	mitre, err := helper.service.BufferWithParams(corner, 1.0, BufferOptions{JoinStyle: JoinMitre, EndCapStyle: CapFlat})
+	if err != nil {
+		t.Fatalf("Failed to create buffer: %v", err)
+	}
+	helper.AssertWithin(outside, mitre, true)
+
+	bevel, err := helper.service.BufferWithParams(corner, 1.0, BufferOptions{JoinStyle: JoinBevel, EndCapStyle: CapFlat})
+	if err != nil {
+		t.Fatalf("Failed to create buffer: %v", err)
+	}
+	helper.AssertWithin(outside, bevel, false)
+}
+
+// TestBufferWithParams_Invalid tests invalid options
+func TestBufferWithParams_Invalid(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	point := helper.PointGeometry()
+
+	invalid := []BufferOptions{
+		{EndCapStyle: CapStyle(7)},
+		{JoinStyle: JoinStyle(-1)},
+		{QuadrantSegments: -2},
+		{MitreLimit: -1},
+	}
+	for _, opts := range invalid {
+		if _, err := helper.service.BufferWithParams(point, 1.0, opts); err == nil {
+			t.Errorf("Expected error for options %+v", opts)
+		}
+	}
+
+	if _, err := helper.service.BufferWithParams(nil, 1.0, BufferOptions{}); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,278 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// WithinBulk tests which of many points lie within one polygon, the core of
+// geofencing. The polygon is prepared once and every point is tested in a
+// single pass with one GEOS context, which is far faster than calling Within
+// per point. Any geometry can be tested in place of a point.
+//
+// Parameters:
+//   - points: The geometries to test, typically Points
+//   - polygon: The area to test them against
+//
+// Returns:
+//   - []bool: For each point in order, whether it is within the polygon
+//   - error: An error naming the first point that is invalid or fails to evaluate
+//
+// Example:
+//
+//	fence, _ := service.ParseGeometry(GeometryInput{WKT: fenceWKT})
+//
+//	inside, err := service.WithinBulk(vehicles, fence)
+//	for i, in := range inside {
+//		if !in {
+//			alert(i)
+//		}
+//	}
+func (s *Service) WithinBulk(points []*Geometry, polygon *Geometry) ([]bool, error) {
+	return s.WithinBulkParallel(points, polygon, 1)
+}
+
+// WithinBulkParallel works like WithinBulk but splits the points between
+// several worker goroutines. Each worker checks out its own GEOS context and
+// prepares its own copy of the polygon, so the workers do not contend with
+// each other. It pays off for large batches; runtime.NumCPU() is a good
+// number of workers. Close waits until every worker has finished.
+//
+// Parameters:
+//   - points: The geometries to test, typically Points
+//   - polygon: The area to test them against
+//   - workers: The number of worker goroutines, at least 1
+//
+// Returns:
+//   - []bool: For each point in order, whether it is within the polygon
+//   - error: An error naming the first point that is invalid or fails to evaluate
+//
+// Example:
+//
+//	inside, err := service.WithinBulkParallel(pings, fence, runtime.NumCPU())
+func (s *Service) WithinBulkParallel(points []*Geometry, polygon *Geometry, workers int) ([]bool, error) {
+	if polygon == nil || polygon.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	for i, point := range points {
+		if point == nil || point.geom == nil {
+			return nil, fmt.Errorf("point %d: invalid geometry", i)
+		}
+	}
+	if workers < 1 {
+		return nil, fmt.Errorf("invalid number of workers: %d", workers)
+	}
+
+	c, err := s.acquire(polygon)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+	if err := c.checkState(points...); err != nil {
+		return nil, err
+	}
+
+	result := make([]bool, len(points))
+	if workers == 1 || len(points) < 2 {
+		prepared := C.GEOSPrepare_r(c.context, polygon.geom)
+		if prepared == nil {
+			return nil, c.geosError("failed to prepare polygon")
+		}
+		defer C.GEOSPreparedGeom_destroy_r(c.context, prepared)
+
+		if i := preparedWithin(c.context, prepared, points, result); i >= 0 {
+			return nil, c.geosError(fmt.Sprintf("point %d: GEOS within operation failed", i))
+		}
+		return result, nil
+	}
+
+	workers = min(workers, len(points))
+	chunk := (len(points) + workers - 1) / workers
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start, end := w*chunk, min((w+1)*chunk, len(points))
+		if start >= end {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[w] = withinWorker(&c.pool, polygon.geom, points[start:end], result[start:end], start)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// withinWorker tests a share of the points of WithinBulkParallel with a GEOS
+// context checked out of the pool. offset is the index of the first point in
+// the batch.
+func withinWorker(pool *contextPool, polygon *C.GEOSGeometry, points []*Geometry, result []bool, offset int) error {
+	w, err := pool.get()
+	if err != nil {
+		return err
+	}
+	defer pool.put(w)
+
+	prepared := C.GEOSPrepare_r(w.context, polygon)
+	if prepared == nil {
+		return w.geosError("failed to prepare polygon")
+	}
+	defer C.GEOSPreparedGeom_destroy_r(w.context, prepared)
+
+	if i := preparedWithin(w.context, prepared, points, result); i >= 0 {
+		return w.geosError(fmt.Sprintf("point %d: GEOS within operation failed", offset+i))
+	}
+	return nil
+}
+
+// preparedWithin tests every point against a prepared polygon, filling in
+// result. It returns the index of the first point that fails to evaluate,
+// or -1.
+func preparedWithin(ctx C.GEOSContextHandle_t, prepared *C.GEOSPreparedGeometry, points []*Geometry, result []bool) int {
+	for i, point := range points {
+		// A point is within the polygon when the polygon contains it
+		switch C.GEOSPreparedContains_r(ctx, prepared, point.geom) {
+		case 1:
+			result[i] = true
+		case 2: // Error case
+			return i
+		}
+	}
+	return -1
+}
+
+// UnionParallel unions many geometries using several worker goroutines. The
+// geometries are sorted along a Hilbert curve and split into one contiguous
+// chunk per worker, so each chunk covers a compact area. Every worker unions
+// its chunk in its own GEOS context, and the partial results, which touch
+// only along the seams between the chunks, are merged at the end. For tens
+// of thousands of parcels this is much faster than Union and than a single
+// cascaded union. Nil geometries are skipped and the input order is not
+// changed. Workers only read the inputs, so a geometry may appear more than
+// once and be used by other operations meanwhile. Close waits until every
+// worker has finished.
+//
+// Parameters:
+//   - geoms: The geometries to union together
+//   - workers: The number of worker goroutines, at least 1
+//
+// Returns:
+//   - *Geometry: A new geometry representing the union of all input geometries
+//   - error: An error if no geometries are given or the operation fails
+//
+// Example:
+//
+//	merged, err := service.UnionParallel(parcels, runtime.NumCPU())
+func (s *Service) UnionParallel(geoms []*Geometry, workers int) (*Geometry, error) {
+	members := make([]*Geometry, 0, len(geoms))
+	for _, g := range geoms {
+		if g != nil && g.geom != nil {
+			members = append(members, g)
+		}
+	}
+	if len(members) == 0 {
+		return nil, errors.New("no geometries provided")
+	}
+	if workers < 1 {
+		return nil, fmt.Errorf("invalid number of workers: %d", workers)
+	}
+
+	c, err := s.acquire(members...)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	order, err := c.hilbertOrder(members)
+	if err != nil {
+		return nil, err
+	}
+	sorted := make([]*C.GEOSGeometry, len(members))
+	for i, j := range order {
+		sorted[i] = members[j].geom
+	}
+
+	workers = min(workers, len(sorted))
+	chunk := (len(sorted) + workers - 1) / workers
+	partials := make([]*C.GEOSGeometry, (len(sorted)+chunk-1)/chunk)
+	errs := make([]error, len(partials))
+	var wg sync.WaitGroup
+	for w := range partials {
+		start, end := w*chunk, min((w+1)*chunk, len(sorted))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			partials[w], errs[w] = unionWorker(&c.pool, sorted[start:end])
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			for _, partial := range partials {
+				if partial != nil {
+					C.GEOSGeom_destroy_r(c.context, partial)
+				}
+			}
+			return nil, err
+		}
+	}
+
+	union := partials[0]
+	if len(partials) > 1 {
+		// The collection takes ownership of the partial results
+		collection := C.GEOSGeom_createCollection_r(c.context, C.GEOS_GEOMETRYCOLLECTION, &partials[0], C.uint(len(partials)))
+		if collection == nil {
+			for _, partial := range partials {
+				C.GEOSGeom_destroy_r(c.context, partial)
+			}
+			return nil, c.geosError("failed to collect partial unions")
+		}
+		union = C.GEOSUnaryUnion_r(c.context, collection)
+		C.GEOSGeom_destroy_r(c.context, collection)
+		if union == nil {
+			return nil, c.geosError("failed to merge partial unions")
+		}
+	}
+
+	return c.newDerivedGeometry("UnionParallel", map[string]any{"workers": workers}, union, geoms...), nil
+}
+
+// unionWorker unions a chunk of geometries with a GEOS context checked out
+// of the pool
+func unionWorker(pool *contextPool, members []*C.GEOSGeometry) (*C.GEOSGeometry, error) {
+	w, err := pool.get()
+	if err != nil {
+		return nil, err
+	}
+	defer pool.put(w)
+
+	// Workers union copies, since the inputs may be shared with other workers
+	// and other operations
+	collection, err := w.collectCopies(members)
+	if err != nil {
+		return nil, err
+	}
+
+	union := C.GEOSUnaryUnion_r(w.context, collection)
+	C.GEOSGeom_destroy_r(w.context, collection)
+	if union == nil {
+		return nil, w.geosError("failed to create union")
+	}
+	return union, nil
+}
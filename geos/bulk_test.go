@@ -0,0 +1,158 @@
+package geos
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// TestWithinBulk tests point-in-polygon queries over many points
+func TestWithinBulk(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	// A square with a hole in the middle
+	fence := helper.ParseWKT("POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 6 4, 6 6, 4 6, 4 4))")
+
+	var points []*Geometry
+	var expected []bool
+	for x := -1; x <= 11; x++ {
+		for y := -1; y <= 11; y++ {
+			points = append(points, helper.ParseWKT(fmt.Sprintf("POINT(%d.5 %d.5)", x, y)))
+			inside := x >= 0 && x < 10 && y >= 0 && y < 10 && !(x >= 4 && x < 6 && y >= 4 && y < 6)
+			expected = append(expected, inside)
+		}
+	}
+
+	for _, workers := range []int{1, 3, 8, 1000} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			inside, err := helper.service.WithinBulkParallel(points, fence, workers)
+			if err != nil {
+				t.Fatalf("Failed to test points: %v", err)
+			}
+			if !reflect.DeepEqual(inside, expected) {
+				t.Errorf("Expected %v, got %v", expected, inside)
+			}
+		})
+	}
+
+	inside, err := helper.service.WithinBulk(points, fence)
+	if err != nil || !reflect.DeepEqual(inside, expected) {
+		t.Errorf("Expected WithinBulk to match, got %v (%v)", inside, err)
+	}
+	if inside, err := helper.service.WithinBulk(nil, fence); err != nil || len(inside) != 0 {
+		t.Errorf("Expected no results for no points, got %v (%v)", inside, err)
+	}
+
+	// A point on the boundary is not within
+	edge, err := helper.service.WithinBulk([]*Geometry{helper.ParseWKT("POINT(0 5)")}, fence)
+	if err != nil || edge[0] {
+		t.Errorf("Expected a boundary point not to be within, got %v (%v)", edge, err)
+	}
+
+	if _, err := helper.service.WithinBulk([]*Geometry{points[0], nil}, fence); err == nil {
+		t.Error("Expected error for a nil point")
+	}
+	if _, err := helper.service.WithinBulkParallel(points, fence, 0); err == nil {
+		t.Error("Expected error for zero workers")
+	}
+	if _, err := helper.service.WithinBulk(points, nil); err == nil {
+		t.Error("Expected error for a nil polygon")
+	}
+}
+
+// TestUnionParallel tests unioning in chunks on worker goroutines
+func TestUnionParallel(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	// A 10 by 10 grid of overlapping squares, shuffled so chunks are not
+	// already in spatial order, plus a nil entry
+	var parcels []*Geometry
+	for i := 0; i < 100; i++ {
+		x, y := (i*37)%100%10, (i*37)%100/10
+		parcels = append(parcels, helper.ParseWKT(fmt.Sprintf("POLYGON((%d %d, %d.5 %d, %d.5 %d.5, %d %d.5, %d %d))", x, y, x+1, y, x+1, y+1, x, y+1, x, y)))
+	}
+	parcels = append(parcels, nil)
+	first := parcels[0]
+
+	for _, workers := range []int{1, 4, 200} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			union, err := helper.service.UnionParallel(parcels, workers)
+			if err != nil {
+				t.Fatalf("Failed to union: %v", err)
+			}
+			expected := helper.ParseWKT("POLYGON((0 0, 10.5 0, 10.5 10.5, 0 10.5, 0 0))")
+			// Topological equality, whatever vertices the seams leave behind
+			if equal, err := helper.service.RelatePattern(union, expected, "T*F**FFF*"); err != nil || !equal {
+				t.Errorf("Expected the whole grid, got %s (%v)", helper.AssertToWKT(union), err)
+			}
+		})
+	}
+	if parcels[0] != first {
+		t.Error("Expected the input order to be kept")
+	}
+
+	if _, err := helper.service.UnionParallel([]*Geometry{nil}, 2); err == nil {
+		t.Error("Expected error for no geometries")
+	}
+	if _, err := helper.service.UnionParallel(parcels, 0); err == nil {
+		t.Error("Expected error for zero workers")
+	}
+}
+
+// TestUnionParallelSharedInputs tests that workers leave shared inputs alone,
+// including a geometry that appears in several chunks while other goroutines
+// read it
+func TestUnionParallelSharedInputs(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	shared, err := helper.service.ParseGeometry(GeometryInput{WKT: "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))", SRID: 3857})
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	wkt := helper.AssertToWKT(shared)
+	parcels := make([]*Geometry, 64)
+	for i := range parcels {
+		parcels[i] = shared
+	}
+
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if got, err := helper.service.ToWKT(shared); err != nil || got != wkt {
+					t.Errorf("Expected %s while unioning, got %s (%v)", wkt, got, err)
+					return
+				}
+			}
+		}()
+	}
+
+	union, err := helper.service.UnionParallel(parcels, 8)
+	close(stop)
+	readers.Wait()
+	if err != nil {
+		t.Fatalf("Failed to union: %v", err)
+	}
+
+	if srid := shared.SRID(); srid != 3857 {
+		t.Errorf("Expected the shared input to keep SRID 3857, got %d", srid)
+	}
+	if srid := union.SRID(); srid != 3857 {
+		t.Errorf("Expected the union to inherit SRID 3857, got %d", srid)
+	}
+	if equal, err := helper.service.RelatePattern(union, shared, "T*F**FFF*"); err != nil || !equal {
+		t.Errorf("Expected the shared square, got %s (%v)", helper.AssertToWKT(union), err)
+	}
+}
@@ -0,0 +1,94 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+)
+
+// Clone returns an independent copy of a geometry owned by this service. The
+// geometry may come from another service, e.g. to move results out of a
+// short-lived worker service before it is closed. Taking a copy is also the
+// safe way to hold on to a geometry that may alias an input, such as the
+// result of Union with a single geometry. The copy keeps the SRID.
+//
+// Parameters:
+//   - geom: The geometry to copy, owned by any open service
+//
+// Returns:
+//   - *Geometry: A new geometry owned by this service
+//   - error: An error if the geometry is invalid or the operation fails
+//
+// Example:
+//
+//	worker, _ := geos.NewService()
+//	result, _ := worker.Buffer(geom, 10)
+//	kept, err := service.Clone(result)
+//	worker.Close()
+//	// kept is still usable
+func (s *Service) Clone(geom *Geometry) (*Geometry, error) {
+	if geom == nil || geom.geom == nil || geom.service == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	if geom.service != s {
+		return s.adopt(geom)
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	copied := C.GEOSGeom_clone_r(c.context, geom.geom)
+	if copied == nil {
+		return nil, c.geosError("failed to clone geometry")
+	}
+
+	return c.newDerivedGeometry("Clone", nil, copied, geom), nil
+}
+
+// Clone returns an independent copy of the geometry owned by the same
+// service, see Service.Clone.
+//
+// Returns:
+//   - *Geometry: A new geometry
+//   - error: An error if the geometry is invalid or the operation fails
+func (g *Geometry) Clone() (*Geometry, error) {
+	if g == nil || g.service == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	return g.service.Clone(g)
+}
+
+// adopt copies a geometry of another service into this one. The geometry is
+// read under the lock of its own service and rebuilt under this one, so the
+// two locks are never held together.
+func (s *Service) adopt(geom *Geometry) (*Geometry, error) {
+	sh, err := geom.service.shapeOf(geom)
+	if err != nil {
+		return nil, err
+	}
+	srid := geom.SRID()
+
+	c, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	built, err := c.buildShape(sh)
+	if err != nil {
+		return nil, err
+	}
+	C.GEOSSetSRID_r(c.context, built, C.int(srid))
+
+	// The copy has the same content, and so the same hash, as the original
+	g := c.newGeometry(built)
+	c.record("Clone", nil, []*Geometry{g}, g)
+	return g, nil
+}
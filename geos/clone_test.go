@@ -0,0 +1,81 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestClone tests copying geometries within and across services
+func TestClone(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	geom, err := helper.service.ParseGeometry(GeometryInput{WKT: "POLYGON Z ((0 0 1, 4 0 2, 4 4 3, 0 0 1))", SRID: 2154})
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	expected, err := helper.service.ToWKTWithOptions(geom, WKTOptions{Trim: true})
+	if err != nil {
+		t.Fatalf("Failed to convert to WKT: %v", err)
+	}
+
+	copied, err := geom.Clone()
+	if err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+	if copied == geom || copied.geom == geom.geom {
+		t.Fatal("Expected an independent copy")
+	}
+
+	worker, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create GEOS service: %v", err)
+	}
+	point, err := worker.ParseGeometry(GeometryInput{WKT: "POINT(0 0)"})
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	buffered, err := worker.Buffer(point, 1)
+	if err != nil {
+		t.Fatalf("Failed to buffer: %v", err)
+	}
+	adopted, err := helper.service.Clone(buffered)
+	if err != nil {
+		t.Fatalf("Failed to clone across services: %v", err)
+	}
+	moved, err := worker.Clone(geom)
+	if err != nil {
+		t.Fatalf("Failed to clone across services: %v", err)
+	}
+
+	for name, c := range map[string]struct {
+		service *Service
+		geom    *Geometry
+	}{"Clone": {helper.service, copied}, "Clone to another service": {worker, moved}} {
+		wkt, err := c.service.ToWKTWithOptions(c.geom, WKTOptions{Trim: true})
+		if err != nil {
+			t.Fatalf("%s: failed to convert to WKT: %v", name, err)
+		}
+		if wkt != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, wkt)
+		}
+		if c.geom.SRID() != 2154 {
+			t.Errorf("%s: expected SRID 2154, got %d", name, c.geom.SRID())
+		}
+	}
+	worker.Close()
+
+	// The adopted geometry outlives the worker
+	if !adopted.IsPolygon() {
+		t.Errorf("Expected a Polygon, got %v", adopted.Type())
+	}
+	if empty, err := helper.service.IsEmpty(adopted); err != nil || empty {
+		t.Errorf("Expected a usable non-empty copy, got %v (%v)", empty, err)
+	}
+
+	if _, err := helper.service.Clone(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+	if _, err := helper.service.Clone(moved); err == nil {
+		t.Error("Expected error for a geometry of a closed service")
+	}
+}
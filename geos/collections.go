@@ -0,0 +1,145 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NumGeometries returns the number of components of a multi-geometry or
+// GeometryCollection. Other geometries count as a single component, so
+// results of operations like Union can be processed the same way whether or
+// not they came back as a collection.
+//
+// Parameters:
+//   - geom: The geometry to count
+//
+// Returns:
+//   - int: The number of components, 0 for an empty collection
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	union, _ := service.Union(parcels)
+//	n, err := service.NumGeometries(union)
+//	// n is the number of disjoint areas in the union
+func (s *Service) NumGeometries(geom *Geometry) (int, error) {
+	if geom == nil || geom.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+
+	n := C.GEOSGetNumGeometries_r(c.context, geom.geom)
+	if n < 0 {
+		return 0, c.geosError("failed to count geometries")
+	}
+
+	return int(n), nil
+}
+
+// GeometryN returns a copy of the component at index n of a multi-geometry
+// or GeometryCollection. For other geometries, index 0 returns a copy of the
+// geometry itself.
+//
+// Parameters:
+//   - geom: The geometry to take the component from
+//   - n: The zero-based index of the component
+//
+// Returns:
+//   - *Geometry: A new geometry holding the component
+//   - error: An error if the index is out of range or the operation fails
+//
+// Example:
+//
+//	first, err := service.GeometryN(multiPolygon, 0)
+func (s *Service) GeometryN(geom *Geometry, n int) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	return c.geometryN(geom, n)
+}
+
+// geometryN copies a component of a geometry.
+func (c *session) geometryN(geom *Geometry, n int) (*Geometry, error) {
+	count := C.GEOSGetNumGeometries_r(c.context, geom.geom)
+	if count < 0 {
+		return nil, c.geosError("failed to count geometries")
+	}
+	if n < 0 || n >= int(count) {
+		return nil, fmt.Errorf("geometry index %d out of range [0, %d)", n, int(count))
+	}
+
+	part := C.GEOSGetGeometryN_r(c.context, geom.geom, C.int(n))
+	if part == nil {
+		return nil, c.geosError("failed to get geometry")
+	}
+	copied := C.GEOSGeom_clone_r(c.context, part)
+	if copied == nil {
+		return nil, c.geosError("failed to copy geometry")
+	}
+
+	return c.newDerivedGeometry("GeometryN", map[string]any{"n": n}, copied, geom), nil
+}
+
+// ForEachGeometry calls fn with a copy of every component of a geometry in
+// order, as for GeometryN. Components are copied one at a time, and fn runs
+// without holding the service lock, so it may use the service. Iteration
+// stops at the first error returned by fn, which ForEachGeometry returns.
+//
+// Parameters:
+//   - geom: The geometry whose components to visit
+//   - fn: The function to call with each component index and copy
+//
+// Returns:
+//   - error: An error from fn, or if the operation fails
+//
+// Example:
+//
+//	result, _ := service.Polygonize(edges)
+//	err := service.ForEachGeometry(result.Polygons, func(i int, polygon *geos.Geometry) error {
+//		wkt, err := service.ToWKT(polygon)
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(i, wkt)
+//		return nil
+//	})
+func (s *Service) ForEachGeometry(geom *Geometry, fn func(i int, part *Geometry) error) error {
+	if fn == nil {
+		return errors.New("callback is required")
+	}
+
+	n, err := s.NumGeometries(geom)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		part, err := s.GeometryN(geom, i)
+		if err != nil {
+			return err
+		}
+		if err := fn(i, part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
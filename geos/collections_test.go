@@ -0,0 +1,73 @@
+package geos
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestGeometryN tests counting and copying the components of geometries
+func TestGeometryN(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected []string
+	}{
+		{"MultiPolygon", "MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))", []string{"POLYGON ((0 0, 1 0, 1 1, 0 0))", "POLYGON ((5 5, 6 5, 6 6, 5 5))"}},
+		{"GeometryCollection", "GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(0 0, 1 1))", []string{"POINT (1 2)", "LINESTRING (0 0, 1 1)"}},
+		{"Single geometry", "POINT(3 4)", []string{"POINT (3 4)"}},
+		{"Empty collection", "MULTIPOINT EMPTY", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.wkt)
+
+			n, err := helper.service.NumGeometries(geom)
+			if err != nil {
+				t.Fatalf("Failed to count geometries: %v", err)
+			}
+			if n != len(tc.expected) {
+				t.Fatalf("Expected %d geometries, got %d", len(tc.expected), n)
+			}
+
+			var got []string
+			err = helper.service.ForEachGeometry(geom, func(i int, part *Geometry) error {
+				wkt, err := helper.service.ToWKTWithOptions(part, WKTOptions{Trim: true})
+				if err != nil {
+					return err
+				}
+				got = append(got, wkt)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Failed to iterate geometries: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+
+			if _, err := helper.service.GeometryN(geom, n); err == nil {
+				t.Errorf("Expected error for index %d", n)
+			}
+		})
+	}
+
+	// Iteration stops at the first callback error
+	stop := errors.New("stop")
+	calls := 0
+	err := helper.service.ForEachGeometry(helper.ParseWKT("MULTIPOINT((0 0), (1 1), (2 2))"), func(i int, part *Geometry) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Expected iteration to stop after one call with the callback error, got %d calls and %v", calls, err)
+	}
+
+	if _, err := helper.service.GeometryN(helper.PointGeometry(), -1); err == nil {
+		t.Error("Expected error for negative index")
+	}
+}
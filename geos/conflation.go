@@ -0,0 +1,408 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Snap snaps the vertices and segments of a geometry to the vertices and
+// segments of a reference geometry within the given tolerance. Snapping
+// nearly-coincident boundaries together before an overlay operation removes
+// the sliver polygons and tiny gaps that floating point noise would produce.
+//
+// Parameters:
+//   - geom: The geometry to adjust
+//   - reference: The geometry to snap to
+//   - tolerance: The maximum distance a vertex may move (must be positive)
+//
+// Returns:
+//   - *Geometry: A new snapped geometry
+//   - error: An error if the tolerance is not positive or the operation fails
+//
+// Example:
+//
+//	parcel := GeometryInput{WKT: "POLYGON((0 0, 10 0.01, 10 10, 0 10, 0 0))"}
+//	road := GeometryInput{WKT: "LINESTRING(-5 0, 15 0)"}
+//
+//	geom1, _ := service.ParseGeometry(parcel)
+//	geom2, _ := service.ParseGeometry(road)
+//
+//	snapped, err := service.Snap(geom1, geom2, 0.1)
+//	// snapped's southern edge now lies exactly on the road
+func (s *Service) Snap(geom, reference *Geometry, tolerance float64) (*Geometry, error) {
+	if geom == nil || reference == nil || geom.geom == nil || reference.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if !(tolerance > 0) || math.IsInf(tolerance, 0) {
+		return nil, fmt.Errorf("tolerance must be positive, got %g", tolerance)
+	}
+
+	c, err := s.acquire(geom, reference)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	result := C.GEOSSnap_r(c.context, geom.geom, reference.geom, C.double(tolerance))
+	if result == nil {
+		return nil, c.geosError("failed to snap geometry")
+	}
+
+	return c.newDerivedGeometry("Snap", map[string]any{"tolerance": tolerance}, result, geom, reference), nil
+}
+
+// SnapLayer snaps the vertices and segments of every geometry in editLayer to
+// nearby vertices and segments of the geometries in referenceLayer. It is the
+// first step of conflation workflows, where a dataset is aligned to a more
+// authoritative one so that shared boundaries coincide exactly.
+//
+// Each edit geometry is only snapped against the reference geometries whose
+// bounding boxes lie within the tolerance, so large reference layers are cheap
+// to use. The result has one snapped copy per edit geometry, in the same order.
+//
+// Parameters:
+//   - editLayer: The geometries to adjust
+//   - referenceLayer: The geometries to snap to
+//   - tolerance: The maximum distance a vertex may move (must be positive)
+//
+// Returns:
+//   - []*Geometry: The snapped geometries, in the order of editLayer
+//   - error: An error if an input is invalid or an operation fails
+//
+// Example:
+//
+//	parcels := []*Geometry{parcel1, parcel2}
+//	roads := []*Geometry{road1, road2, road3}
+//
+//	aligned, err := service.SnapLayer(parcels, roads, 0.5)
+func (s *Service) SnapLayer(editLayer, referenceLayer []*Geometry, tolerance float64) ([]*Geometry, error) {
+	if !(tolerance > 0) || math.IsInf(tolerance, 0) {
+		return nil, fmt.Errorf("tolerance must be positive, got %g", tolerance)
+	}
+	for i, geom := range editLayer {
+		if geom == nil || geom.geom == nil {
+			return nil, fmt.Errorf("invalid geometry at edit layer index %d", i)
+		}
+	}
+	for i, geom := range referenceLayer {
+		if geom == nil || geom.geom == nil {
+			return nil, fmt.Errorf("invalid geometry at reference layer index %d", i)
+		}
+	}
+
+	c, err := s.acquire(editLayer...)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+	if err := c.checkState(referenceLayer...); err != nil {
+		return nil, err
+	}
+
+	// Precompute the reference envelopes once
+	references := make([]*C.GEOSGeometry, 0, len(referenceLayer))
+	envelopes := make([]envelope, 0, len(referenceLayer))
+	for _, ref := range referenceLayer {
+		env, ok, err := c.envelopeOf(ref.geom)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			references = append(references, ref.geom)
+			envelopes = append(envelopes, env)
+		}
+	}
+
+	snapped := make([]*Geometry, len(editLayer))
+	candidates := make([]*C.GEOSGeometry, 0)
+	for i, geom := range editLayer {
+		candidates = candidates[:0]
+		env, ok, err := c.envelopeOf(geom.geom)
+		if err != nil {
+			c.discard(snapped[:i]...)
+			return nil, err
+		}
+		if ok {
+			search := env.expand(tolerance)
+			for j, refEnv := range envelopes {
+				if search.intersects(refEnv) {
+					candidates = append(candidates, references[j])
+				}
+			}
+		}
+
+		var result *C.GEOSGeometry
+		if len(candidates) == 0 {
+			result = C.GEOSGeom_clone_r(c.context, geom.geom)
+		} else {
+			result, err = c.snapToAll(geom.geom, candidates, tolerance)
+			if err != nil {
+				c.discard(snapped[:i]...)
+				return nil, err
+			}
+		}
+		if result == nil {
+			c.discard(snapped[:i]...)
+			return nil, c.geosError("failed to snap geometry")
+		}
+
+		snapped[i] = c.newGeometry(result)
+	}
+	inputs := append(append([]*Geometry{}, editLayer...), referenceLayer...)
+	c.inheritSRID(inputs, snapped...)
+	c.record("SnapLayer", map[string]any{"tolerance": tolerance}, inputs, snapped...)
+
+	return snapped, nil
+}
+
+// snapToAll snaps a geometry to a set of reference geometries, which are
+// copied into a temporary collection when there are several.
+func (c *session) snapToAll(g *C.GEOSGeometry, references []*C.GEOSGeometry, tolerance float64) (*C.GEOSGeometry, error) {
+	if len(references) == 1 {
+		return C.GEOSSnap_r(c.context, g, references[0], C.double(tolerance)), nil
+	}
+
+	collection, err := c.collectCopies(references)
+	if err != nil {
+		return nil, err
+	}
+
+	result := C.GEOSSnap_r(c.context, g, collection, C.double(tolerance))
+	C.GEOSGeom_destroy_r(c.context, collection)
+	if result == nil {
+		return nil, c.geosError("failed to snap geometry")
+	}
+	return result, nil
+}
+
+// MatchOptions configures MatchFeatures.
+type MatchOptions struct {
+	// MaxDistance is the search radius for candidate pairs and the Hausdorff
+	// distance at which the distance score drops to zero. It must be positive.
+	MaxDistance float64 `json:"max_distance"`
+
+	// MinScore discards candidates scoring below it (0 keeps every candidate).
+	MinScore float64 `json:"min_score,omitempty"`
+
+	// OverlapWeight, DistanceWeight and NameWeight weight the score components.
+	// When all three are zero every component is weighted equally.
+	OverlapWeight  float64 `json:"overlap_weight,omitempty"`
+	DistanceWeight float64 `json:"distance_weight,omitempty"`
+	NameWeight     float64 `json:"name_weight,omitempty"`
+
+	// NameSimilarity optionally scores the attributes of feature i of layer A
+	// against feature j of layer B, returning a value between 0 and 1. It is
+	// called without holding the service lock, so it may use the service.
+	NameSimilarity func(i, j int) float64 `json:"-"`
+}
+
+// FeatureMatch is a scored candidate pairing of two features.
+type FeatureMatch struct {
+	IndexA int `json:"index_a"`
+	IndexB int `json:"index_b"`
+
+	// Overlap is the intersection over union of the two areas. It is only
+	// computed when both features are polygonal, as reported by HasOverlap.
+	Overlap    float64 `json:"overlap"`
+	HasOverlap bool    `json:"has_overlap"`
+
+	HausdorffDistance float64 `json:"hausdorff_distance"`
+	NameSimilarity    float64 `json:"name_similarity"`
+
+	// Score is the weighted average of the available components, between 0 and 1
+	Score float64 `json:"score"`
+}
+
+// MatchFeatures pairs the features of two datasets that likely represent the
+// same real-world object. Every pair within MaxDistance of each other is scored
+// on area overlap, Hausdorff distance and, when a callback is given, name
+// similarity. The candidates are meant for human review rather than automatic
+// merging, so every pair above MinScore is returned, not just the best one.
+//
+// Parameters:
+//   - layerA: The first dataset
+//   - layerB: The second dataset
+//   - opts: Search radius, score weights and the optional name callback
+//
+// Returns:
+//   - []FeatureMatch: Candidates ordered by IndexA, then by descending score
+//   - error: An error if an input is invalid or an operation fails
+//
+// Example:
+//
+//	matches, err := service.MatchFeatures(osmBuildings, cadastre, geos.MatchOptions{
+//		MaxDistance: 10,
+//		MinScore:    0.6,
+//		NameSimilarity: func(i, j int) float64 {
+//			if osmNames[i] == cadastreNames[j] {
+//				return 1
+//			}
+//			return 0
+//		},
+//	})
+func (s *Service) MatchFeatures(layerA, layerB []*Geometry, opts MatchOptions) ([]FeatureMatch, error) {
+	if !(opts.MaxDistance > 0) || math.IsInf(opts.MaxDistance, 0) {
+		return nil, fmt.Errorf("max distance must be positive, got %g", opts.MaxDistance)
+	}
+	if opts.OverlapWeight < 0 || opts.DistanceWeight < 0 || opts.NameWeight < 0 {
+		return nil, fmt.Errorf("match weights must not be negative")
+	}
+	for i, geom := range layerA {
+		if geom == nil || geom.geom == nil {
+			return nil, fmt.Errorf("invalid geometry at layer A index %d", i)
+		}
+	}
+	for i, geom := range layerB {
+		if geom == nil || geom.geom == nil {
+			return nil, fmt.Errorf("invalid geometry at layer B index %d", i)
+		}
+	}
+
+	matches, err := s.matchCandidates(layerA, layerB, opts.MaxDistance)
+	if err != nil {
+		return nil, err
+	}
+
+	overlapWeight, distanceWeight, nameWeight := opts.OverlapWeight, opts.DistanceWeight, opts.NameWeight
+	if overlapWeight == 0 && distanceWeight == 0 && nameWeight == 0 {
+		overlapWeight, distanceWeight, nameWeight = 1, 1, 1
+	}
+
+	scored := matches[:0]
+	for _, m := range matches {
+		total := distanceWeight * math.Max(0, 1-m.HausdorffDistance/opts.MaxDistance)
+		weight := distanceWeight
+		if m.HasOverlap {
+			total += overlapWeight * m.Overlap
+			weight += overlapWeight
+		}
+		if opts.NameSimilarity != nil {
+			m.NameSimilarity = math.Min(1, math.Max(0, opts.NameSimilarity(m.IndexA, m.IndexB)))
+			total += nameWeight * m.NameSimilarity
+			weight += nameWeight
+		}
+		if weight > 0 {
+			m.Score = total / weight
+		}
+		if m.Score >= opts.MinScore {
+			scored = append(scored, m)
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].IndexA != scored[j].IndexA {
+			return scored[i].IndexA < scored[j].IndexA
+		}
+		return scored[i].Score > scored[j].Score
+	})
+
+	return scored, nil
+}
+
+// matchCandidates finds the pairs within maxDistance of each other and measures
+// their geometric similarity. The name callback is applied by the caller after
+// the lock has been released.
+func (s *Service) matchCandidates(layerA, layerB []*Geometry, maxDistance float64) ([]FeatureMatch, error) {
+	c, err := s.acquire(layerA...)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+	if err := c.checkState(layerB...); err != nil {
+		return nil, err
+	}
+
+	indexesB := make([]int, 0, len(layerB))
+	envelopesB := make([]envelope, 0, len(layerB))
+	for j, geom := range layerB {
+		env, ok, err := c.envelopeOf(geom.geom)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			indexesB = append(indexesB, j)
+			envelopesB = append(envelopesB, env)
+		}
+	}
+
+	var matches []FeatureMatch
+	for i, a := range layerA {
+		env, ok, err := c.envelopeOf(a.geom)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		search := env.expand(maxDistance)
+
+		for k, envB := range envelopesB {
+			if !search.intersects(envB) {
+				continue
+			}
+			b := layerB[indexesB[k]]
+
+			within := C.GEOSDistanceWithin_r(c.context, a.geom, b.geom, C.double(maxDistance))
+			if within == 2 {
+				return nil, c.geosError("GEOS distance within operation failed")
+			}
+			if within == 0 {
+				continue
+			}
+
+			m := FeatureMatch{IndexA: i, IndexB: indexesB[k]}
+
+			var hausdorff C.double
+			if C.GEOSHausdorffDistance_r(c.context, a.geom, b.geom, &hausdorff) == 0 {
+				return nil, c.geosError("failed to calculate Hausdorff distance")
+			}
+			m.HausdorffDistance = float64(hausdorff)
+
+			if C.GEOSGeom_getDimensions_r(c.context, a.geom) == 2 && C.GEOSGeom_getDimensions_r(c.context, b.geom) == 2 {
+				overlap, err := c.overlapRatio(a.geom, b.geom)
+				if err != nil {
+					return nil, err
+				}
+				m.Overlap = overlap
+				m.HasOverlap = true
+			}
+
+			matches = append(matches, m)
+		}
+	}
+
+	return matches, nil
+}
+
+// overlapRatio returns the intersection over union of two polygonal geometries.
+func (c *session) overlapRatio(a, b *C.GEOSGeometry) (float64, error) {
+	var areaA, areaB, areaI C.double
+	if C.GEOSArea_r(c.context, a, &areaA) == 0 || C.GEOSArea_r(c.context, b, &areaB) == 0 {
+		return 0, c.geosError("failed to calculate area")
+	}
+
+	intersection := C.GEOSIntersection_r(c.context, a, b)
+	if intersection == nil {
+		return 0, c.geosError("failed to compute intersection")
+	}
+	ok := C.GEOSArea_r(c.context, intersection, &areaI)
+	C.GEOSGeom_destroy_r(c.context, intersection)
+	if ok == 0 {
+		return 0, c.geosError("failed to calculate area")
+	}
+
+	union := float64(areaA + areaB - areaI)
+	if union <= 0 {
+		return 0, nil
+	}
+	return float64(areaI) / union, nil
+}
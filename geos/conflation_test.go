@@ -0,0 +1,173 @@
+package geos
+
+import (
+	"math"
+	"testing"
+)
+
+// TestSnap tests snapping a geometry to a reference geometry
+func TestSnap(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name      string
+		geom      string
+		reference string
+		tolerance float64
+		expected  string
+	}{
+		{"Vertex within tolerance", "LINESTRING(0 0.05, 10 0)", "POINT(0 0)", 0.1, "LINESTRING (0 0, 10 0)"},
+		{"Vertex beyond tolerance", "LINESTRING(0 0.5, 10 0)", "POINT(0 0)", 0.1, "LINESTRING (0 0.5, 10 0)"},
+		{"Polygon onto polygon", "POLYGON((0 0, 1 0, 1 1.01, 0 1, 0 0))", "POLYGON((0 1, 1 1, 1 2, 0 2, 0 1))", 0.05, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.geom)
+			reference := helper.ParseWKT(tc.reference)
+
+			snapped, err := helper.service.Snap(geom, reference, tc.tolerance)
+			if err != nil {
+				t.Fatalf("Failed to snap: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(snapped, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	point := helper.PointGeometry()
+	if _, err := helper.service.Snap(point, point, 0); err == nil {
+		t.Error("Expected error for zero tolerance")
+	}
+	if _, err := helper.service.Snap(nil, point, 1); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestSnapLayer tests snapping an edit layer to a reference layer
+func TestSnapLayer(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	edit := []*Geometry{
+		helper.ParseWKT("LINESTRING(0 0.1, 10 -0.1)"),
+		helper.ParseWKT("LINESTRING(0 0.1, 5 0.2, 10 0.1)"),
+		helper.ParseWKT("POINT(100 100)"),
+	}
+	reference := []*Geometry{
+		helper.ParseWKT("LINESTRING(0 0, 10 0)"),
+		helper.ParseWKT("POINT(0 0)"),
+		helper.ParseWKT("POINT(10 0)"),
+		helper.ParseWKT("POINT(50 50)"),
+	}
+
+	snapped, err := helper.service.SnapLayer(edit, reference, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to snap layer: %v", err)
+	}
+	if len(snapped) != len(edit) {
+		t.Fatalf("Expected %d geometries, got %d", len(edit), len(snapped))
+	}
+
+	expected := []string{
+		"LINESTRING (0 0, 10 0)",
+		"LINESTRING (0 0, 5 0.2, 10 0)",
+		"POINT (100 100)",
+	}
+	for i, want := range expected {
+		wkt, err := helper.service.ToWKTWithOptions(snapped[i], WKTOptions{Trim: true})
+		if err != nil {
+			t.Fatalf("Failed to convert to WKT: %v", err)
+		}
+		if wkt != want {
+			t.Errorf("Geometry %d: expected %s, got %s", i, want, wkt)
+		}
+	}
+
+	// The reference layer must survive being borrowed into a collection
+	helper.AssertToWKT(reference[0])
+
+	if _, err := helper.service.SnapLayer(edit, reference, 0); err == nil {
+		t.Error("Expected error for zero tolerance")
+	}
+	if _, err := helper.service.SnapLayer([]*Geometry{nil}, reference, 1); err == nil {
+		t.Error("Expected error for nil edit geometry")
+	}
+	if _, err := helper.service.SnapLayer(edit, []*Geometry{nil}, 1); err == nil {
+		t.Error("Expected error for nil reference geometry")
+	}
+}
+
+// TestMatchFeatures tests scoring candidate matches between two datasets
+func TestMatchFeatures(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	layerA := []*Geometry{
+		helper.ParseWKT("POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))"),
+		helper.ParseWKT("POINT(500 500)"),
+	}
+	layerB := []*Geometry{
+		helper.ParseWKT("POLYGON((5 0, 15 0, 15 10, 5 10, 5 0))"),
+		helper.ParseWKT("POLYGON((1 0, 11 0, 11 10, 1 10, 1 0))"),
+		helper.ParseWKT("POLYGON((100 100, 110 100, 110 110, 100 110, 100 100))"),
+	}
+
+	matches, err := helper.service.MatchFeatures(layerA, layerB, MatchOptions{MaxDistance: 10})
+	if err != nil {
+		t.Fatalf("Failed to match features: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("Expected 2 candidates, got %d: %+v", len(matches), matches)
+	}
+
+	best := matches[0]
+	if best.IndexA != 0 || best.IndexB != 1 {
+		t.Errorf("Expected best match (0, 1), got (%d, %d)", best.IndexA, best.IndexB)
+	}
+	if !best.HasOverlap || math.Abs(best.Overlap-90.0/110.0) > 1e-9 {
+		t.Errorf("Expected overlap %g, got %g", 90.0/110.0, best.Overlap)
+	}
+	if math.Abs(best.HausdorffDistance-1) > 1e-9 {
+		t.Errorf("Expected Hausdorff distance 1, got %g", best.HausdorffDistance)
+	}
+	if expected := (0.9 + 90.0/110.0) / 2; math.Abs(best.Score-expected) > 1e-9 {
+		t.Errorf("Expected score %g, got %g", expected, best.Score)
+	}
+	if matches[1].Score >= best.Score {
+		t.Errorf("Expected candidates in descending score order, got %+v", matches)
+	}
+
+	// A name callback can outweigh the geometric evidence
+	matches, err = helper.service.MatchFeatures(layerA, layerB, MatchOptions{
+		MaxDistance: 10,
+		MinScore:    0.5,
+		NameWeight:  10,
+		NameSimilarity: func(i, j int) float64 {
+			if j == 0 {
+				return 1
+			}
+			return 0
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to match features: %v", err)
+	}
+	if len(matches) != 1 || matches[0].IndexB != 0 || matches[0].NameSimilarity != 1 {
+		t.Errorf("Expected a single name-driven match with feature 0, got %+v", matches)
+	}
+
+	if _, err := helper.service.MatchFeatures(layerA, layerB, MatchOptions{}); err == nil {
+		t.Error("Expected error for missing max distance")
+	}
+	if _, err := helper.service.MatchFeatures(layerA, []*Geometry{nil}, MatchOptions{MaxDistance: 1}); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,282 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// NewPoint builds a 2D Point directly from its coordinates. Unlike
+// formatting WKT for ParseGeometry, no text is produced or parsed, so it is
+// faster and every float64 value is kept exactly.
+//
+// Parameters:
+//   - x, y: The coordinates of the point
+//
+// Returns:
+//   - *Geometry: A new Point
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	stop, err := service.NewPoint(28.9784, 41.0082)
+func (s *Service) NewPoint(x, y float64) (*Geometry, error) {
+	return s.fromShape("NewPoint", nil, &shape{typeID: C.GEOS_POINT, coords: []float64{x, y}})
+}
+
+// NewPointZ builds a 3D Point directly from its coordinates, as NewPoint.
+//
+// Parameters:
+//   - x, y, z: The coordinates of the point
+//
+// Returns:
+//   - *Geometry: A new Point with a Z value
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	summit, err := service.NewPointZ(86.925, 27.988, 8849)
+func (s *Service) NewPointZ(x, y, z float64) (*Geometry, error) {
+	return s.fromShape("NewPointZ", nil, &shape{typeID: C.GEOS_POINT, hasZ: true, coords: []float64{x, y, z}})
+}
+
+// NewLineString builds a LineString directly from coordinates, e.g. the
+// fixes of a GPS track, filling a coordinate sequence instead of formatting
+// and parsing WKT. Coordinates are [x y], [x y z] or [x y z m], all of the
+// same length.
+//
+// Parameters:
+//   - coords: At least 2 coordinates
+//
+// Returns:
+//   - *Geometry: A new LineString
+//   - error: An error if there are too few coordinates or their lengths differ
+//
+// Example:
+//
+//	track, err := service.NewLineString([][]float64{
+//		{28.9784, 41.0082, 40},
+//		{28.9790, 41.0091, 42},
+//		{28.9801, 41.0097, 45},
+//	})
+func (s *Service) NewLineString(coords [][]float64) (*Geometry, error) {
+	if len(coords) < 2 {
+		return nil, fmt.Errorf("a LineString needs at least 2 coordinates, got %d", len(coords))
+	}
+
+	sh, err := coordinateShape(C.GEOS_LINESTRING, coords)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.fromShape("NewLineString", nil, sh)
+}
+
+// NewPolygon builds a Polygon directly from its shell and optional holes,
+// without assembling WKT. Every ring must be closed, i.e. end with its first
+// coordinate, and have at least 4 coordinates; the error names the ring at
+// fault. Rings must have the same coordinate dimension.
+//
+// Parameters:
+//   - shell: The exterior ring
+//   - holes: The interior rings, if any
+//
+// Returns:
+//   - *Geometry: A new Polygon
+//   - error: An error if a ring is empty, unclosed or too short
+//
+// Example:
+//
+//	footprint, err := service.NewPolygon(
+//		[][]float64{{0, 0}, {20, 0}, {20, 10}, {0, 10}, {0, 0}},
+//		[][]float64{{8, 4}, {12, 4}, {12, 6}, {8, 6}, {8, 4}},
+//	)
+func (s *Service) NewPolygon(shell [][]float64, holes ...[][]float64) (*Geometry, error) {
+	if len(shell) == 0 {
+		return nil, errors.New("polygon shell has no coordinates")
+	}
+	for i, hole := range holes {
+		if len(hole) == 0 {
+			return nil, fmt.Errorf("polygon hole %d has no coordinates", i)
+		}
+	}
+
+	sh, err := polygonShape(append([][][]float64{shell}, holes...))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.fromShape("NewPolygon", nil, sh)
+}
+
+// NewMultiPoint assembles points into a MultiPoint. Like the other
+// collection constructors, it copies its inputs into the collection, so they
+// stay owned by the caller and remain usable. No inputs build an empty
+// collection.
+//
+// Parameters:
+//   - points: The Points to assemble
+//
+// Returns:
+//   - *Geometry: A new MultiPoint
+//   - error: An error if an input is not a Point or the operation fails
+//
+// Example:
+//
+//	a, _ := service.NewPoint(0, 0)
+//	b, _ := service.NewPoint(5, 5)
+//	stops, err := service.NewMultiPoint(a, b)
+func (s *Service) NewMultiPoint(points ...*Geometry) (*Geometry, error) {
+	return s.newCollection("NewMultiPoint", C.GEOS_MULTIPOINT, TypePoint, (*Geometry).IsPoint, points)
+}
+
+// NewMultiLineString assembles LineStrings or LinearRings into a
+// MultiLineString, copying them as NewMultiPoint does.
+//
+// Parameters:
+//   - lines: The LineStrings to assemble
+//
+// Returns:
+//   - *Geometry: A new MultiLineString
+//   - error: An error if an input is not a LineString or the operation fails
+func (s *Service) NewMultiLineString(lines ...*Geometry) (*Geometry, error) {
+	return s.newCollection("NewMultiLineString", C.GEOS_MULTILINESTRING, TypeLineString, (*Geometry).IsLineString, lines)
+}
+
+// NewMultiPolygon assembles polygons into a MultiPolygon, copying them as
+// NewMultiPoint does. The polygons are not merged; use Union to dissolve
+// overlapping ones.
+//
+// Parameters:
+//   - polygons: The Polygons to assemble
+//
+// Returns:
+//   - *Geometry: A new MultiPolygon
+//   - error: An error if an input is not a Polygon or the operation fails
+//
+// Example:
+//
+//	buildings, err := service.NewMultiPolygon(footprints...)
+//	affected, _ := service.Intersects(buildings, floodZone)
+func (s *Service) NewMultiPolygon(polygons ...*Geometry) (*Geometry, error) {
+	return s.newCollection("NewMultiPolygon", C.GEOS_MULTIPOLYGON, TypePolygon, (*Geometry).IsPolygon, polygons)
+}
+
+// NewGeometryCollection assembles geometries of any type, including other
+// collections, into a GeometryCollection, copying them as NewMultiPoint does.
+//
+// Parameters:
+//   - geoms: The geometries to assemble
+//
+// Returns:
+//   - *Geometry: A new GeometryCollection
+//   - error: An error if an input is invalid or the operation fails
+func (s *Service) NewGeometryCollection(geoms ...*Geometry) (*Geometry, error) {
+	return s.newCollection("NewGeometryCollection", C.GEOS_GEOMETRYCOLLECTION, TypeUnknown, nil, geoms)
+}
+
+// newCollection copies geometries into a new collection of the given type.
+// accepts, if set, restricts the inputs to the part type.
+func (s *Service) newCollection(method string, typeID C.int, part GeometryType, accepts func(*Geometry) bool, geoms []*Geometry) (*Geometry, error) {
+	for i, g := range geoms {
+		if g == nil || g.geom == nil {
+			return nil, fmt.Errorf("invalid geometry at index %d", i)
+		}
+		if accepts != nil && !accepts(g) {
+			return nil, fmt.Errorf("geometry at index %d must be a %v, got %v", i, part, g.Type())
+		}
+	}
+
+	c, err := s.acquire(geoms...)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	if len(geoms) == 0 {
+		empty := C.GEOSGeom_createEmptyCollection_r(c.context, typeID)
+		if empty == nil {
+			return nil, c.geosError("failed to create collection")
+		}
+		return c.newRecordedGeometry(method, nil, empty), nil
+	}
+
+	// The collection takes ownership of its parts, so it gets copies
+	parts := make([]*C.GEOSGeometry, 0, len(geoms))
+	for _, g := range geoms {
+		copied := C.GEOSGeom_clone_r(c.context, g.geom)
+		if copied == nil {
+			c.destroyAll(parts)
+			return nil, c.geosError("failed to copy geometry")
+		}
+		parts = append(parts, copied)
+	}
+
+	collection := C.GEOSGeom_createCollection_r(c.context, typeID, &parts[0], C.uint(len(parts)))
+	if collection == nil {
+		c.destroyAll(parts)
+		return nil, c.geosError("failed to create collection")
+	}
+
+	return c.newDerivedGeometry(method, nil, collection, geoms...), nil
+}
+
+// Empty builds an empty geometry of the given type, e.g. as the neutral
+// starting value of a fold or as a placeholder result. Operations return
+// such typed empty geometries, never nil, when nothing is left, as for a
+// negative Buffer that shrinks a polygon away or a Difference that removes
+// all of it; IsEmpty tells them apart.
+//
+// Parameters:
+//   - geomType: The type of the geometry, any type but TypeUnknown
+//
+// Returns:
+//   - *Geometry: A new empty geometry
+//   - error: An error if the type is unknown or the operation fails
+//
+// Example:
+//
+//	result, err := service.Empty(geos.TypeMultiPolygon)
+//	// result will be MULTIPOLYGON EMPTY
+func (s *Service) Empty(geomType GeometryType) (*Geometry, error) {
+	typeID, ok := geomType.typeID()
+	if !ok {
+		return nil, fmt.Errorf("cannot build an empty %v", geomType)
+	}
+
+	return s.fromShape("Empty", map[string]any{"geomType": geomType.String()}, &shape{typeID: typeID})
+}
+
+// Box builds the closed rectangular polygon covering a bounding box, e.g. a
+// query window or map viewport, without assembling ring coordinates by hand.
+// The shell runs counter-clockwise from (minX, minY).
+//
+// Parameters:
+//   - minX, minY, maxX, maxY: The bounding box, with minX < maxX and minY < maxY
+//
+// Returns:
+//   - *Geometry: A new Polygon
+//   - error: An error if the box is empty, inverted or not finite
+//
+// Example:
+//
+//	viewport, err := service.Box(28.90, 40.98, 29.05, 41.06)
+//	visible, _ := service.Intersects(viewport, parcel)
+func (s *Service) Box(minX, minY, maxX, maxY float64) (*Geometry, error) {
+	for _, v := range []float64{minX, minY, maxX, maxY} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.New("box coordinates must be finite")
+		}
+	}
+	if minX >= maxX || minY >= maxY {
+		return nil, fmt.Errorf("invalid box: (%g %g, %g %g) has no area", minX, minY, maxX, maxY)
+	}
+
+	return s.rectangle("Box", map[string]any{"minX": minX, "minY": minY, "maxX": maxX, "maxY": maxY}, minX, minY, maxX, maxY)
+}
@@ -0,0 +1,319 @@
+package geos
+
+import (
+	"math"
+	"testing"
+)
+
+// TestNewPoint tests building points from coordinates
+func TestNewPoint(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		build    func() (*Geometry, error)
+		expected []float64
+	}{
+		{"2D", func() (*Geometry, error) { return helper.service.NewPoint(1.5, -2) }, []float64{1.5, -2}},
+		{"3D", func() (*Geometry, error) { return helper.service.NewPointZ(1, 2, 3) }, []float64{1, 2, 3}},
+		{"Extreme values", func() (*Geometry, error) {
+			return helper.service.NewPoint(math.MaxFloat64, math.SmallestNonzeroFloat64)
+		}, []float64{math.MaxFloat64, math.SmallestNonzeroFloat64}},
+		{"Non-terminating decimals", func() (*Geometry, error) { return helper.service.NewPoint(0.1, 1.0/3) }, []float64{0.1, 1.0 / 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			point, err := tc.build()
+			if err != nil {
+				t.Fatalf("Failed to build point: %v", err)
+			}
+			if !point.IsPoint() {
+				t.Fatalf("Expected a Point, got %v", point.Type())
+			}
+
+			// The coordinates survive without any rounding
+			coords, err := helper.service.Coordinates(point)
+			if err != nil {
+				t.Fatalf("Failed to get coordinates: %v", err)
+			}
+			if len(coords) != 1 || len(coords[0]) != len(tc.expected) {
+				t.Fatalf("Expected one coordinate %v, got %v", tc.expected, coords)
+			}
+			for i, v := range tc.expected {
+				if coords[0][i] != v {
+					t.Errorf("Ordinate %d: expected %v, got %v", i, v, coords[0][i])
+				}
+			}
+		})
+	}
+}
+
+// TestNewLineString tests building lines from coordinate slices
+func TestNewLineString(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		coords   [][]float64
+		expected string
+	}{
+		{"2D", [][]float64{{0, 0}, {1, 1}, {2, 0}}, "LINESTRING (0 0, 1 1, 2 0)"},
+		{"3D", [][]float64{{0, 0, 40}, {1, 1, 42}}, "LINESTRING Z (0 0 40, 1 1 42)"},
+		{"Measured", [][]float64{{0, 0, 5, 100}, {3, 4, 6, 105}}, "LINESTRING ZM (0 0 5 100, 3 4 6 105)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			line, err := helper.service.NewLineString(tc.coords)
+			if err != nil {
+				t.Fatalf("Failed to build line: %v", err)
+			}
+			wkt, err := helper.service.ToWKTWithOptions(line, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	errorCases := map[string][][]float64{
+		"No coordinates":    nil,
+		"Single coordinate": {{0, 0}},
+		"Mixed dimensions":  {{0, 0}, {1, 1, 1}},
+		"Short coordinate":  {{0, 0}, {1}},
+	}
+	for name, coords := range errorCases {
+		if _, err := helper.service.NewLineString(coords); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// TestNewPolygon tests building polygons from rings
+func TestNewPolygon(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	shell := [][]float64{{0, 0}, {20, 0}, {20, 10}, {0, 10}, {0, 0}}
+	hole := [][]float64{{8, 4}, {12, 4}, {12, 6}, {8, 6}, {8, 4}}
+
+	testCases := []struct {
+		name     string
+		shell    [][]float64
+		holes    [][][]float64
+		expected string
+	}{
+		{"Shell only", shell, nil, "POLYGON ((0 0, 20 0, 20 10, 0 10, 0 0))"},
+		{"With hole", shell, [][][]float64{hole}, "POLYGON ((0 0, 20 0, 20 10, 0 10, 0 0), (8 4, 12 4, 12 6, 8 6, 8 4))"},
+		{"3D", [][]float64{{0, 0, 1}, {1, 0, 1}, {1, 1, 1}, {0, 0, 1}}, nil, "POLYGON Z ((0 0 1, 1 0 1, 1 1 1, 0 0 1))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			polygon, err := helper.service.NewPolygon(tc.shell, tc.holes...)
+			if err != nil {
+				t.Fatalf("Failed to build polygon: %v", err)
+			}
+			wkt, err := helper.service.ToWKTWithOptions(polygon, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	errorCases := []struct {
+		name  string
+		shell [][]float64
+		holes [][][]float64
+	}{
+		{"Empty shell", nil, nil},
+		{"Unclosed shell", [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}}, nil},
+		{"Too few coordinates", [][]float64{{0, 0}, {1, 0}, {0, 0}}, nil},
+		{"Unclosed hole", shell, [][][]float64{{{8, 4}, {12, 4}, {12, 6}, {8, 6}}}},
+		{"Empty hole", shell, [][][]float64{nil}},
+		{"Mixed dimensions", shell, [][][]float64{{{8, 4, 0}, {12, 4, 0}, {12, 6, 0}, {8, 4, 0}}}},
+	}
+	for _, tc := range errorCases {
+		if _, err := helper.service.NewPolygon(tc.shell, tc.holes...); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
+
+// TestNewCollections tests assembling geometries into collections
+func TestNewCollections(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	a := helper.ParseWKT("POINT(0 0)")
+	b := helper.ParseWKT("POINT(5 5)")
+	line := helper.ParseWKT("LINESTRING(0 0, 1 1)")
+	ring := helper.ParseWKT("LINEARRING(0 0, 1 0, 1 1, 0 0)")
+	square := helper.ParseWKT("POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))")
+	triangle := helper.ParseWKT("POLYGON((5 5, 6 5, 6 6, 5 5))")
+
+	testCases := []struct {
+		name     string
+		build    func() (*Geometry, error)
+		expected string
+	}{
+		{"MultiPoint", func() (*Geometry, error) { return helper.service.NewMultiPoint(a, b) }, "MULTIPOINT ((0 0), (5 5))"},
+		{"MultiLineString", func() (*Geometry, error) { return helper.service.NewMultiLineString(line, ring) }, "MULTILINESTRING ((0 0, 1 1), (0 0, 1 0, 1 1, 0 0))"},
+		{"MultiPolygon", func() (*Geometry, error) { return helper.service.NewMultiPolygon(square, triangle) },
+			"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))"},
+		{"GeometryCollection", func() (*Geometry, error) {
+			points, err := helper.service.NewMultiPoint(a, b)
+			if err != nil {
+				return nil, err
+			}
+			return helper.service.NewGeometryCollection(line, points, square)
+		}, "GEOMETRYCOLLECTION (LINESTRING (0 0, 1 1), MULTIPOINT ((0 0), (5 5)), POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0)))"},
+		{"Empty", func() (*Geometry, error) { return helper.service.NewMultiPolygon() }, "MULTIPOLYGON EMPTY"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			collection, err := tc.build()
+			if err != nil {
+				t.Fatalf("Failed to build collection: %v", err)
+			}
+			wkt, err := helper.service.ToWKTWithOptions(collection, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	// The inputs remain owned by the caller
+	if wkt, err := helper.service.ToWKTWithOptions(a, WKTOptions{Trim: true}); err != nil || wkt != "POINT (0 0)" {
+		t.Errorf("Expected the input point to remain usable, got %s (%v)", wkt, err)
+	}
+
+	if _, err := helper.service.NewMultiPoint(a, line); err == nil {
+		t.Error("Expected error for a LineString in a MultiPoint")
+	}
+	if _, err := helper.service.NewMultiPolygon(square, nil); err == nil {
+		t.Error("Expected error for a nil geometry")
+	}
+}
+
+// TestEmpty tests building empty geometries and the empty results of operations
+func TestEmpty(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		geomType GeometryType
+		expected string
+	}{
+		{TypePoint, "POINT EMPTY"},
+		{TypeLineString, "LINESTRING EMPTY"},
+		{TypeLinearRing, "LINEARRING EMPTY"},
+		{TypePolygon, "POLYGON EMPTY"},
+		{TypeMultiPoint, "MULTIPOINT EMPTY"},
+		{TypeMultiLineString, "MULTILINESTRING EMPTY"},
+		{TypeMultiPolygon, "MULTIPOLYGON EMPTY"},
+		{TypeGeometryCollection, "GEOMETRYCOLLECTION EMPTY"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			empty, err := helper.service.Empty(tc.geomType)
+			if err != nil {
+				t.Fatalf("Failed to build empty geometry: %v", err)
+			}
+			if empty.Type() != tc.geomType {
+				t.Errorf("Expected type %v, got %v", tc.geomType, empty.Type())
+			}
+			wkt, err := helper.service.ToWKTWithOptions(empty, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.Empty(TypeUnknown); err == nil {
+		t.Error("Expected error for TypeUnknown")
+	}
+
+	// Operations that leave nothing return empty geometries rather than nil
+	square := helper.ParseWKT("POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))")
+	shrunk, err := helper.service.Buffer(square, -5)
+	if err != nil {
+		t.Fatalf("Failed to buffer: %v", err)
+	}
+	removed, err := helper.service.Difference(square, helper.ParseWKT("POLYGON((-1 -1, 3 -1, 3 3, -1 3, -1 -1))"))
+	if err != nil {
+		t.Fatalf("Failed to compute difference: %v", err)
+	}
+	for name, result := range map[string]*Geometry{"Buffer": shrunk, "Difference": removed} {
+		if result == nil {
+			t.Fatalf("%s: expected an empty geometry, got nil", name)
+		}
+		if empty, err := helper.service.IsEmpty(result); err != nil || !empty {
+			t.Errorf("%s: expected an empty result, got %v (%v)", name, empty, err)
+		}
+	}
+
+	// Union skips nil entries but needs at least one geometry
+	union, err := helper.service.Union([]*Geometry{nil, square})
+	if err != nil || union == nil {
+		t.Errorf("Expected the union of a nil and a square to succeed, got %v", err)
+	}
+	if _, err := helper.service.Union([]*Geometry{nil}); err == nil {
+		t.Error("Expected error for a union of nil geometries")
+	}
+}
+
+// TestBox tests building rectangular polygons from bounds
+func TestBox(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	box, err := helper.service.Box(-1, 2, 3, 5.5)
+	if err != nil {
+		t.Fatalf("Failed to build box: %v", err)
+	}
+	wkt, err := helper.service.ToWKTWithOptions(box, WKTOptions{Trim: true})
+	if err != nil {
+		t.Fatalf("Failed to convert to WKT: %v", err)
+	}
+	if want := "POLYGON ((-1 2, 3 2, 3 5.5, -1 5.5, -1 2))"; wkt != want {
+		t.Errorf("Expected %s, got %s", want, wkt)
+	}
+
+	minX, minY, maxX, maxY, err := box.Bounds()
+	if err != nil {
+		t.Fatalf("Failed to get bounds: %v", err)
+	}
+	if minX != -1 || minY != 2 || maxX != 3 || maxY != 5.5 {
+		t.Errorf("Expected bounds (-1 2, 3 5.5), got (%g %g, %g %g)", minX, minY, maxX, maxY)
+	}
+
+	errorCases := map[string][4]float64{
+		"Inverted X": {3, 0, 1, 1},
+		"Inverted Y": {0, 3, 1, 1},
+		"Zero width": {1, 0, 1, 1},
+		"NaN":        {0, 0, math.NaN(), 1},
+		"Infinite":   {math.Inf(-1), 0, 1, 1},
+	}
+	for name, b := range errorCases {
+		if _, err := helper.service.Box(b[0], b[1], b[2], b[3]); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Coordinates returns every coordinate of a geometry in order, visiting the
+// rings of polygons shell first and the components of collections in turn.
+// Each coordinate holds X and Y, followed by Z and M when the geometry has
+// them. The result is plain Go data, ready for plotting, numeric libraries or
+// custom algorithms without a text round trip. A geometry with M but no Z is
+// rejected, since its [x y m] coordinates would read back as XYZ.
+//
+// Parameters:
+//   - geom: The geometry to read
+//
+// Returns:
+//   - [][]float64: The coordinates, empty for an empty geometry
+//   - error: An error if the geometry has M but no Z or the operation fails
+//
+// Example:
+//
+//	line := GeometryInput{WKT: "LINESTRING(0 0, 1 2, 3 4)"}
+//	geom, _ := service.ParseGeometry(line)
+//
+//	coords, err := service.Coordinates(geom)
+//	// coords will be [[0 0] [1 2] [3 4]]
+func (s *Service) Coordinates(geom *Geometry) ([][]float64, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	sh, err := s.shapeOf(geom)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLayout(sh); err != nil {
+		return nil, err
+	}
+
+	coords := make([][]float64, 0, sh.numCoordinates())
+	sh.visitCoordinates(func(c []float64) {
+		coords = append(coords, c)
+	})
+
+	return coords, nil
+}
+
+// Coordinates returns every coordinate of the geometry, see
+// Service.Coordinates.
+//
+// Returns:
+//   - [][]float64: The coordinates, empty for an empty geometry
+//   - error: An error if the geometry is invalid, has M but no Z or the operation fails
+func (g *Geometry) Coordinates() ([][]float64, error) {
+	if g == nil || g.service == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	return g.service.Coordinates(g)
+}
+
+// PolygonCoordinates returns the coordinates of a polygon ring by ring, the
+// shell first followed by the holes, as in a GeoJSON Polygon. Like
+// Coordinates, it rejects a polygon with M but no Z.
+//
+// Parameters:
+//   - polygon: The Polygon to read
+//
+// Returns:
+//   - [][][]float64: The rings, empty for an empty polygon
+//   - error: An error if the geometry is not a Polygon, has M but no Z or the operation fails
+//
+// Example:
+//
+//	rings, err := service.PolygonCoordinates(parcel)
+//	shell, holes := rings[0], rings[1:]
+func (s *Service) PolygonCoordinates(polygon *Geometry) ([][][]float64, error) {
+	if polygon == nil || polygon.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	if !polygon.IsPolygon() {
+		return nil, fmt.Errorf("geometry must be a Polygon, got %v", polygon.Type())
+	}
+
+	sh, err := s.shapeOf(polygon)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLayout(sh); err != nil {
+		return nil, err
+	}
+
+	rings := make([][][]float64, len(sh.parts))
+	for i, ring := range sh.parts {
+		rings[i] = make([][]float64, 0, ring.numCoordinates())
+		ring.visitCoordinates(func(c []float64) {
+			rings[i] = append(rings[i], c)
+		})
+	}
+
+	return rings, nil
+}
+
+// FromCoordinates builds a Point, LineString, LinearRing or MultiPoint from
+// coordinates in the form returned by Coordinates. Every coordinate must have
+// the same length: 2 for XY, 3 for XYZ or 4 for XYZM. No coordinates build an
+// empty geometry.
+//
+// Parameters:
+//   - geomType: TypePoint, TypeLineString, TypeLinearRing or TypeMultiPoint
+//   - coords: The coordinates
+//
+// Returns:
+//   - *Geometry: A new geometry
+//   - error: An error if the coordinates do not fit the type
+//
+// Example:
+//
+//	track, err := service.FromCoordinates(geos.TypeLineString, [][]float64{{0, 0}, {1, 2}, {3, 4}})
+func (s *Service) FromCoordinates(geomType GeometryType, coords [][]float64) (*Geometry, error) {
+	var sh *shape
+	var err error
+	switch geomType {
+	case TypePoint:
+		if len(coords) > 1 {
+			return nil, fmt.Errorf("a Point has at most one coordinate, got %d", len(coords))
+		}
+		sh, err = coordinateShape(C.GEOS_POINT, coords)
+	case TypeLineString:
+		if len(coords) == 1 {
+			return nil, errors.New("a LineString needs at least 2 coordinates, got 1")
+		}
+		sh, err = coordinateShape(C.GEOS_LINESTRING, coords)
+	case TypeLinearRing:
+		if err := checkRing(coords); err != nil {
+			return nil, err
+		}
+		sh, err = coordinateShape(C.GEOS_LINEARRING, coords)
+	case TypeMultiPoint:
+		if err := checkDimensions(coords); err != nil {
+			return nil, err
+		}
+		sh = &shape{typeID: C.GEOS_MULTIPOINT, parts: make([]*shape, len(coords))}
+		for i := range coords {
+			if sh.parts[i], err = coordinateShape(C.GEOS_POINT, coords[i:i+1]); err != nil {
+				return nil, err
+			}
+			sh.hasZ, sh.hasM = sh.parts[i].hasZ, sh.parts[i].hasM
+		}
+	default:
+		return nil, fmt.Errorf("cannot build a %v from a coordinate list", geomType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.fromShape("FromCoordinates", map[string]any{"geomType": geomType.String()}, sh)
+}
+
+// FromPolygonCoordinates builds a Polygon from rings in the form returned by
+// PolygonCoordinates: the shell followed by any holes. Each ring must be
+// closed and have at least 4 coordinates. No rings build an empty polygon.
+//
+// Parameters:
+//   - rings: The shell and holes
+//
+// Returns:
+//   - *Geometry: A new Polygon
+//   - error: An error if a ring is malformed
+//
+// Example:
+//
+//	square, err := service.FromPolygonCoordinates([][][]float64{
+//		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+//	})
+func (s *Service) FromPolygonCoordinates(rings [][][]float64) (*Geometry, error) {
+	sh, err := polygonShape(rings)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.fromShape("FromPolygonCoordinates", nil, sh)
+}
+
+// fromShape builds a new geometry from a shape, recorded as the named method
+func (s *Service) fromShape(method string, params map[string]any, sh *shape) (*Geometry, error) {
+	c, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	g, err := c.buildShape(sh)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newRecordedGeometry(method, params, g), nil
+}
+
+// polygonShape converts polygon rings to a shape, checking each ring
+func polygonShape(rings [][][]float64) (*shape, error) {
+	sh := &shape{typeID: C.GEOS_POLYGON, parts: make([]*shape, len(rings))}
+	for i, ring := range rings {
+		if err := checkRing(ring); err != nil {
+			return nil, fmt.Errorf("ring %d: %w", i, err)
+		}
+		part, err := coordinateShape(C.GEOS_LINEARRING, ring)
+		if err != nil {
+			return nil, fmt.Errorf("ring %d: %w", i, err)
+		}
+		sh.parts[i] = part
+	}
+
+	// Rings must agree on dimension, like the coordinates within a ring
+	for _, part := range sh.parts {
+		if part.stride() != sh.parts[0].stride() {
+			return nil, errors.New("rings have different coordinate dimensions")
+		}
+	}
+	if len(sh.parts) > 0 {
+		sh.hasZ, sh.hasM = sh.parts[0].hasZ, sh.parts[0].hasM
+	}
+
+	return sh, nil
+}
+
+// coordinateShape flattens coordinates into a shape with a single coordinate
+// sequence
+func coordinateShape(typeID C.int, coords [][]float64) (*shape, error) {
+	if err := checkDimensions(coords); err != nil {
+		return nil, err
+	}
+
+	sh := &shape{typeID: typeID}
+	if len(coords) == 0 {
+		return sh, nil
+	}
+	sh.hasZ = len(coords[0]) >= 3
+	sh.hasM = len(coords[0]) == 4
+
+	sh.coords = make([]float64, 0, len(coords)*len(coords[0]))
+	for _, c := range coords {
+		sh.coords = append(sh.coords, c...)
+	}
+
+	return sh, nil
+}
+
+// checkLayout rejects shapes with M but no Z. Coordinate slices tell the
+// layout by their length alone, so [x y m] would be taken for [x y z].
+func checkLayout(sh *shape) error {
+	if sh.hasM && !sh.hasZ {
+		return errors.New("geometry has M but no Z, which coordinate slices cannot represent")
+	}
+	return nil
+}
+
+// checkDimensions verifies that every coordinate has the same usable length
+func checkDimensions(coords [][]float64) error {
+	for i, c := range coords {
+		if len(c) < 2 || len(c) > 4 {
+			return fmt.Errorf("coordinate %d has %d ordinates, want 2, 3 or 4", i, len(c))
+		}
+		if len(c) != len(coords[0]) {
+			return fmt.Errorf("coordinate %d has %d ordinates, but the first has %d", i, len(c), len(coords[0]))
+		}
+	}
+	return nil
+}
+
+// checkRing verifies that ring coordinates are closed and enclose an area.
+// An empty ring is allowed.
+func checkRing(coords [][]float64) error {
+	if len(coords) == 0 {
+		return nil
+	}
+	if len(coords) < 4 {
+		return fmt.Errorf("a ring needs at least 4 coordinates, got %d", len(coords))
+	}
+	first, last := coords[0], coords[len(coords)-1]
+	if len(first) < 2 || len(last) < 2 || first[0] != last[0] || first[1] != last[1] {
+		return errors.New("ring is not closed: the first and last coordinates differ")
+	}
+	return nil
+}
+
+// visitCoordinates calls fn with a copy of every coordinate of the shape and
+// its parts, in order
+func (sh *shape) visitCoordinates(fn func(c []float64)) {
+	stride := sh.stride()
+	for i := 0; i+stride <= len(sh.coords); i += stride {
+		fn(append([]float64(nil), sh.coords[i:i+stride]...))
+	}
+	for _, part := range sh.parts {
+		part.visitCoordinates(fn)
+	}
+}
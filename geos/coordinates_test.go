@@ -0,0 +1,154 @@
+package geos
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCoordinates tests extracting coordinates into Go slices
+func TestCoordinates(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		wkt      string
+		expected [][]float64
+	}{
+		{"POINT(1 2)", [][]float64{{1, 2}}},
+		{"LINESTRING Z (0 0 5, 1 2 6)", [][]float64{{0, 0, 5}, {1, 2, 6}}},
+		{"POLYGON((0 0, 2 0, 2 2, 0 0))", [][]float64{{0, 0}, {2, 0}, {2, 2}, {0, 0}}},
+		{"GEOMETRYCOLLECTION(POINT(1 1), LINESTRING(2 2, 3 3))", [][]float64{{1, 1}, {2, 2}, {3, 3}}},
+		{"LINESTRING EMPTY", [][]float64{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			coords, err := helper.service.Coordinates(helper.ParseWKT(tc.wkt))
+			if err != nil {
+				t.Fatalf("Failed to get coordinates: %v", err)
+			}
+			if !reflect.DeepEqual(coords, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, coords)
+			}
+		})
+	}
+
+	rings, err := helper.service.PolygonCoordinates(helper.ParseWKT("POLYGON((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 1))"))
+	if err != nil {
+		t.Fatalf("Failed to get polygon coordinates: %v", err)
+	}
+	expected := [][][]float64{
+		{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+		{{1, 1}, {2, 1}, {2, 2}, {1, 1}},
+	}
+	if !reflect.DeepEqual(rings, expected) {
+		t.Errorf("Expected rings %v, got %v", expected, rings)
+	}
+	if _, err := helper.service.PolygonCoordinates(helper.LineGeometry()); err == nil {
+		t.Error("Expected error for non-polygon")
+	}
+}
+
+// TestCoordinatesLayout tests that coordinates read back with the ordinates
+// they were written with
+func TestCoordinatesLayout(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	coords, err := helper.ParseWKT("LINESTRING ZM (0 0 1 2, 1 1 3 4)").Coordinates()
+	if err != nil {
+		t.Fatalf("Failed to get coordinates: %v", err)
+	}
+	rebuilt, err := helper.service.FromCoordinates(TypeLineString, coords)
+	if err != nil {
+		t.Fatalf("Failed to build geometry: %v", err)
+	}
+	wkt, err := helper.service.ToWKTWithOptions(rebuilt, WKTOptions{Trim: true})
+	if err != nil {
+		t.Fatalf("Failed to convert to WKT: %v", err)
+	}
+	if want := "LINESTRING ZM (0 0 1 2, 1 1 3 4)"; wkt != want {
+		t.Errorf("Expected %s, got %s", want, wkt)
+	}
+
+	// [x y m] would be read back as [x y z]
+	if _, err := helper.ParseWKT("LINESTRING M (0 0 1, 1 1 2)").Coordinates(); err == nil {
+		t.Error("Expected error for M without Z")
+	}
+	if _, err := helper.service.PolygonCoordinates(helper.ParseWKT("POLYGON M ((0 0 1, 1 0 1, 1 1 1, 0 0 1))")); err == nil {
+		t.Error("Expected error for a polygon with M without Z")
+	}
+}
+
+// TestFromCoordinates tests building geometries from Go slices
+func TestFromCoordinates(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		geomType GeometryType
+		coords   [][]float64
+		expected string
+	}{
+		{"Point", TypePoint, [][]float64{{1, 2}}, "POINT (1 2)"},
+		{"Empty point", TypePoint, nil, "POINT EMPTY"},
+		{"LineString Z", TypeLineString, [][]float64{{0, 0, 1}, {1, 1, 2}}, "LINESTRING Z (0 0 1, 1 1 2)"},
+		{"LinearRing", TypeLinearRing, [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 0}}, "LINEARRING (0 0, 1 0, 1 1, 0 0)"},
+		{"MultiPoint", TypeMultiPoint, [][]float64{{0, 0}, {1, 1}}, "MULTIPOINT ((0 0), (1 1))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom, err := helper.service.FromCoordinates(tc.geomType, tc.coords)
+			if err != nil {
+				t.Fatalf("Failed to build geometry: %v", err)
+			}
+			wkt, err := helper.service.ToWKTWithOptions(geom, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	polygon, err := helper.service.FromPolygonCoordinates([][][]float64{
+		{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+		{{1, 1}, {2, 1}, {2, 2}, {1, 1}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build polygon: %v", err)
+	}
+	wkt, err := helper.service.ToWKTWithOptions(polygon, WKTOptions{Trim: true})
+	if err != nil {
+		t.Fatalf("Failed to convert to WKT: %v", err)
+	}
+	if want := "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 1))"; wkt != want {
+		t.Errorf("Expected %s, got %s", want, wkt)
+	}
+
+	errorCases := []struct {
+		name  string
+		build func() (*Geometry, error)
+	}{
+		{"Mixed dimensions", func() (*Geometry, error) {
+			return helper.service.FromCoordinates(TypeLineString, [][]float64{{0, 0}, {1, 1, 1}})
+		}},
+		{"Single coordinate line", func() (*Geometry, error) {
+			return helper.service.FromCoordinates(TypeLineString, [][]float64{{0, 0}})
+		}},
+		{"Unsupported type", func() (*Geometry, error) {
+			return helper.service.FromCoordinates(TypePolygon, [][]float64{{0, 0}})
+		}},
+		{"Unclosed ring", func() (*Geometry, error) {
+			return helper.service.FromPolygonCoordinates([][][]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}})
+		}},
+	}
+	for _, tc := range errorCases {
+		if _, err := tc.build(); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// meanEarthRadius is the mean radius of the WGS84 ellipsoid in meters, used
+// where the Earth is approximated by a sphere
+const meanEarthRadius = 6371008.8
+
+// Corridor returns the area within a distance of a route given in WGS84
+// longitude/latitude, answering questions like "what is within 500 m of this
+// route". The route is buffered in a local equirectangular projection centered
+// on it, so width is in meters, and the corridor is returned in longitude and
+// latitude. Distances are accurate to within a few percent for routes that
+// span up to several hundred kilometers away from the poles.
+//
+// Parameters:
+//   - route: The route in longitude/latitude, usually a LineString
+//   - width: The distance from the route in meters (must be positive)
+//
+// Returns:
+//   - *Geometry: The corridor polygon in longitude/latitude
+//   - error: An error if the width or coordinates are out of range, or the operation fails
+//
+// Example:
+//
+//	route := GeometryInput{WKT: "LINESTRING(28.97 41.01, 29.03 41.04)"}
+//	geom, _ := service.ParseGeometry(route)
+//
+//	corridor, err := service.Corridor(geom, 500)
+//	// corridor covers everything within 500 m of the route
+func (s *Service) Corridor(route *Geometry, width float64) (*Geometry, error) {
+	if route == nil || route.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if !(width > 0) || math.IsInf(width, 0) {
+		return nil, fmt.Errorf("corridor width must be positive, got %g", width)
+	}
+
+	c, err := s.acquire(route)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	corridor, err := c.corridor(route.geom, width)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newDerivedGeometry("Corridor", map[string]any{"width": width}, corridor, route), nil
+}
+
+// FeaturesInCorridor returns the indexes of the features that intersect the
+// corridor within width meters of a route. Features and route are in WGS84
+// longitude/latitude, as for Corridor. The corridor is built and prepared
+// once and features are first filtered by bounding box, so large feature sets
+// are tested quickly.
+//
+// Parameters:
+//   - features: The candidate features in longitude/latitude; nil entries are skipped
+//   - route: The route in longitude/latitude
+//   - width: The distance from the route in meters (must be positive)
+//
+// Returns:
+//   - []int: The indexes of the matching features in ascending order
+//   - error: An error if the inputs are invalid or the operation fails
+//
+// Example:
+//
+//	hits, err := service.FeaturesInCorridor(stations, route, 500)
+//	for _, i := range hits {
+//		fmt.Println(stationNames[i])
+//	}
+func (s *Service) FeaturesInCorridor(features []*Geometry, route *Geometry, width float64) ([]int, error) {
+	if route == nil || route.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	for i, feature := range features {
+		if feature != nil && feature.geom == nil {
+			return nil, fmt.Errorf("invalid geometry at index %d", i)
+		}
+	}
+
+	if !(width > 0) || math.IsInf(width, 0) {
+		return nil, fmt.Errorf("corridor width must be positive, got %g", width)
+	}
+
+	c, err := s.acquire(route)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+	if err := c.checkState(features...); err != nil {
+		return nil, err
+	}
+
+	corridor, err := c.corridor(route.geom, width)
+	if err != nil {
+		return nil, err
+	}
+	defer C.GEOSGeom_destroy_r(c.context, corridor)
+
+	env, _, err := c.envelopeOf(corridor)
+	if err != nil {
+		return nil, err
+	}
+
+	prepared := C.GEOSPrepare_r(c.context, corridor)
+	if prepared == nil {
+		return nil, c.geosError("failed to prepare corridor")
+	}
+	defer C.GEOSPreparedGeom_destroy_r(c.context, prepared)
+
+	hits := make([]int, 0)
+	for i, feature := range features {
+		if feature == nil {
+			continue
+		}
+		featureEnv, ok, err := c.envelopeOf(feature.geom)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || !env.intersects(featureEnv) {
+			continue
+		}
+		result := C.GEOSPreparedIntersects_r(c.context, prepared, feature.geom)
+		if result == 2 {
+			return nil, c.geosError(fmt.Sprintf("GEOS prepared intersects operation failed at index %d", i))
+		}
+		if result == 1 {
+			hits = append(hits, i)
+		}
+	}
+
+	return hits, nil
+}
+
+// corridor buffers a longitude/latitude geometry by width meters in a local
+// equirectangular projection. The caller owns the returned geometry.
+func (c *session) corridor(g *C.GEOSGeometry, width float64) (*C.GEOSGeometry, error) {
+	env, ok, err := c.envelopeOf(g)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("route is empty")
+	}
+	if env.minX < -180 || env.maxX > 180 || env.minY < -90 || env.maxY > 90 {
+		return nil, errors.New("route coordinates must be longitude/latitude in degrees")
+	}
+
+	lon0, lat0 := (env.minX+env.maxX)/2, (env.minY+env.maxY)/2
+	ky := meanEarthRadius * math.Pi / 180
+	kx := ky * math.Cos(lat0*math.Pi/180)
+	if kx < 1e-6*ky {
+		return nil, errors.New("route is too close to a pole for a local projection")
+	}
+
+	sh, err := c.readShape(g)
+	if err != nil {
+		return nil, err
+	}
+	sh.transformXY(func(x, y float64) (float64, float64) {
+		return (x - lon0) * kx, (y - lat0) * ky
+	})
+
+	projected, err := c.buildShape(sh)
+	if err != nil {
+		return nil, err
+	}
+	buffered := C.GEOSBuffer_r(c.context, projected, C.double(width), defaultQuadrantSegments)
+	C.GEOSGeom_destroy_r(c.context, projected)
+	if buffered == nil {
+		return nil, c.geosError("failed to buffer route")
+	}
+
+	sh, err = c.readShape(buffered)
+	C.GEOSGeom_destroy_r(c.context, buffered)
+	if err != nil {
+		return nil, err
+	}
+	sh.transformXY(func(x, y float64) (float64, float64) {
+		return lon0 + x/kx, lat0 + y/ky
+	})
+
+	return c.buildShape(sh)
+}
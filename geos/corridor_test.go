@@ -0,0 +1,70 @@
+package geos
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCorridor tests buffering routes in meters
+func TestCorridor(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	// 0.01 degrees of longitude at the equator is about 1112 m
+	route := helper.ParseWKT("LINESTRING(0 0, 0.01 0)")
+
+	corridor, err := helper.service.Corridor(route, 500)
+	if err != nil {
+		t.Fatalf("Failed to build corridor: %v", err)
+	}
+
+	testCases := []struct {
+		name   string
+		wkt    string
+		inside bool
+	}{
+		{"445 m beside the route", "POINT(0.005 0.004)", true},
+		{"556 m beside the route", "POINT(0.005 0.005)", false},
+		{"445 m beyond the end", "POINT(0.014 0)", true},
+		{"612 m beyond the end", "POINT(0.0155 0)", false},
+	}
+
+	features := make([]*Geometry, len(testCases))
+	var expected []int
+	for i, tc := range testCases {
+		features[i] = helper.ParseWKT(tc.wkt)
+		if tc.inside {
+			expected = append(expected, i)
+		}
+
+		t.Run(tc.name, func(t *testing.T) {
+			within, err := helper.service.Within(features[i], corridor)
+			if err != nil {
+				t.Fatalf("Failed to test within: %v", err)
+			}
+			if within != tc.inside {
+				t.Errorf("Expected within %v, got %v", tc.inside, within)
+			}
+		})
+	}
+
+	t.Run("FeaturesInCorridor", func(t *testing.T) {
+		hits, err := helper.service.FeaturesInCorridor(append(features, nil), route, 500)
+		if err != nil {
+			t.Fatalf("Failed to filter features: %v", err)
+		}
+		if !reflect.DeepEqual(hits, expected) {
+			t.Errorf("Expected %v, got %v", expected, hits)
+		}
+	})
+
+	if _, err := helper.service.Corridor(route, 0); err == nil {
+		t.Error("Expected error for zero width")
+	}
+	if _, err := helper.service.Corridor(helper.ParseWKT("LINESTRING(0 0, 500 0)"), 10); err == nil {
+		t.Error("Expected error for projected coordinates")
+	}
+	if _, err := helper.service.Corridor(nil, 10); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
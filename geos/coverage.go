@@ -0,0 +1,194 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// CoverageReport is the result of CoverageIsValid.
+type CoverageReport struct {
+	// Valid is true if the polygons form a valid coverage
+	Valid bool
+
+	// InvalidEdges holds, for each input polygon in order, the parts of its
+	// boundary that overlap a neighbor, do not match the shared edge of a
+	// neighbor exactly, or border a gap narrower than the gap width. It is
+	// an empty geometry for polygons without problems.
+	InvalidEdges []*Geometry
+
+	// Invalid holds the indexes of the polygons with invalid edges
+	Invalid []int
+}
+
+// CoverageIsValid checks whether polygons form a valid coverage: a set of
+// polygons such as parcels or administrative areas whose interiors do not
+// overlap and whose shared boundaries have exactly the same vertices. Coverage
+// operations like CoverageSimplify rely on this. Gaps between polygons are
+// allowed, but gaps narrower than gapWidth are reported as slivers.
+//
+// Parameters:
+//   - polygons: The Polygons and MultiPolygons of the coverage
+//   - gapWidth: The widest gap to report; 0 reports overlaps and mismatched
+//     edges only
+//
+// Returns:
+//   - CoverageReport: Whether the coverage is valid and where the problems are
+//   - error: An error if a geometry is not polygonal or the operation fails
+//
+// Example:
+//
+//	report, err := service.CoverageIsValid(parcels, 0.01)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, i := range report.Invalid {
+//		wkt, _ := service.ToWKT(report.InvalidEdges[i])
+//		fmt.Printf("parcel %d has invalid edges %s\n", i, wkt)
+//	}
+func (s *Service) CoverageIsValid(polygons []*Geometry, gapWidth float64) (CoverageReport, error) {
+	if gapWidth < 0 || math.IsNaN(gapWidth) || math.IsInf(gapWidth, 0) {
+		return CoverageReport{}, fmt.Errorf("gap width must not be negative, got %g", gapWidth)
+	}
+
+	c, err := s.acquire()
+	if err != nil {
+		return CoverageReport{}, err
+	}
+	defer c.release()
+
+	collection, err := c.coverageCollection(polygons)
+	if err != nil {
+		return CoverageReport{}, err
+	}
+
+	var edges *C.GEOSGeometry
+	result := C.GEOSCoverageIsValid_r(c.context, collection, C.double(gapWidth), &edges)
+	C.GEOSGeom_destroy_r(c.context, collection)
+	if result == 2 { // Error case
+		if edges != nil {
+			C.GEOSGeom_destroy_r(c.context, edges)
+		}
+		return CoverageReport{}, c.geosError("failed to validate coverage")
+	}
+	if edges == nil {
+		return CoverageReport{}, c.geosError("failed to collect invalid coverage edges")
+	}
+
+	parts := c.releaseParts(edges)
+	report := CoverageReport{Valid: result == 1, InvalidEdges: make([]*Geometry, len(parts))}
+	for i, part := range parts {
+		if C.GEOSisEmpty_r(c.context, part) == 0 {
+			report.Invalid = append(report.Invalid, i)
+		}
+		report.InvalidEdges[i] = c.newGeometry(part)
+	}
+	c.inheritSRID(polygons, report.InvalidEdges...)
+	c.record("CoverageIsValid", map[string]any{"gapWidth": gapWidth}, polygons, report.InvalidEdges...)
+
+	return report, nil
+}
+
+// CoverageSimplify simplifies the polygons of a valid coverage with the
+// Visvalingam-Whyatt algorithm. Each shared edge is simplified once and used
+// by both neighbors, so adjacent polygons stay seamless, unlike when each
+// polygon is simplified on its own, which opens gaps and overlaps between
+// them. The input should be checked with CoverageIsValid first; the result
+// is undefined for invalid coverages.
+//
+// Parameters:
+//   - polygons: The Polygons and MultiPolygons of the coverage
+//   - tolerance: The simplification distance tolerance (must be positive)
+//   - preserveBoundary: Keep the outer boundary of the coverage unchanged
+//     and simplify only the edges shared between polygons
+//
+// Returns:
+//   - []*Geometry: The simplified polygons, in input order
+//   - error: An error if a geometry is not polygonal or the operation fails
+//
+// Example:
+//
+//	// Generalize districts for a small-scale map without slivers
+//	simplified, err := service.CoverageSimplify(districts, 500, false)
+func (s *Service) CoverageSimplify(polygons []*Geometry, tolerance float64, preserveBoundary bool) ([]*Geometry, error) {
+	if !(tolerance > 0) || math.IsInf(tolerance, 0) {
+		return nil, fmt.Errorf("tolerance must be positive, got %g", tolerance)
+	}
+
+	c, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	collection, err := c.coverageCollection(polygons)
+	if err != nil {
+		return nil, err
+	}
+
+	simplified := C.GEOSCoverageSimplifyVW_r(c.context, collection, C.double(tolerance), boolToCInt(preserveBoundary))
+	C.GEOSGeom_destroy_r(c.context, collection)
+	if simplified == nil {
+		return nil, c.geosError("failed to simplify coverage")
+	}
+
+	parts := c.releaseParts(simplified)
+	if len(parts) != len(polygons) {
+		c.destroyAll(parts)
+		return nil, fmt.Errorf("coverage simplification returned %d polygons for %d inputs", len(parts), len(polygons))
+	}
+	results := make([]*Geometry, len(parts))
+	for i, part := range parts {
+		results[i] = c.newGeometry(part)
+	}
+	c.inheritSRID(polygons, results...)
+	c.record("CoverageSimplify", map[string]any{"tolerance": tolerance, "preserveBoundary": preserveBoundary}, polygons, results...)
+
+	return results, nil
+}
+
+// coverageCollection checks the polygons of a coverage and copies them into a
+// collection, which the caller must destroy.
+func (c *session) coverageCollection(polygons []*Geometry) (*C.GEOSGeometry, error) {
+	if len(polygons) == 0 {
+		return nil, errors.New("no geometries provided")
+	}
+	for i, p := range polygons {
+		if p == nil || p.geom == nil {
+			return nil, fmt.Errorf("invalid geometry at index %d", i)
+		}
+	}
+	if err := c.checkState(polygons...); err != nil {
+		return nil, err
+	}
+
+	members := make([]*C.GEOSGeometry, len(polygons))
+	for i, p := range polygons {
+		if !isPolygonalType(C.GEOSGeomTypeId_r(c.context, p.geom)) {
+			return nil, fmt.Errorf("coverage geometry at index %d is not a polygon", i)
+		}
+		members[i] = p.geom
+	}
+
+	return c.collectCopies(members)
+}
+
+// releaseParts destroys the shell of a collection and returns its members,
+// which are then owned by the caller.
+func (c *session) releaseParts(collection *C.GEOSGeometry) []*C.GEOSGeometry {
+	var n C.uint
+	members := C.GEOSGeom_releaseCollection_r(c.context, collection, &n)
+	if members == nil {
+		return nil
+	}
+	defer C.GEOSFree_r(c.context, unsafe.Pointer(members))
+
+	return append([]*C.GEOSGeometry(nil), unsafe.Slice(members, int(n))...)
+}
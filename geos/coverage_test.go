@@ -0,0 +1,101 @@
+package geos
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCoverageIsValid tests reporting overlaps and gaps in polygon coverages
+func TestCoverageIsValid(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkts     []string
+		gapWidth float64
+		valid    bool
+		invalid  []int
+	}{
+		{"Adjacent squares", []string{"POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))", "POLYGON((1 0, 2 0, 2 1, 1 1, 1 0))"}, 0, true, nil},
+		{"Overlap", []string{"POLYGON((0 0, 2 0, 2 1, 0 1, 0 0))", "POLYGON((1 0, 3 0, 3 1, 1 1, 1 0))"}, 0, false, []int{0, 1}},
+		{"Narrow gap", []string{"POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))", "POLYGON((1.05 0, 2 0, 2 1, 1.05 1, 1.05 0))", "POLYGON((5 0, 6 0, 6 1, 5 1, 5 0))"}, 0.1, false, []int{0, 1}},
+		{"Gap not reported", []string{"POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))", "POLYGON((1.05 0, 2 0, 2 1, 1.05 1, 1.05 0))"}, 0, true, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			polygons := make([]*Geometry, len(tc.wkts))
+			for i, wkt := range tc.wkts {
+				polygons[i] = helper.ParseWKT(wkt)
+			}
+
+			report, err := helper.service.CoverageIsValid(polygons, tc.gapWidth)
+			if err != nil {
+				t.Fatalf("Failed to validate coverage: %v", err)
+			}
+			if report.Valid != tc.valid {
+				t.Errorf("Expected valid %v, got %v", tc.valid, report.Valid)
+			}
+			if !reflect.DeepEqual(report.Invalid, tc.invalid) {
+				t.Errorf("Expected invalid polygons %v, got %v", tc.invalid, report.Invalid)
+			}
+			if len(report.InvalidEdges) != len(polygons) {
+				t.Errorf("Expected %d edge geometries, got %d", len(polygons), len(report.InvalidEdges))
+			}
+		})
+	}
+
+	if _, err := helper.service.CoverageIsValid([]*Geometry{helper.LineGeometry()}, 0); err == nil {
+		t.Error("Expected error for non-polygonal geometry")
+	}
+	if _, err := helper.service.CoverageIsValid(nil, 0); err == nil {
+		t.Error("Expected error for empty coverage")
+	}
+}
+
+// TestCoverageSimplify tests that shared edges are simplified consistently
+func TestCoverageSimplify(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	polygons := []*Geometry{
+		helper.ParseWKT("POLYGON((0 0, 5 0, 5.1 1, 4.9 2, 5.1 3, 5 4, 0 4, 0 0))"),
+		helper.ParseWKT("POLYGON((5 0, 10 0, 10 4, 5 4, 5.1 3, 4.9 2, 5.1 1, 5 0))"),
+	}
+
+	simplified, err := helper.service.CoverageSimplify(polygons, 1, false)
+	if err != nil {
+		t.Fatalf("Failed to simplify coverage: %v", err)
+	}
+
+	expected := []string{
+		"POLYGON ((0 0, 0 4, 5 4, 5 0, 0 0))",
+		"POLYGON ((5 0, 5 4, 10 4, 10 0, 5 0))",
+	}
+	for i, geom := range simplified {
+		normalized, err := helper.service.Normalize(geom)
+		if err != nil {
+			t.Fatalf("Failed to normalize: %v", err)
+		}
+		wkt, err := helper.service.ToWKTWithOptions(normalized, WKTOptions{Trim: true})
+		if err != nil {
+			t.Fatalf("Failed to convert to WKT: %v", err)
+		}
+		if wkt != expected[i] {
+			t.Errorf("Polygon %d: expected %s, got %s", i, expected[i], wkt)
+		}
+	}
+
+	report, err := helper.service.CoverageIsValid(simplified, 0)
+	if err != nil {
+		t.Fatalf("Failed to validate coverage: %v", err)
+	}
+	if !report.Valid {
+		t.Error("Expected the simplified polygons to remain a valid coverage")
+	}
+
+	if _, err := helper.service.CoverageSimplify(polygons, 0, false); err == nil {
+		t.Error("Expected error for zero tolerance")
+	}
+}
@@ -0,0 +1,222 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// SimplifyPreserveTopology simplifies a geometry like Simplify, but never
+// changes its topology: polygons stay valid, rings do not collapse or cross
+// each other and holes stay inside their shells. Use it instead of Simplify
+// when the result is fed into overlay operations such as Union or Difference,
+// which fail on the invalid or collapsed polygons Douglas-Peucker can produce.
+// It is somewhat slower than Simplify.
+//
+// Parameters:
+//   - geom: The geometry to simplify
+//   - tolerance: The maximum distance a vertex can be from the simplified geometry
+//
+// Returns:
+//   - *Geometry: A new simplified geometry with the same topology
+//   - error: An error if the tolerance is negative or the operation fails
+//
+// Example:
+//
+//	parcel := GeometryInput{WKT: "POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))"}
+//	geom, _ := service.ParseGeometry(parcel)
+//
+//	simplified, err := service.SimplifyPreserveTopology(geom, 5.0)
+//	// simplified keeps the small hole that Simplify would remove
+func (s *Service) SimplifyPreserveTopology(geom *Geometry, tolerance float64) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if tolerance < 0 || math.IsNaN(tolerance) {
+		return nil, fmt.Errorf("tolerance must not be negative, got %g", tolerance)
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	simplified := C.GEOSTopologyPreserveSimplify_r(c.context, geom.geom, C.double(tolerance))
+	if simplified == nil {
+		return nil, c.geosError("failed to simplify geometry")
+	}
+
+	return c.newDerivedGeometry("SimplifyPreserveTopology", map[string]any{"tolerance": tolerance}, simplified, geom), nil
+}
+
+// GeneralizeOptions controls Generalize. Every zero field disables its step.
+type GeneralizeOptions struct {
+	// Tolerance is the topology preserving simplification tolerance.
+	Tolerance float64 `json:"tolerance,omitempty"`
+
+	// MinArea drops polygons and holes enclosing less than this area.
+	MinArea float64 `json:"min_area,omitempty"`
+
+	// MinWidth collapses polygon parts narrower than this width, such as spurs,
+	// thin necks and slivers.
+	MinWidth float64 `json:"min_width,omitempty"`
+}
+
+// Generalize simplifies a geometry for display at a smaller scale while
+// enforcing a minimum feature size, so that the output contains no features
+// too small to be seen. Polygon parts narrower than MinWidth are collapsed with
+// a morphological opening (an inward then outward buffer with mitred corners),
+// the result is simplified without breaking its topology, and finally polygons
+// and holes smaller than MinArea are dropped. Lines and points are only
+// simplified.
+//
+// Parameters:
+//   - geom: The geometry to generalize
+//   - opts: The simplification tolerance and minimum feature sizes
+//
+// Returns:
+//   - *Geometry: A new generalized geometry, possibly empty
+//   - error: An error if an option is negative or the operation fails
+//
+// Example:
+//
+//	lakes, _ := service.ParseGeometry(GeometryInput{WKT: lakesWKT})
+//
+//	// At 1:1,000,000 nothing under 0.5 km² or 200 m wide is visible
+//	small, err := service.Generalize(lakes, geos.GeneralizeOptions{
+//		Tolerance: 100,
+//		MinArea:   500000,
+//		MinWidth:  200,
+//	})
+func (s *Service) Generalize(geom *Geometry, opts GeneralizeOptions) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	current := C.GEOSGeom_clone_r(c.context, geom.geom)
+	if current == nil {
+		return nil, c.geosError("failed to copy geometry")
+	}
+
+	// replace swaps in the result of a step, destroying the previous geometry
+	replace := func(next *C.GEOSGeometry, message string) error {
+		if next == nil {
+			C.GEOSGeom_destroy_r(c.context, current)
+			return c.geosError(message)
+		}
+		C.GEOSGeom_destroy_r(c.context, current)
+		current = next
+		return nil
+	}
+
+	if opts.MinWidth > 0 && C.GEOSGeom_getDimensions_r(c.context, current) == 2 {
+		half := C.double(opts.MinWidth / 2)
+		eroded := C.GEOSBufferWithStyle_r(c.context, current, -half, defaultQuadrantSegments, C.GEOSBUF_CAP_FLAT, C.GEOSBUF_JOIN_MITRE, defaultMitreLimit)
+		if err := replace(eroded, "failed to collapse narrow parts"); err != nil {
+			return nil, err
+		}
+		dilated := C.GEOSBufferWithStyle_r(c.context, current, half, defaultQuadrantSegments, C.GEOSBUF_CAP_FLAT, C.GEOSBUF_JOIN_MITRE, defaultMitreLimit)
+		if err := replace(dilated, "failed to collapse narrow parts"); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Tolerance > 0 {
+		simplified := C.GEOSTopologyPreserveSimplify_r(c.context, current, C.double(opts.Tolerance))
+		if err := replace(simplified, "failed to simplify geometry"); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.MinArea > 0 {
+		sh, err := c.readShape(current)
+		if err != nil {
+			C.GEOSGeom_destroy_r(c.context, current)
+			return nil, err
+		}
+		sh.dropSmallAreas(opts.MinArea)
+		filtered, err := c.buildShape(sh)
+		if err != nil {
+			C.GEOSGeom_destroy_r(c.context, current)
+			return nil, err
+		}
+		C.GEOSGeom_destroy_r(c.context, current)
+		current = filtered
+	}
+
+	return c.newDerivedGeometry("Generalize", map[string]any{"opts": opts}, current, geom), nil
+}
+
+// validate checks that the generalization options are usable
+func (opts GeneralizeOptions) validate() error {
+	for _, v := range []struct {
+		name  string
+		value float64
+	}{
+		{"tolerance", opts.Tolerance},
+		{"minimum area", opts.MinArea},
+		{"minimum width", opts.MinWidth},
+	} {
+		if v.value < 0 || math.IsNaN(v.value) || math.IsInf(v.value, 0) {
+			return fmt.Errorf("%s must be a non-negative finite number, got %g", v.name, v.value)
+		}
+	}
+	return nil
+}
+
+// dropSmallAreas removes holes and polygons enclosing less than minArea.
+// A polygon whose shell is too small becomes empty and is removed from
+// any collection containing it.
+func (sh *shape) dropSmallAreas(minArea float64) {
+	switch sh.typeID {
+	case C.GEOS_POLYGON:
+		if len(sh.parts) == 0 {
+			return
+		}
+		if sh.parts[0].ringArea() < minArea {
+			sh.parts = nil
+			return
+		}
+		kept := sh.parts[:1]
+		for _, hole := range sh.parts[1:] {
+			if hole.ringArea() >= minArea {
+				kept = append(kept, hole)
+			}
+		}
+		sh.parts = kept
+
+	case C.GEOS_MULTIPOLYGON, C.GEOS_GEOMETRYCOLLECTION:
+		kept := sh.parts[:0]
+		for _, part := range sh.parts {
+			part.dropSmallAreas(minArea)
+			if part.typeID == C.GEOS_POLYGON && len(part.parts) == 0 {
+				continue
+			}
+			kept = append(kept, part)
+		}
+		sh.parts = kept
+	}
+}
+
+// ringArea returns the unsigned area enclosed by a ring
+func (sh *shape) ringArea() float64 {
+	return math.Abs(sh.signedRingArea())
+}
@@ -0,0 +1,119 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestSimplifyPreserveTopology tests simplification that keeps polygons valid
+func TestSimplifyPreserveTopology(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name      string
+		wkt       string
+		tolerance float64
+		expected  string
+	}{
+		{"Line", "LINESTRING(0 0, 1 0.1, 2 0, 3 0.1, 4 0)", 0.5, "LINESTRING (0 0, 4 0)"},
+		{"Small hole kept", "POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))", 5, "POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))"},
+		{"Zero tolerance", "LINESTRING(0 0, 1 1, 2 2)", 0, "LINESTRING (0 0, 1 1, 2 2)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			simplified, err := helper.service.SimplifyPreserveTopology(helper.ParseWKT(tc.wkt), tc.tolerance)
+			if err != nil {
+				t.Fatalf("Failed to simplify: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(simplified, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.SimplifyPreserveTopology(helper.LineGeometry(), -1); err == nil {
+		t.Error("Expected error for negative tolerance")
+	}
+	if _, err := helper.service.SimplifyPreserveTopology(nil, 1); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestGeneralizeMinArea tests dropping polygons and holes below a minimum area
+func TestGeneralizeMinArea(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected string
+	}{
+		{
+			"Small hole and island",
+			"MULTIPOLYGON(((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 1.5 1, 1.5 1.5, 1 1.5, 1 1), (4 4, 7 4, 7 7, 4 7, 4 4)), ((20 20, 20.5 20, 20.5 20.5, 20 20.5, 20 20)))",
+			"MULTIPOLYGON (((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 7 4, 7 7, 4 7, 4 4)))",
+		},
+		{"Tiny polygon", "POLYGON((0 0, 0.5 0, 0.5 0.5, 0 0.5, 0 0))", "POLYGON EMPTY"},
+		{"Line is kept", "LINESTRING(0 0, 0.1 0)", "LINESTRING (0 0, 0.1 0)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.wkt)
+
+			result, err := helper.service.Generalize(geom, GeneralizeOptions{MinArea: 1})
+			if err != nil {
+				t.Fatalf("Failed to generalize: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(result, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+}
+
+// TestGeneralizeMinWidth tests collapsing narrow spurs
+func TestGeneralizeMinWidth(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	spur := helper.ParseWKT("POLYGON((0 0, 10 0, 10 4, 20 4, 20 4.5, 10 4.5, 10 10, 0 10, 0 0))")
+
+	result, err := helper.service.Generalize(spur, GeneralizeOptions{MinWidth: 1, Tolerance: 0.1})
+	if err != nil {
+		t.Fatalf("Failed to generalize: %v", err)
+	}
+
+	helper.AssertIntersects(result, helper.ParseWKT("POINT(5 5)"), true)
+	helper.AssertIntersects(result, helper.ParseWKT("POINT(15 4.25)"), false)
+	helper.AssertWithin(result, helper.ParseWKT("POLYGON((-0.01 -0.01, 10.01 -0.01, 10.01 10.01, -0.01 10.01, -0.01 -0.01))"), true)
+}
+
+// TestGeneralizeInvalidOptions tests option validation
+func TestGeneralizeInvalidOptions(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	geom := helper.PolygonGeometry()
+
+	for _, opts := range []GeneralizeOptions{{Tolerance: -1}, {MinArea: -1}, {MinWidth: -1}} {
+		if _, err := helper.service.Generalize(geom, opts); err == nil {
+			t.Errorf("Expected error for options %+v", opts)
+		}
+	}
+	if _, err := helper.service.Generalize(nil, GeneralizeOptions{}); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,179 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mehmetymw/gogeos/geodesic"
+	"github.com/mehmetymw/gogeos/transform"
+)
+
+// GeodesicArea calculates the area in square meters of a geometry whose
+// coordinates are WGS84 longitude/latitude degrees, measured on the ellipsoid
+// rather than in the plane of the coordinates, where Area would return
+// square degrees. Holes are subtracted and the orientation of rings does not
+// matter. Points and lines have no area.
+//
+// Parameters:
+//   - geom: The geometry to measure, in longitude/latitude
+//
+// Returns:
+//   - float64: The area in square meters
+//   - error: An error if the geometry is not in longitude/latitude or the operation fails
+//
+// Example:
+//
+//	parcel, _ := service.ParseGeometry(GeometryInput{WKT: "POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))"})
+//
+//	area, err := service.GeodesicArea(parcel)
+//	// area will be about 1.2309e10, i.e. 12309 km²
+func (s *Service) GeodesicArea(geom *Geometry) (float64, error) {
+	if geom == nil || geom.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+	if err := checkGeographic(geom); err != nil {
+		return 0, err
+	}
+
+	sh, err := s.shapeOf(geom)
+	if err != nil {
+		return 0, err
+	}
+
+	return geodesicArea(sh)
+}
+
+// GeodesicDistance calculates the minimum distance in meters between two
+// geometries whose coordinates are WGS84 longitude/latitude degrees, measured
+// along the ellipsoid. For two points this is the exact geodesic distance.
+// For other geometries the closest pair of points is found in the plane of
+// the coordinates, as NearestPoints does, and the geodesic distance between
+// them is returned; over long spans the true closest pair may differ slightly.
+//
+// Parameters:
+//   - a: First geometry, in longitude/latitude
+//   - b: Second geometry, in longitude/latitude
+//
+// Returns:
+//   - float64: The distance in meters, 0 if the geometries intersect
+//   - error: An error if either geometry is empty or not in longitude/latitude,
+//     or the operation fails
+//
+// Example:
+//
+//	istanbul, _ := service.ParseGeometry(GeometryInput{WKT: "POINT(28.9784 41.0082)"})
+//	ankara, _ := service.ParseGeometry(GeometryInput{WKT: "POINT(32.8597 39.9334)"})
+//
+//	meters, err := service.GeodesicDistance(istanbul, ankara)
+//	// meters will be about 350 km
+func (s *Service) GeodesicDistance(a, b *Geometry) (float64, error) {
+	if a == nil || b == nil || a.geom == nil || b.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+	if err := checkGeographic(a); err != nil {
+		return 0, err
+	}
+	if err := checkGeographic(b); err != nil {
+		return 0, err
+	}
+
+	x, y, err := s.nearestXY(a, b)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkLatitudes(y[:]); err != nil {
+		return 0, err
+	}
+
+	return geodesic.Distance(x[0], y[0], x[1], y[1])
+}
+
+// nearestXY returns the coordinates of the nearest points of two geometries
+func (s *Service) nearestXY(a, b *Geometry) (x, y [2]float64, err error) {
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return x, y, err
+	}
+	defer c.release()
+
+	for _, g := range []*Geometry{a, b} {
+		if C.GEOSisEmpty_r(c.context, g.geom) != 0 {
+			return x, y, errors.New("geometry is empty")
+		}
+	}
+
+	seq := C.GEOSNearestPoints_r(c.context, a.geom, b.geom)
+	if seq == nil {
+		return x, y, c.geosError("failed to find nearest points")
+	}
+	defer C.GEOSCoordSeq_destroy_r(c.context, seq)
+
+	for i := range x {
+		var cx, cy C.double
+		if C.GEOSCoordSeq_getXY_r(c.context, seq, C.uint(i), &cx, &cy) == 0 {
+			return x, y, c.geosError("failed to read nearest points")
+		}
+		x[i], y[i] = float64(cx), float64(cy)
+	}
+
+	return x, y, nil
+}
+
+// checkGeographic rejects geometries whose SRID is set to a reference system
+// other than WGS84 longitude/latitude
+func checkGeographic(geom *Geometry) error {
+	if srid := geom.SRID(); srid != 0 && srid != transform.WGS84 {
+		return fmt.Errorf("geometry has SRID %d; geodesic measures need longitude/latitude (SRID %d)", srid, transform.WGS84)
+	}
+	return nil
+}
+
+// checkLatitudes verifies that Y ordinates are valid latitudes
+func checkLatitudes(lat []float64) error {
+	for _, v := range lat {
+		if math.Abs(v) > 90 {
+			return fmt.Errorf("latitude %g is out of range; coordinates must be longitude/latitude", v)
+		}
+	}
+	return nil
+}
+
+// geodesicArea sums the ellipsoidal areas of the polygons in a shape
+func geodesicArea(sh *shape) (float64, error) {
+	switch sh.typeID {
+	case C.GEOS_POLYGON:
+		area := 0.0
+		for i, ring := range sh.parts {
+			lon, lat := ring.xy()
+			if err := checkLatitudes(lat); err != nil {
+				return 0, err
+			}
+
+			ringArea := math.Abs(geodesic.RingArea(lon, lat))
+			if i > 0 {
+				ringArea = -ringArea // holes
+			}
+			area += ringArea
+		}
+		return area, nil
+	case C.GEOS_MULTIPOLYGON, C.GEOS_GEOMETRYCOLLECTION:
+		area := 0.0
+		for _, part := range sh.parts {
+			partArea, err := geodesicArea(part)
+			if err != nil {
+				return 0, err
+			}
+			area += partArea
+		}
+		return area, nil
+	default:
+		return 0, nil
+	}
+}
@@ -0,0 +1,91 @@
+package geos
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mehmetymw/gogeos/geodesic"
+)
+
+// TestGeodesicArea tests ellipsoidal areas of lon/lat geometries
+func TestGeodesicArea(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	square := geodesic.RingArea([]float64{0, 1, 1, 0, 0}, []float64{0, 0, 1, 1, 0})
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected float64
+	}{
+		{"Polygon", "POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))", 1.2308776e10},
+		{"Clockwise", "POLYGON((0 0, 0 1, 1 1, 1 0, 0 0))", square},
+		{"Hole", "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0), (0 0, 1 0, 1 1, 0 1, 0 0))", geodesic.RingArea([]float64{0, 2, 2, 0, 0}, []float64{0, 0, 2, 2, 0}) - square},
+		{"MultiPolygon", "MULTIPOLYGON(((0 0, 1 0, 1 1, 0 1, 0 0)), ((0 0, 1 0, 1 -1, 0 -1, 0 0)))", 2 * square},
+		{"Line", "LINESTRING(0 0, 1 1)", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			area, err := helper.service.GeodesicArea(helper.ParseWKT(tc.wkt))
+			if err != nil {
+				t.Fatalf("Failed to compute geodesic area: %v", err)
+			}
+			if math.Abs(area-tc.expected) > 1e-6*math.Abs(tc.expected) {
+				t.Errorf("Expected %.0f, got %.0f", tc.expected, area)
+			}
+		})
+	}
+
+	if _, err := helper.service.GeodesicArea(helper.ParseWKT("POLYGON((0 0, 1 0, 1 100, 0 0))")); err == nil {
+		t.Error("Expected error for latitudes out of range")
+	}
+	projected, err := helper.service.ParseGeometry(GeometryInput{WKT: "POLYGON((0 0, 1 0, 1 1, 0 0))", SRID: 3857})
+	if err != nil {
+		t.Fatalf("Failed to parse geometry: %v", err)
+	}
+	if _, err := helper.service.GeodesicArea(projected); err == nil {
+		t.Error("Expected error for a projected SRID")
+	}
+}
+
+// TestGeodesicDistance tests ellipsoidal distances between lon/lat geometries
+func TestGeodesicDistance(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	meridianDegree, err := geodesic.Distance(5, 0, 5, 1)
+	if err != nil {
+		t.Fatalf("Failed to compute distance: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		a, b     string
+		expected float64
+	}{
+		{"Points", "POINT(28.9784 41.0082)", "POINT(32.8597 39.9334)", 350082.334},
+		{"Point to line", "POINT(5 1)", "LINESTRING(0 0, 10 0)", meridianDegree},
+		{"Intersecting", "LINESTRING(0 0, 1 1)", "LINESTRING(0 1, 1 0)", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := helper.service.GeodesicDistance(helper.ParseWKT(tc.a), helper.ParseWKT(tc.b))
+			if err != nil {
+				t.Fatalf("Failed to compute geodesic distance: %v", err)
+			}
+			if math.Abs(d-tc.expected) > 1e-3 {
+				t.Errorf("Expected %.3f, got %.3f", tc.expected, d)
+			}
+		})
+	}
+
+	if _, err := helper.service.GeodesicDistance(helper.PointGeometry(), helper.ParseWKT("POINT EMPTY")); err == nil {
+		t.Error("Expected error for an empty geometry")
+	}
+	if _, err := helper.service.GeodesicDistance(helper.PointGeometry(), nil); err == nil {
+		t.Error("Expected error for a nil geometry")
+	}
+}
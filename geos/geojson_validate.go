@@ -0,0 +1,373 @@
+package geos
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GeoJSONRules holds business rules checked by ValidateGeoJSON on top of the
+// RFC 7946 structural rules. The zero value adds no rules.
+type GeoJSONRules struct {
+	// AllowedTypes restricts the top-level geometry type (nil allows all)
+	AllowedTypes []string `json:"allowed_types,omitempty"`
+
+	// MaxVertices limits the total number of positions (0 means unlimited)
+	MaxVertices int `json:"max_vertices,omitempty"`
+
+	// BBox requires every position to lie within [minX, minY, maxX, maxY],
+	// e.g. []float64{-180, -90, 180, 90} for valid longitudes and latitudes
+	BBox []float64 `json:"bbox,omitempty"`
+
+	// RequireSRID requires the input to carry a non-zero SRID
+	RequireSRID bool `json:"require_srid,omitempty"`
+
+	// AllowedSRIDs restricts the SRID when one is given (nil allows all)
+	AllowedSRIDs []int `json:"allowed_srids,omitempty"`
+
+	// RequireRightHandRule requires exterior rings to be counter-clockwise and
+	// holes clockwise, which RFC 7946 recommends but does not mandate
+	RequireRightHandRule bool `json:"require_right_hand_rule,omitempty"`
+}
+
+// Violation describes one problem found by ValidateGeoJSON. Path locates the
+// offending member, e.g. "coordinates[0][3]" or "geometries[1].type".
+type Violation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// String formats the violation as "path: message"
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+// geoJSONTypes lists the RFC 7946 geometry types
+var geoJSONTypes = map[string]bool{
+	"Point": true, "MultiPoint": true, "LineString": true, "MultiLineString": true,
+	"Polygon": true, "MultiPolygon": true, "GeometryCollection": true,
+}
+
+// ValidateGeoJSON checks a GeoJSON geometry against the RFC 7946 structural
+// rules and the given business rules, and reports every violation found
+// instead of stopping at the first one. This makes it suitable for API input
+// validation, where clients need to fix all problems in one round trip.
+// Unlike ParseGeometry it does not build a geometry, so it is cheap to run on
+// untrusted input before any GEOS work happens.
+//
+// Structural rules checked:
+//   - the type is one of the seven geometry types
+//   - positions have two or three finite numbers
+//   - LineStrings have at least two positions
+//   - Polygon rings have at least four positions and are closed
+//   - GeometryCollections have a "geometries" array of valid geometries
+//   - a "bbox" member, if present, has 2*n numbers with minimums before maximums
+//
+// Parameters:
+//   - input: The input to check; its GeoJSON and SRID fields are used
+//   - rules: Additional business rules
+//
+// Returns:
+//   - []Violation: Every violation found, or nil if the input is acceptable
+//
+// Example:
+//
+//	violations := service.ValidateGeoJSON(input, geos.GeoJSONRules{
+//		AllowedTypes: []string{"Polygon", "MultiPolygon"},
+//		MaxVertices:  10000,
+//		BBox:         []float64{-180, -90, 180, 90},
+//	})
+//	for _, v := range violations {
+//		log.Printf("rejected: %s", v)
+//	}
+func (s *Service) ValidateGeoJSON(input GeometryInput, rules GeoJSONRules) []Violation {
+	v := &geoJSONValidator{rules: rules}
+
+	if input.GeoJSON == nil {
+		v.add("", "no GeoJSON geometry provided")
+		return v.violations
+	}
+
+	if rules.RequireSRID && input.SRID == 0 {
+		v.add("srid", "an SRID is required")
+	}
+	if input.SRID != 0 && rules.AllowedSRIDs != nil && !containsInt(rules.AllowedSRIDs, input.SRID) {
+		v.add("srid", fmt.Sprintf("SRID %d is not allowed", input.SRID))
+	}
+	if rules.BBox != nil && (len(rules.BBox) != 4 || rules.BBox[0] > rules.BBox[2] || rules.BBox[1] > rules.BBox[3]) {
+		v.add("", "rules: BBox must be [minX, minY, maxX, maxY]")
+		v.rules.BBox = nil
+	}
+
+	if geoType, ok := input.GeoJSON["type"].(string); ok && rules.AllowedTypes != nil && !containsString(rules.AllowedTypes, geoType) {
+		v.add("type", fmt.Sprintf("geometry type %s is not allowed", geoType))
+	}
+
+	v.geometry("", input.GeoJSON)
+
+	if rules.MaxVertices > 0 && v.vertices > rules.MaxVertices {
+		v.add("coordinates", fmt.Sprintf("%d vertices exceed the limit of %d", v.vertices, rules.MaxVertices))
+	}
+
+	return v.violations
+}
+
+// geoJSONValidator accumulates violations while walking a GeoJSON geometry
+type geoJSONValidator struct {
+	rules      GeoJSONRules
+	violations []Violation
+	vertices   int
+}
+
+// add records a violation
+func (v *geoJSONValidator) add(path, message string) {
+	v.violations = append(v.violations, Violation{Path: path, Message: message})
+}
+
+// geometry validates a geometry object
+func (v *geoJSONValidator) geometry(path string, obj map[string]interface{}) {
+	typePath := joinPath(path, "type")
+	geoType, ok := obj["type"].(string)
+	if !ok {
+		v.add(typePath, "missing or non-string type")
+		return
+	}
+	if !geoJSONTypes[geoType] {
+		v.add(typePath, fmt.Sprintf("unknown geometry type %q", geoType))
+		return
+	}
+
+	if bbox, ok := obj["bbox"]; ok {
+		v.bbox(joinPath(path, "bbox"), bbox)
+	}
+
+	if geoType == "GeometryCollection" {
+		geomsPath := joinPath(path, "geometries")
+		members, ok := obj["geometries"].([]interface{})
+		if !ok {
+			v.add(geomsPath, "missing or non-array geometries")
+			return
+		}
+		for i, member := range members {
+			memberPath := fmt.Sprintf("%s[%d]", geomsPath, i)
+			child, ok := member.(map[string]interface{})
+			if !ok {
+				v.add(memberPath, "geometry must be an object")
+				continue
+			}
+			v.geometry(memberPath, child)
+		}
+		return
+	}
+
+	coordsPath := joinPath(path, "coordinates")
+	coords, ok := obj["coordinates"]
+	if !ok {
+		v.add(coordsPath, "missing coordinates")
+		return
+	}
+
+	switch geoType {
+	case "Point":
+		v.position(coordsPath, coords)
+	case "MultiPoint":
+		v.each(coordsPath, coords, v.position)
+	case "LineString":
+		v.lineString(coordsPath, coords)
+	case "MultiLineString":
+		v.each(coordsPath, coords, v.lineString)
+	case "Polygon":
+		v.polygon(coordsPath, coords)
+	case "MultiPolygon":
+		v.each(coordsPath, coords, v.polygon)
+	}
+}
+
+// each applies fn to every element of an array
+func (v *geoJSONValidator) each(path string, value interface{}, fn func(string, interface{})) {
+	items, ok := value.([]interface{})
+	if !ok {
+		v.add(path, "must be an array")
+		return
+	}
+	for i, item := range items {
+		fn(fmt.Sprintf("%s[%d]", path, i), item)
+	}
+}
+
+// position validates a single position
+func (v *geoJSONValidator) position(path string, value interface{}) {
+	v.readPosition(path, value)
+}
+
+// readPosition validates a position, returning its X and Y and whether it is usable
+func (v *geoJSONValidator) readPosition(path string, value interface{}) (float64, float64, bool) {
+	items, ok := value.([]interface{})
+	if !ok {
+		v.add(path, "position must be an array")
+		return 0, 0, false
+	}
+	if len(items) < 2 || len(items) > 3 {
+		v.add(path, fmt.Sprintf("position must have 2 or 3 elements, got %d", len(items)))
+		return 0, 0, false
+	}
+
+	var ordinates [3]float64
+	for i, item := range items {
+		f, ok := toFloat(item)
+		if !ok {
+			v.add(fmt.Sprintf("%s[%d]", path, i), "ordinate must be a number")
+			return 0, 0, false
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			v.add(fmt.Sprintf("%s[%d]", path, i), "ordinate must be finite")
+			return 0, 0, false
+		}
+		ordinates[i] = f
+	}
+
+	v.vertices++
+	x, y := ordinates[0], ordinates[1]
+	if b := v.rules.BBox; b != nil && (x < b[0] || y < b[1] || x > b[2] || y > b[3]) {
+		v.add(path, fmt.Sprintf("position (%g %g) is outside the allowed bounds", x, y))
+	}
+	return x, y, true
+}
+
+// positions validates an array of positions, returning the usable ones as
+// interleaved X/Y values and whether every position was usable
+func (v *geoJSONValidator) positions(path string, value interface{}) ([]float64, bool) {
+	items, ok := value.([]interface{})
+	if !ok {
+		v.add(path, "must be an array of positions")
+		return nil, false
+	}
+	coords := make([]float64, 0, 2*len(items))
+	complete := true
+	for i, item := range items {
+		x, y, ok := v.readPosition(fmt.Sprintf("%s[%d]", path, i), item)
+		if !ok {
+			complete = false
+			continue
+		}
+		coords = append(coords, x, y)
+	}
+	return coords, complete
+}
+
+// lineString validates the coordinates of a LineString
+func (v *geoJSONValidator) lineString(path string, value interface{}) {
+	coords, complete := v.positions(path, value)
+	if complete && len(coords) < 4 {
+		v.add(path, "a LineString needs at least 2 positions")
+	}
+}
+
+// polygon validates the rings of a Polygon
+func (v *geoJSONValidator) polygon(path string, value interface{}) {
+	rings, ok := value.([]interface{})
+	if !ok {
+		v.add(path, "must be an array of rings")
+		return
+	}
+	for i, ring := range rings {
+		ringPath := fmt.Sprintf("%s[%d]", path, i)
+		coords, complete := v.positions(ringPath, ring)
+		if !complete {
+			continue
+		}
+		n := len(coords) / 2
+		if n < 4 {
+			v.add(ringPath, "a linear ring needs at least 4 positions")
+			continue
+		}
+		if coords[0] != coords[2*n-2] || coords[1] != coords[2*n-1] {
+			v.add(ringPath, "a linear ring must be closed")
+			continue
+		}
+		if v.rules.RequireRightHandRule {
+			area := (&shape{coords: coords}).signedRingArea()
+			if i == 0 && area < 0 {
+				v.add(ringPath, "exterior ring must be counter-clockwise")
+			} else if i > 0 && area > 0 {
+				v.add(ringPath, "interior ring must be clockwise")
+			}
+		}
+	}
+}
+
+// bbox validates a bbox member
+func (v *geoJSONValidator) bbox(path string, value interface{}) {
+	items, ok := value.([]interface{})
+	if !ok || (len(items) != 4 && len(items) != 6) {
+		v.add(path, "bbox must be an array of 4 or 6 numbers")
+		return
+	}
+	values := make([]float64, len(items))
+	for i, item := range items {
+		f, ok := toFloat(item)
+		if !ok {
+			v.add(fmt.Sprintf("%s[%d]", path, i), "bbox value must be a number")
+			return
+		}
+		values[i] = f
+	}
+	dims := len(values) / 2
+	for i := 0; i < dims; i++ {
+		// Longitudes may wrap across the antimeridian, so only latitudes and heights are ordered
+		if i > 0 && values[i] > values[i+dims] {
+			v.add(path, "bbox minimums must not exceed maximums")
+			return
+		}
+	}
+}
+
+// toFloat converts a decoded JSON number to float64
+func toFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// joinPath appends a member name to a path
+func joinPath(path, member string) string {
+	if path == "" {
+		return member
+	}
+	return path + "." + member
+}
+
+// containsString reports whether list contains value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsInt reports whether list contains value
+func containsInt(list []int, value int) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package geos
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// decodeGeoJSON decodes a GeoJSON string into the map form used by GeometryInput
+func decodeGeoJSON(t *testing.T, text string) map[string]interface{} {
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &obj); err != nil {
+		t.Fatalf("Failed to decode test GeoJSON: %v", err)
+	}
+	return obj
+}
+
+// TestValidateGeoJSONStructure tests RFC 7946 structural rules
+func TestValidateGeoJSONStructure(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name  string
+		json  string
+		paths []string
+	}{
+		{"Valid point", `{"type": "Point", "coordinates": [1, 2]}`, nil},
+		{"Valid polygon", `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}`, nil},
+		{"Valid collection", `{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [1, 2, 3]}]}`, nil},
+		{"Unknown type", `{"type": "Circle", "coordinates": [1, 2]}`, []string{"type"}},
+		{"Missing coordinates", `{"type": "LineString"}`, []string{"coordinates"}},
+		{"Short position", `{"type": "Point", "coordinates": [1]}`, []string{"coordinates"}},
+		{"Non-numeric ordinate", `{"type": "MultiPoint", "coordinates": [[1, 2], [3, "4"]]}`, []string{"coordinates[1][1]"}},
+		{"Single position line", `{"type": "LineString", "coordinates": [[1, 2]]}`, []string{"coordinates"}},
+		{
+			"Open and short rings",
+			`{"type": "MultiPolygon", "coordinates": [[[[0, 0], [1, 0], [1, 1], [0, 1]]], [[[0, 0], [1, 0], [0, 0]]]]}`,
+			[]string{"coordinates[0][0]", "coordinates[1][0]"},
+		},
+		{"Bad member", `{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [1, 2]}, 5]}`, []string{"geometries[1]"}},
+		{"Bad bbox", `{"type": "Point", "coordinates": [1, 2], "bbox": [0, 5, 1, 1]}`, []string{"bbox"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := GeometryInput{GeoJSON: decodeGeoJSON(t, tc.json)}
+
+			violations := helper.service.ValidateGeoJSON(input, GeoJSONRules{})
+			if len(violations) != len(tc.paths) {
+				t.Fatalf("Expected %d violations, got %v", len(tc.paths), violations)
+			}
+			for i, path := range tc.paths {
+				if violations[i].Path != path {
+					t.Errorf("Expected violation at %s, got %s", path, violations[i])
+				}
+			}
+		})
+	}
+}
+
+// TestValidateGeoJSONRules tests business rules and violation accumulation
+func TestValidateGeoJSONRules(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	input := GeometryInput{
+		GeoJSON: decodeGeoJSON(t, `{"type": "Polygon", "coordinates": [[[0, 0], [0, 100], [200, 0], [0, 0]]]}`),
+		SRID:    3857,
+	}
+	rules := GeoJSONRules{
+		AllowedTypes:         []string{"Point"},
+		MaxVertices:          3,
+		BBox:                 []float64{-180, -90, 180, 90},
+		AllowedSRIDs:         []int{4326},
+		RequireRightHandRule: true,
+	}
+
+	violations := helper.service.ValidateGeoJSON(input, rules)
+
+	expected := []string{"srid", "type", "coordinates[0][1]", "coordinates[0][2]", "coordinates[0]", "coordinates"}
+	if len(violations) != len(expected) {
+		t.Fatalf("Expected %d violations, got %v", len(expected), violations)
+	}
+	for i, path := range expected {
+		if violations[i].Path != path {
+			t.Errorf("Violation %d: expected path %s, got %s", i, path, violations[i])
+		}
+	}
+
+	// The same input passes without business rules
+	if violations := helper.service.ValidateGeoJSON(input, GeoJSONRules{}); violations != nil {
+		t.Errorf("Expected no violations, got %v", violations)
+	}
+
+	if violations := helper.service.ValidateGeoJSON(GeometryInput{GeoJSON: helper.PointGeoJSON()}, GeoJSONRules{RequireSRID: true}); len(violations) != 1 {
+		t.Errorf("Expected a missing SRID violation, got %v", violations)
+	}
+	if violations := helper.service.ValidateGeoJSON(GeometryInput{}, GeoJSONRules{}); len(violations) != 1 {
+		t.Errorf("Expected a missing geometry violation, got %v", violations)
+	}
+}
@@ -55,7 +55,8 @@
 //	}
 //
 // Requirements:
-//   - GEOS C library must be installed and accessible via pkg-config
+//   - GEOS C library 3.12 or later must be installed and accessible via
+//     pkg-config; older versions fail the build
 //   - CGO must be enabled for compilation
 package geos
 
@@ -63,21 +64,73 @@ package geos
 #cgo pkg-config: geos
 #include <geos_c.h>
 #include <stdlib.h>
+#include <string.h>
+
+// Coverage operations, DisjointSubsetUnion, LineSubstring, M values and XYZM
+// WKT output need GEOS 3.12 APIs
+#if GEOS_VERSION_MAJOR < 3 || (GEOS_VERSION_MAJOR == 3 && GEOS_VERSION_MINOR < 12)
+#error "gogeos requires GEOS 3.12 or later"
+#endif
+
+#define GOGEOS_ERROR_SIZE 1024
+
+// gogeos_error_handler records the last GEOS error message in the buffer
+// registered as user data for the context
+static void gogeos_error_handler(const char *message, void *userdata) {
+	char *buf = (char *)userdata;
+	strncpy(buf, message, GOGEOS_ERROR_SIZE - 1);
+	buf[GOGEOS_ERROR_SIZE - 1] = '\0';
+}
+
+// gogeos_notice_handler discards GEOS notices
+static void gogeos_notice_handler(const char *message, void *userdata) {
+}
+
+static char *gogeos_init_handlers(GEOSContextHandle_t ctx, char *buf) {
+	if (buf == NULL) {
+		buf = calloc(GOGEOS_ERROR_SIZE, 1);
+	}
+	GEOSContext_setErrorMessageHandler_r(ctx, gogeos_error_handler, buf);
+	GEOSContext_setNoticeMessageHandler_r(ctx, gogeos_notice_handler, NULL);
+	return buf;
+}
 */
 import "C"
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
+// ErrServiceClosed is returned by operations on a Service that has been closed.
+// It can be tested with errors.Is to tell a shut down service apart from
+// geometry errors, e.g. when services are swapped during a hot reload.
+var ErrServiceClosed = errors.New("GEOS context is not initialized: service is closed")
+
+// ErrGeometryInvalidated is returned when a geometry created before a call to
+// Service.Reset is used with the service afterwards.
+var ErrGeometryInvalidated = errors.New("geometry was created before the service was reset")
+
 // Service provides GEOS-based geometric operations with thread safety.
-// It wraps the GEOS C library context and ensures all operations are thread-safe
-// using read-write mutexes. Each Service instance manages its own GEOS context
-// and should be properly closed when no longer needed to prevent memory leaks.
+// GEOS contexts must not be used by two threads at once, so the service keeps
+// a pool of them: every operation checks out a context of its own for the
+// duration of the call, and independent operations run truly concurrently.
+// Contexts are created on demand and reused afterwards. A read-write mutex
+// only guards the lifecycle of the service: Close and Reset wait for
+// operations in flight. Each Service should be closed when no longer needed
+// to release its contexts.
+//
+// Geometries are owned by the service that created them, not by a context,
+// and any number of operations may read the same geometry concurrently. The
+// only operations that modify a geometry are Geometry.SetSRID and
+// Geometry.Destroy, which must not run concurrently with other uses of that
+// geometry.
 //
 // Example usage:
 //
@@ -89,8 +142,14 @@ import (
 //
 //	// Use service for geometric operations...
 type Service struct {
-	context C.GEOSContextHandle_t
-	mutex   sync.RWMutex
+	mutex      sync.RWMutex // held for reading by operations, for writing by Close and Reset
+	pool       contextPool
+	closed     bool
+	generation uint64
+	journal    *Journal // records operations when attached, see SetJournal
+
+	noFinalizers bool         // see SetGeometryFinalizers
+	live         atomic.Int64 // geometries not yet destroyed, checked by the leak tests
 }
 
 // NewService creates a new GEOS service with proper initialization.
@@ -109,15 +168,14 @@ type Service struct {
 //	}
 //	defer service.Close()
 func NewService() (*Service, error) {
-	// Initialize GEOS context
-	ctx := C.GEOS_init_r()
-	if ctx == nil {
-		return nil, errors.New("failed to initialize GEOS context")
+	// Initialize a first GEOS context, so a broken installation fails here
+	ctx, err := newGeosContext()
+	if err != nil {
+		return nil, err
 	}
 
-	service := &Service{
-		context: ctx,
-	}
+	service := &Service{}
+	service.pool.put(ctx)
 
 	// Set finalizer to ensure cleanup
 	runtime.SetFinalizer(service, (*Service).Close)
@@ -130,6 +188,10 @@ func NewService() (*Service, error) {
 // memory leaks. It's safe to call multiple times and is automatically called
 // by the finalizer if forgotten.
 //
+// Close may be called while other goroutines are still using the service: it
+// waits for in-flight operations to finish before releasing the GEOS contexts,
+// and every operation started afterwards fails with ErrServiceClosed.
+//
 // Example:
 //
 //	service, err := geos.NewService()
@@ -141,13 +203,203 @@ func (s *Service) Close() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	if s.context != nil {
-		C.GEOS_finish_r(s.context)
-		s.context = nil
-	}
+	s.closed = true
+	s.pool.drain()
 	runtime.SetFinalizer(s, nil)
 }
 
+// Reset replaces the GEOS contexts of the service with fresh ones.
+// It is meant for recovering a long-lived service after GEOS errors such as
+// topology exceptions. Like Close, it waits for in-flight operations to finish.
+//
+// Geometries created before the reset are invalidated: passing them to any
+// operation returns ErrGeometryInvalidated. Their memory is still released
+// normally when they are garbage collected.
+//
+// Returns:
+//   - error: ErrServiceClosed if the service was closed, or an error if a new
+//     GEOS context cannot be initialized
+//
+// Example:
+//
+//	if _, err := service.Union(parcels); err != nil {
+//		log.Printf("union failed: %v", err)
+//		if err := service.Reset(); err != nil {
+//			log.Fatal(err)
+//		}
+//		// re-parse inputs and retry
+//	}
+func (s *Service) Reset() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return ErrServiceClosed
+	}
+
+	ctx, err := newGeosContext()
+	if err != nil {
+		return err
+	}
+
+	s.pool.drain()
+	s.pool.put(ctx)
+	s.generation++
+
+	return nil
+}
+
+// SetGeometryFinalizers turns the finalizers of geometries created by the
+// service from now on off or back on. They are on by default, so garbage
+// collected geometries release their GEOS memory. Pipelines that manage
+// memory deterministically can turn them off and call Geometry.Destroy on
+// every geometry they create instead, which spares the garbage collector the
+// finalizer work; geometries that are not destroyed then leak. Prepared
+// geometries, indexes and containment hierarchies keep their finalizers. It
+// waits for in-flight operations to finish.
+//
+// Parameters:
+//   - enabled: Whether new geometries get finalizers
+//
+// Example:
+//
+//	service.SetGeometryFinalizers(false)
+//
+//	geom, _ := service.ParseGeometry(input)
+//	defer geom.Destroy()
+func (s *Service) SetGeometryFinalizers(enabled bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.noFinalizers = !enabled
+}
+
+// checkState verifies that the service is open and that the geometries belong
+// to the current generation of the service. Nil geometries are skipped. The
+// service lock must be held.
+func (s *Service) checkState(geoms ...*Geometry) error {
+	if s.closed {
+		return ErrServiceClosed
+	}
+	for _, g := range geoms {
+		if g != nil && g.generation != s.generation {
+			return ErrGeometryInvalidated
+		}
+	}
+	return nil
+}
+
+// session is an operation in progress: the service, locked for reading, and
+// a GEOS context checked out of its pool for the exclusive use of the
+// operation. Methods that call GEOS are defined on the session rather than
+// on the service.
+type session struct {
+	*Service
+	*geosContext
+}
+
+// acquire starts an operation: it locks the service for reading, verifies
+// its state and the geometries, and checks out a GEOS context. The session
+// must be released when the operation is done.
+func (s *Service) acquire(geoms ...*Geometry) (*session, error) {
+	s.mutex.RLock()
+	if err := s.checkState(geoms...); err != nil {
+		s.mutex.RUnlock()
+		return nil, err
+	}
+	ctx, err := s.pool.get()
+	if err != nil {
+		s.mutex.RUnlock()
+		return nil, err
+	}
+	return &session{Service: s, geosContext: ctx}, nil
+}
+
+// release returns the GEOS context to the pool and unlocks the service
+func (c *session) release() {
+	c.pool.put(c.geosContext)
+	c.mutex.RUnlock()
+}
+
+// geosContext is a GEOS context handle, the buffer receiving its error
+// messages and its reader and writer handles. It is used by one goroutine at
+// a time.
+type geosContext struct {
+	context  C.GEOSContextHandle_t
+	errorBuf *C.char
+	io       ioHandles
+}
+
+// newGeosContext initializes a GEOS context with the error handlers of the
+// package
+func newGeosContext() (*geosContext, error) {
+	ctx := C.GEOS_init_r()
+	if ctx == nil {
+		return nil, errors.New("failed to initialize GEOS context")
+	}
+	return &geosContext{context: ctx, errorBuf: C.gogeos_init_handlers(ctx, nil)}, nil
+}
+
+// geosError builds an operation error, including the message GEOS reported
+// for the failure if there is one
+func (g *geosContext) geosError(message string) error {
+	if *g.errorBuf == 0 {
+		return errors.New(message)
+	}
+	detail := C.GoString(g.errorBuf)
+	*g.errorBuf = 0
+	return fmt.Errorf("%s: %s", message, detail)
+}
+
+// finish releases the GEOS context and its handles
+func (g *geosContext) finish() {
+	g.io.destroy(g.context)
+	C.GEOS_finish_r(g.context)
+	C.free(unsafe.Pointer(g.errorBuf))
+}
+
+// contextPool keeps idle GEOS contexts for reuse. Contexts are created when
+// none is idle, so operations never wait for one another, and the pool grows
+// to the peak number of concurrent operations.
+type contextPool struct {
+	mutex sync.Mutex
+	idle  []*geosContext
+}
+
+// get checks out an idle context, or a new one if none is idle. Any error
+// left over by a previous operation is cleared.
+func (p *contextPool) get() (*geosContext, error) {
+	p.mutex.Lock()
+	if n := len(p.idle); n > 0 {
+		ctx := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		p.mutex.Unlock()
+		*ctx.errorBuf = 0
+		return ctx, nil
+	}
+	p.mutex.Unlock()
+	return newGeosContext()
+}
+
+// put returns a context to the pool
+func (p *contextPool) put(ctx *geosContext) {
+	p.mutex.Lock()
+	p.idle = append(p.idle, ctx)
+	p.mutex.Unlock()
+}
+
+// drain releases every idle context. The service lock must be held for
+// writing, so that no context is checked out.
+func (p *contextPool) drain() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, ctx := range p.idle {
+		ctx.finish()
+	}
+	p.idle = nil
+}
+
 // Geometry represents a spatial geometry with automatic cleanup.
 // It wraps a GEOS geometry object and maintains a reference to the service
 // that created it to ensure proper cleanup. Geometry objects are automatically
@@ -156,13 +408,18 @@ func (s *Service) Close() {
 // Note: Geometry objects are not thread-safe on their own, but operations
 // on them through the Service are thread-safe.
 type Geometry struct {
-	geom    *C.struct_GEOSGeom_t
-	service *Service
+	geom       *C.struct_GEOSGeom_t
+	service    *Service
+	generation uint64
+	geomType   GeometryType // cached at creation, see Type
 }
 
 // GeometryInput represents input geometry data that can be either WKT or GeoJSON format.
-// Only one of WKT or GeoJSON should be provided. The SRID field is optional and
-// currently not used in processing but reserved for future spatial reference system support.
+// Only one of WKT or GeoJSON should be provided. The optional SRID is set on
+// the parsed geometry, see Geometry.SRID; WKT may instead carry it as an EWKT
+// "SRID=n;" prefix.
+// ParseGeometry rejects invalid geometries unless AllowInvalid is set, which lets
+// broken input be loaded and then repaired with MakeValid.
 //
 // Supported GeoJSON types: Point, LineString, Polygon
 // Supported WKT types: All standard OGC WKT geometry types
@@ -182,40 +439,91 @@ type Geometry struct {
 //		},
 //	}
 type GeometryInput struct {
-	WKT     string                 `json:"wkt,omitempty"`
-	GeoJSON map[string]interface{} `json:"geojson,omitempty"`
-	SRID    int                    `json:"srid,omitempty"`
+	WKT          string                 `json:"wkt,omitempty"`
+	GeoJSON      map[string]interface{} `json:"geojson,omitempty"`
+	SRID         int                    `json:"srid,omitempty"`
+	AllowInvalid bool                   `json:"allow_invalid,omitempty"`
 }
 
 // newGeometry creates a new geometry with cleanup
-func (s *Service) newGeometry(geom *C.struct_GEOSGeom_t) *Geometry {
+func (c *session) newGeometry(geom *C.struct_GEOSGeom_t) *Geometry {
 	if geom == nil {
 		return nil
 	}
 
 	g := &Geometry{
-		geom:    geom,
-		service: s,
+		geom:       geom,
+		service:    c.Service,
+		generation: c.generation,
+		geomType:   geometryType(C.GEOSGeomTypeId_r(c.context, geom)),
 	}
+	c.live.Add(1)
 
 	// Set finalizer for automatic cleanup
-	runtime.SetFinalizer(g, (*Geometry).destroy)
+	if !c.noFinalizers {
+		runtime.SetFinalizer(g, (*Geometry).destroy)
+	}
 	return g
 }
 
+// Destroy releases the GEOS memory of the geometry right away instead of
+// when the garbage collector finalizes the geometry. The garbage collector
+// only sees the small Go wrapper, not the C memory behind it, so pipelines
+// that create geometries at a high rate can run out of memory long before it
+// collects them; destroying intermediate results as soon as they are no
+// longer needed keeps memory use flat. Afterwards every operation rejects
+// the geometry as invalid. Destroy is safe to call multiple times, but must
+// not be called while the geometry is in use by another goroutine, indexed,
+// prepared or part of a containment hierarchy. Results that are the same
+// object as an input, such as the result of Union with a single geometry,
+// destroy that input as well.
+//
+// Example:
+//
+//	for _, row := range rows {
+//		geom, _ := service.ParseGeometry(row)
+//		buffered, _ := service.Buffer(geom, 10)
+//		geom.Destroy()
+//
+//		wkt, _ := service.ToWKT(buffered)
+//		buffered.Destroy()
+//		write(wkt)
+//	}
+func (g *Geometry) Destroy() {
+	if g != nil {
+		g.destroy()
+	}
+}
+
 // destroy cleans up geometry resources
 func (g *Geometry) destroy() {
-	if g.geom != nil && g.service != nil && g.service.context != nil {
-		g.service.mutex.RLock()
-		if g.service.context != nil {
-			C.GEOSGeom_destroy_r(g.service.context, g.geom)
+	if g.geom != nil && g.service != nil {
+		// Geometries of a closed service are left alone, since all of its
+		// contexts have been released
+		if c, err := g.service.acquire(); err == nil {
+			C.GEOSGeom_destroy_r(c.context, g.geom)
+			c.release()
 		}
-		g.service.mutex.RUnlock()
 		g.geom = nil
+		g.service.live.Add(-1)
 	}
 	runtime.SetFinalizer(g, nil)
 }
 
+// discard destroys geometries the session created for a result it gives up
+// on after an error. Without finalizers nothing else would release them.
+// Nil geometries are skipped.
+func (c *session) discard(geoms ...*Geometry) {
+	for _, g := range geoms {
+		if g != nil && g.geom != nil {
+			C.GEOSGeom_destroy_r(c.context, g.geom)
+			g.geom = nil
+			c.live.Add(-1)
+			runtime.SetFinalizer(g, nil)
+		}
+	}
+}
+
 // ParseGeometry parses WKT or GeoJSON input into a GEOS geometry object.
 // It supports both WKT strings and GeoJSON objects, with validation for
 // proper format and geometric validity.
@@ -229,7 +537,10 @@ func (g *Geometry) destroy() {
 //
 // Supported formats:
 //   - WKT: Well-Known Text format (e.g., "POINT(1.0 2.0)")
-//   - GeoJSON: Point, LineString, and Polygon geometries
+//   - GeoJSON: Point, LineString, and Polygon geometries, with a third
+//     position value read as Z and a fourth as M. Coordinates are copied into
+//     GEOS as they are, without a round trip through WKT text, and may also
+//     be given as []float64, [][]float64 or [][][]float64.
 //
 // Example:
 //
@@ -239,19 +550,106 @@ func (g *Geometry) destroy() {
 //		return fmt.Errorf("failed to parse geometry: %w", err)
 //	}
 func (s *Service) ParseGeometry(input GeometryInput) (*Geometry, error) {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
+	c, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	return c.parse("ParseGeometry", input)
+}
 
-	if s.context == nil {
-		return nil, errors.New("GEOS context is not initialized")
+// ParseGeometries parses a batch of inputs like ParseGeometry, sharing one
+// GEOS context and its WKT reader across the batch. This removes most
+// of the per-call overhead when loading large tables of WKT or GeoJSON rows.
+// A bad input does not stop the batch: its geometry is nil and its error is
+// set at the same index, while every other input is still parsed. The
+// service lock is held for the whole batch, so Close waits for it to finish.
+//
+// Parameters:
+//   - inputs: The inputs to parse
+//
+// Returns:
+//   - []*Geometry: The parsed geometries in input order, nil where parsing failed
+//   - []error: The error of each input in input order, nil where parsing succeeded
+//
+// Example:
+//
+//	inputs := make([]GeometryInput, len(rows))
+//	for i, row := range rows {
+//		inputs[i] = GeometryInput{WKT: row.WKT, SRID: 4326}
+//	}
+//
+//	geoms, errs := service.ParseGeometries(inputs)
+//	for i, err := range errs {
+//		if err != nil {
+//			log.Printf("row %d: %v", i, err)
+//		}
+//	}
+func (s *Service) ParseGeometries(inputs []GeometryInput) ([]*Geometry, []error) {
+	geoms := make([]*Geometry, len(inputs))
+	errs := make([]error, len(inputs))
+
+	c, err := s.acquire()
+	if err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return geoms, errs
+	}
+	defer c.release()
+
+	for i, input := range inputs {
+		geoms[i], errs[i] = c.parse("ParseGeometries", input)
 	}
 
-	var wkt string
+	return geoms, errs
+}
+
+// parse converts one input to a geometry, recording it as the named method.
+// WKT is read with the reader of the context, while GeoJSON is built directly
+// into GEOS coordinate sequences.
+func (c *session) parse(method string, input GeometryInput) (*Geometry, error) {
+	if input.SRID < 0 {
+		return nil, fmt.Errorf("invalid SRID: %d", input.SRID)
+	}
 
+	var geom *C.GEOSGeometry
+	var source, description string
+	srid := input.SRID
 	if input.WKT != "" {
-		wkt = input.WKT
+		wktSRID, wkt, err := splitEWKT(input.WKT)
+		if err != nil {
+			return nil, err
+		}
+		if wktSRID != 0 {
+			if srid != 0 && srid != wktSRID {
+				return nil, fmt.Errorf("SRID %d does not match the EWKT SRID %d", srid, wktSRID)
+			}
+			srid = wktSRID
+		}
+
+		// Validate WKT format before parsing
+		if len(wkt) == 0 {
+			return nil, errors.New("empty WKT string")
+		}
+
+		reader, err := c.wktReader()
+		if err != nil {
+			return nil, err
+		}
+
+		// Create C string safely
+		cWKT := C.CString(wkt)
+		defer C.free(unsafe.Pointer(cWKT))
+
+		// Parse geometry with error checking
+		geom = C.GEOSWKTReader_read_r(c.context, reader, cWKT)
+		if geom == nil {
+			return nil, c.geosError(fmt.Sprintf("failed to parse WKT geometry: %s", wkt))
+		}
+		source, description = wkt, wkt
 	} else if input.GeoJSON != nil {
-		// Convert GeoJSON to WKT (improved implementation)
 		geoType, ok := input.GeoJSON["type"].(string)
 		if !ok {
 			return nil, errors.New("invalid GeoJSON: missing type")
@@ -262,100 +660,175 @@ func (s *Service) ParseGeometry(input GeometryInput) (*Geometry, error) {
 			return nil, errors.New("invalid GeoJSON: missing coordinates")
 		}
 
-		var err error
-		wkt, err = s.geoJSONToWKT(geoType, coords)
+		sh, err := geoJSONShape(geoType, coords)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert GeoJSON: %v", err)
+		}
+		if geom, err = c.buildShape(sh); err != nil {
+			return nil, err
+		}
+
+		// The journal identifies GeoJSON input by its canonical encoding
+		text, err := json.Marshal(map[string]interface{}{"type": geoType, "coordinates": coords})
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert GeoJSON to WKT: %v", err)
+			C.GEOSGeom_destroy_r(c.context, geom)
+			return nil, fmt.Errorf("failed to encode GeoJSON: %v", err)
 		}
+		source, description = string(text), "GeoJSON "+geoType
 	} else {
 		return nil, errors.New("no geometry provided: either WKT or GeoJSON is required")
 	}
 
-	// Validate WKT format before parsing
-	if len(wkt) == 0 {
-		return nil, errors.New("empty WKT string")
-	}
-
-	// Create C string safely
-	cWKT := C.CString(wkt)
-	defer C.free(unsafe.Pointer(cWKT))
-
-	// Parse geometry with error checking
-	geom := C.GEOSGeomFromWKT_r(s.context, cWKT)
-	if geom == nil {
-		return nil, fmt.Errorf("failed to parse WKT geometry: %s", wkt)
-	}
-
 	// Validate the parsed geometry
-	if C.GEOSisValid_r(s.context, geom) == 0 {
-		C.GEOSGeom_destroy_r(s.context, geom)
-		return nil, fmt.Errorf("invalid geometry: %s", wkt)
+	if !input.AllowInvalid && C.GEOSisValid_r(c.context, geom) == 0 {
+		C.GEOSGeom_destroy_r(c.context, geom)
+		return nil, fmt.Errorf("invalid geometry: %s", description)
+	}
+	if srid != 0 {
+		C.GEOSSetSRID_r(c.context, geom, C.int(srid))
 	}
 
-	return s.newGeometry(geom), nil
+	g := c.newGeometry(geom)
+	c.recordHashes(method, map[string]any{"allowInvalid": input.AllowInvalid}, []string{hashBytes([]byte(source))}, g)
+	return g, nil
 }
 
-// geoJSONToWKT converts GeoJSON coordinates to WKT (improved implementation)
-func (s *Service) geoJSONToWKT(geoType string, coords interface{}) (string, error) {
+// geoJSONShape converts GeoJSON coordinates to a shape, which is built into
+// GEOS coordinate sequences as is, without formatting and reparsing the
+// ordinates as WKT text. Positions with a third value get Z coordinates and
+// a fourth value is read as M, so all ordinates survive parsing. Besides the
+// []interface{} arrays of decoded JSON, positions may be given as []float64
+// and arrays of them as [][]float64 or [][][]float64.
+func geoJSONShape(geoType string, coords interface{}) (*shape, error) {
 	switch geoType {
 	case "Point":
-		if coordArray, ok := coords.([]interface{}); ok && len(coordArray) >= 2 {
-			x, okX := coordArray[0].(float64)
-			y, okY := coordArray[1].(float64)
-			if okX && okY {
-				return fmt.Sprintf("POINT(%f %f)", x, y), nil
-			}
+		position, err := geoJSONPosition(coords)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Point coordinates: %v", err)
 		}
-		return "", errors.New("invalid Point coordinates")
+		return geoJSONSequence(C.GEOS_POINT, append([]float64(nil), position...), len(position)), nil
 
 	case "Polygon":
-		if rings, ok := coords.([]interface{}); ok && len(rings) > 0 {
-			if ring, ok := rings[0].([]interface{}); ok && len(ring) >= 4 {
-				wkt := "POLYGON(("
-				for i, coord := range ring {
-					if coordArray, ok := coord.([]interface{}); ok && len(coordArray) >= 2 {
-						x, okX := coordArray[0].(float64)
-						y, okY := coordArray[1].(float64)
-						if okX && okY {
-							if i > 0 {
-								wkt += ", "
-							}
-							wkt += fmt.Sprintf("%f %f", x, y)
-						}
-					}
-				}
-				wkt += "))"
-				return wkt, nil
+		rings, ok := geoJSONArray(coords)
+		if !ok || len(rings) == 0 {
+			return nil, errors.New("invalid Polygon coordinates")
+		}
+		polygon := &shape{typeID: C.GEOS_POLYGON, parts: make([]*shape, len(rings))}
+		dims := 0
+		for i, ring := range rings {
+			ordinates, ringDims, err := geoJSONPositions(ring, 4)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Polygon coordinates: ring %d: %v", i, err)
+			}
+			if i > 0 && ringDims != dims {
+				return nil, errors.New("invalid Polygon coordinates: rings have different dimensions")
 			}
+			polygon.parts[i], dims = geoJSONSequence(C.GEOS_LINEARRING, ordinates, ringDims), ringDims
 		}
-		return "", errors.New("invalid Polygon coordinates")
+		polygon.hasZ, polygon.hasM = polygon.parts[0].hasZ, polygon.parts[0].hasM
+		return polygon, nil
 
 	case "LineString":
-		if coords, ok := coords.([]interface{}); ok && len(coords) >= 2 {
-			wkt := "LINESTRING("
-			for i, coord := range coords {
-				if coordArray, ok := coord.([]interface{}); ok && len(coordArray) >= 2 {
-					x, okX := coordArray[0].(float64)
-					y, okY := coordArray[1].(float64)
-					if okX && okY {
-						if i > 0 {
-							wkt += ", "
-						}
-						wkt += fmt.Sprintf("%f %f", x, y)
-					}
-				}
+		ordinates, dims, err := geoJSONPositions(coords, 2)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LineString coordinates: %v", err)
+		}
+		return geoJSONSequence(C.GEOS_LINESTRING, ordinates, dims), nil
+	}
+
+	return nil, fmt.Errorf("unsupported GeoJSON type: %s", geoType)
+}
+
+// geoJSONSequence makes a point, line or ring shape from interleaved
+// ordinates with dims values per position
+func geoJSONSequence(typeID C.int, ordinates []float64, dims int) *shape {
+	return &shape{typeID: typeID, hasZ: dims >= 3, hasM: dims == 4, coords: ordinates}
+}
+
+// geoJSONPositions reads a GeoJSON position array of at least min positions
+// into interleaved ordinates and returns the number of ordinates per position
+func geoJSONPositions(coords interface{}, min int) ([]float64, int, error) {
+	list, ok := geoJSONArray(coords)
+	if !ok || len(list) < min {
+		return nil, 0, fmt.Errorf("at least %d positions are required", min)
+	}
+
+	var ordinates []float64
+	dims := 0
+	for i, coord := range list {
+		position, err := geoJSONPosition(coord)
+		if err != nil {
+			return nil, 0, fmt.Errorf("position %d: %v", i, err)
+		}
+		if i == 0 {
+			dims = len(position)
+			ordinates = make([]float64, 0, dims*len(list))
+		} else if len(position) != dims {
+			return nil, 0, fmt.Errorf("position %d has %d values, but the first has %d", i, len(position), dims)
+		}
+		ordinates = append(ordinates, position...)
+	}
+
+	return ordinates, dims, nil
+}
+
+// geoJSONArray returns the elements of a GeoJSON array of positions or of
+// position arrays
+func geoJSONArray(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case [][]float64:
+		list := make([]interface{}, len(v))
+		for i, position := range v {
+			list[i] = position
+		}
+		return list, true
+	case [][][]float64:
+		list := make([]interface{}, len(v))
+		for i, positions := range v {
+			list[i] = positions
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// geoJSONPosition reads the ordinates of a single GeoJSON position
+func geoJSONPosition(coord interface{}) ([]float64, error) {
+	var values []float64
+	switch c := coord.(type) {
+	case []float64:
+		values = c
+	case []interface{}:
+		values = make([]float64, len(c))
+		for i, v := range c {
+			f, ok := v.(float64)
+			if !ok {
+				return nil, errors.New("position values must be numbers")
 			}
-			wkt += ")"
-			return wkt, nil
+			values[i] = f
+		}
+	default:
+		return nil, errors.New("position must be an array")
+	}
+	if len(values) < 2 || len(values) > 4 {
+		return nil, fmt.Errorf("position has %d values, want 2, 3 or 4", len(values))
+	}
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.New("position values must be finite")
 		}
-		return "", errors.New("invalid LineString coordinates")
 	}
 
-	return "", fmt.Errorf("unsupported GeoJSON type: %s", geoType)
+	return values, nil
 }
 
 // ToWKT converts a geometry object to its Well-Known Text (WKT) representation.
 // This is useful for serializing geometries for storage or transmission.
+// Coordinates always use '.' as the decimal separator and are never written
+// in scientific notation, independent of the process locale, and trailing
+// zeros are trimmed. Z and M values are written when the geometry has them.
 //
 // Parameters:
 //   - geom: The geometry object to convert
@@ -375,26 +848,30 @@ func (s *Service) geoJSONToWKT(geoType string, coords interface{}) (string, erro
 //	if err != nil {
 //		log.Fatal(err)
 //	}
-//	fmt.Println(wkt) // Output: POINT (1.0000000000000000 2.0000000000000000)
+//	fmt.Println(wkt) // Output: POINT (1 2)
 func (s *Service) ToWKT(geom *Geometry) (string, error) {
 	if geom == nil || geom.geom == nil {
 		return "", errors.New("invalid geometry")
 	}
 
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
+	c, err := s.acquire(geom)
+	if err != nil {
+		return "", err
+	}
+	defer c.release()
 
-	if s.context == nil {
-		return "", errors.New("GEOS context is not initialized")
+	writer, err := c.wktWriter()
+	if err != nil {
+		return "", err
 	}
 
-	cWKT := C.GEOSGeomToWKT_r(s.context, geom.geom)
+	cWKT := C.GEOSWKTWriter_write_r(c.context, writer, geom.geom)
 	if cWKT == nil {
-		return "", errors.New("failed to convert geometry to WKT")
+		return "", c.geosError("failed to convert geometry to WKT")
 	}
-	defer C.free(unsafe.Pointer(cWKT))
+	defer C.GEOSFree_r(c.context, unsafe.Pointer(cWKT))
 
-	return C.GoString(cWKT), nil
+	return formatCoordinates(C.GoString(cWKT), -1), nil
 }
 
 // Within tests whether geometry A is completely within geometry B.
@@ -424,16 +901,15 @@ func (s *Service) Within(a, b *Geometry) (bool, error) {
 		return false, errors.New("invalid geometry")
 	}
 
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	if s.context == nil {
-		return false, errors.New("GEOS context is not initialized")
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return false, err
 	}
+	defer c.release()
 
-	result := C.GEOSWithin_r(s.context, a.geom, b.geom)
+	result := C.GEOSWithin_r(c.context, a.geom, b.geom)
 	if result == 2 { // Error case
-		return false, errors.New("GEOS within operation failed")
+		return false, c.geosError("GEOS within operation failed")
 	}
 
 	return result == 1, nil
@@ -466,16 +942,15 @@ func (s *Service) Intersects(a, b *Geometry) (bool, error) {
 		return false, errors.New("invalid geometry")
 	}
 
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	if s.context == nil {
-		return false, errors.New("GEOS context is not initialized")
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return false, err
 	}
+	defer c.release()
 
-	result := C.GEOSIntersects_r(s.context, a.geom, b.geom)
+	result := C.GEOSIntersects_r(c.context, a.geom, b.geom)
 	if result == 2 { // Error case
-		return false, errors.New("GEOS intersects operation failed")
+		return false, c.geosError("GEOS intersects operation failed")
 	}
 
 	return result == 1, nil
@@ -509,17 +984,16 @@ func (s *Service) Distance(a, b *Geometry) (float64, error) {
 		return 0, errors.New("invalid geometry")
 	}
 
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	if s.context == nil {
-		return 0, errors.New("GEOS context is not initialized")
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return 0, err
 	}
+	defer c.release()
 
 	var distance C.double
-	result := C.GEOSDistance_r(s.context, a.geom, b.geom, &distance)
+	result := C.GEOSDistance_r(c.context, a.geom, b.geom, &distance)
 	if result == 0 {
-		return 0, errors.New("failed to calculate distance")
+		return 0, c.geosError("failed to calculate distance")
 	}
 
 	return float64(distance), nil
@@ -534,7 +1008,8 @@ func (s *Service) Distance(a, b *Geometry) (float64, error) {
 //   - radius: The buffer distance (positive for expansion, negative for contraction)
 //
 // Returns:
-//   - *Geometry: A new geometry representing the buffer zone
+//   - *Geometry: A new geometry representing the buffer zone, an empty
+//     polygon if a negative radius shrinks the geometry away
 //   - error: An error if the operation fails
 //
 // Example:
@@ -549,19 +1024,18 @@ func (s *Service) Buffer(geom *Geometry, radius float64) (*Geometry, error) {
 		return nil, errors.New("invalid geometry")
 	}
 
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	if s.context == nil {
-		return nil, errors.New("GEOS context is not initialized")
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
 	}
+	defer c.release()
 
-	buffered := C.GEOSBuffer_r(s.context, geom.geom, C.double(radius), 8)
+	buffered := C.GEOSBuffer_r(c.context, geom.geom, C.double(radius), 8)
 	if buffered == nil {
-		return nil, errors.New("failed to create buffer")
+		return nil, c.geosError("failed to create buffer")
 	}
 
-	return s.newGeometry(buffered), nil
+	return c.newDerivedGeometry("Buffer", map[string]any{"radius": radius}, buffered, geom), nil
 }
 
 // Simplify simplifies a geometry using the Douglas-Peucker algorithm.
@@ -588,19 +1062,18 @@ func (s *Service) Simplify(geom *Geometry, tolerance float64) (*Geometry, error)
 		return nil, errors.New("invalid geometry")
 	}
 
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	if s.context == nil {
-		return nil, errors.New("GEOS context is not initialized")
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
 	}
+	defer c.release()
 
-	simplified := C.GEOSSimplify_r(s.context, geom.geom, C.double(tolerance))
+	simplified := C.GEOSSimplify_r(c.context, geom.geom, C.double(tolerance))
 	if simplified == nil {
-		return nil, errors.New("failed to simplify geometry")
+		return nil, c.geosError("failed to simplify geometry")
 	}
 
-	return s.newGeometry(simplified), nil
+	return c.newDerivedGeometry("Simplify", map[string]any{"tolerance": tolerance}, simplified, geom), nil
 }
 
 // Union creates a union of multiple geometries.
@@ -629,30 +1102,50 @@ func (s *Service) Union(geometries []*Geometry) (*Geometry, error) {
 		return nil, errors.New("no geometries provided")
 	}
 
+	// Nil entries are skipped, but at least one geometry must remain
+	first := 0
+	for first < len(geometries) && (geometries[first] == nil || geometries[first].geom == nil) {
+		first++
+	}
+	if first == len(geometries) {
+		return nil, errors.New("invalid geometry")
+	}
 	if len(geometries) == 1 {
-		return geometries[0], nil
+		return geometries[first], nil
 	}
 
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	if s.context == nil {
-		return nil, errors.New("GEOS context is not initialized")
+	c, err := s.acquire(geometries...)
+	if err != nil {
+		return nil, err
 	}
+	defer c.release()
 
-	result := geometries[0]
-	for i := 1; i < len(geometries); i++ {
+	// Intermediate unions stay raw GEOS geometries and each one is destroyed
+	// as soon as the next one replaces it
+	var union *C.GEOSGeometry
+	for i := first + 1; i < len(geometries); i++ {
 		if geometries[i] == nil || geometries[i].geom == nil {
 			continue
 		}
 
-		union := C.GEOSUnion_r(s.context, result.geom, geometries[i].geom)
+		previous := union
+		if previous == nil {
+			union = C.GEOSUnion_r(c.context, geometries[first].geom, geometries[i].geom)
+		} else {
+			union = C.GEOSUnion_r(c.context, previous, geometries[i].geom)
+			C.GEOSGeom_destroy_r(c.context, previous)
+		}
 		if union == nil {
-			return nil, errors.New("failed to create union")
+			return nil, c.geosError("failed to create union")
 		}
+	}
 
-		result = s.newGeometry(union)
+	result := geometries[first]
+	if union != nil {
+		result = c.newGeometry(union)
 	}
+	c.inheritSRID(geometries, result)
+	c.record("Union", nil, geometries, result)
 
 	return result, nil
 }
@@ -666,7 +1159,8 @@ func (s *Service) Union(geometries []*Geometry) (*Geometry, error) {
 //   - b: The geometry to subtract
 //
 // Returns:
-//   - *Geometry: A new geometry representing A - B
+//   - *Geometry: A new geometry representing A - B, an empty geometry if B
+//     covers A
 //   - error: An error if the operation fails
 //
 // Example:
@@ -684,19 +1178,18 @@ func (s *Service) Difference(a, b *Geometry) (*Geometry, error) {
 		return nil, errors.New("invalid geometry")
 	}
 
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	if s.context == nil {
-		return nil, errors.New("GEOS context is not initialized")
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return nil, err
 	}
+	defer c.release()
 
-	diff := C.GEOSDifference_r(s.context, a.geom, b.geom)
+	diff := C.GEOSDifference_r(c.context, a.geom, b.geom)
 	if diff == nil {
-		return nil, errors.New("failed to create difference")
+		return nil, c.geosError("failed to create difference")
 	}
 
-	return s.newGeometry(diff), nil
+	return c.newDerivedGeometry("Difference", nil, diff, a, b), nil
 }
 
 // ValidateGeometry validates input geometry format without full parsing.
@@ -727,7 +1220,10 @@ func (s *Service) ValidateGeometry(input GeometryInput) error {
 			return errors.New("empty WKT string")
 		}
 		// Check for basic WKT keywords
-		wkt := input.WKT
+		_, wkt, err := splitEWKT(input.WKT)
+		if err != nil {
+			return err
+		}
 		validTypes := []string{"POINT", "LINESTRING", "POLYGON", "MULTIPOINT", "MULTILINESTRING", "MULTIPOLYGON", "GEOMETRYCOLLECTION"}
 		isValid := false
 		for _, validType := range validTypes {
@@ -1,7 +1,10 @@
 package geos
 
 import (
+	"errors"
+	"math"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -17,8 +20,8 @@ func TestNewService(t *testing.T) {
 		t.Fatal("Service should not be nil")
 	}
 
-	if service.context == nil {
-		t.Fatal("Service context should not be nil")
+	if len(service.pool.idle) != 1 {
+		t.Fatal("Service should have an initialized GEOS context")
 	}
 }
 
@@ -32,9 +35,9 @@ func TestServiceClose(t *testing.T) {
 	// Close the service
 	service.Close()
 
-	// Verify context is cleaned up
-	if service.context != nil {
-		t.Error("Service context should be nil after Close()")
+	// Verify contexts are cleaned up
+	if !service.closed || len(service.pool.idle) != 0 {
+		t.Error("Service contexts should be released after Close()")
 	}
 
 	// Multiple closes should be safe
@@ -181,6 +184,55 @@ func TestParseGeometry_GeoJSON(t *testing.T) {
 	}
 }
 
+// TestParseGeometry_GeoJSONCoordinates tests that GeoJSON coordinates reach GEOS unchanged
+func TestParseGeometry_GeoJSONCoordinates(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	// Typed slices are accepted like decoded JSON arrays
+	polygon := helper.ParseGeoJSON(map[string]interface{}{
+		"type":        "Polygon",
+		"coordinates": [][][]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+	})
+	wkt, err := helper.service.ToWKTWithOptions(polygon, WKTOptions{Trim: true})
+	if err != nil {
+		t.Fatalf("Failed to convert to WKT: %v", err)
+	}
+	if wkt != "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))" {
+		t.Errorf("Expected the unit square, got %s", wkt)
+	}
+
+	line := helper.ParseGeoJSON(map[string]interface{}{
+		"type":        "LineString",
+		"coordinates": [][]float64{{0, 0, 5}, {1, 1, 6}},
+	})
+	if hasZ, err := helper.service.HasZ(line); err != nil || !hasZ {
+		t.Errorf("Expected a line with Z, got %v, %v", hasZ, err)
+	}
+
+	// Ordinates keep every bit of precision
+	x, y := 0.1+0.2, 1.0/3
+	point := helper.ParseGeoJSON(map[string]interface{}{"type": "Point", "coordinates": []float64{x, y}})
+	if got, err := helper.service.X(point); err != nil || got != x {
+		t.Errorf("Expected X %v, got %v, %v", x, got, err)
+	}
+	if got, err := helper.service.Y(point); err != nil || got != y {
+		t.Errorf("Expected Y %v, got %v, %v", y, got, err)
+	}
+
+	invalid := []map[string]interface{}{
+		{"type": "Point", "coordinates": []float64{math.NaN(), 0}},
+		{"type": "Point", "coordinates": []float64{1}},
+		{"type": "LineString", "coordinates": [][]float64{{0, 0}}},
+		{"type": "Polygon", "coordinates": [][][]float64{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}},
+	}
+	for _, geoJSON := range invalid {
+		if _, err := helper.service.ParseGeometry(GeometryInput{GeoJSON: geoJSON}); err == nil {
+			t.Errorf("Expected error for %v", geoJSON)
+		}
+	}
+}
+
 // TestParseGeometry_EmptyInput tests parsing with empty input
 func TestParseGeometry_EmptyInput(t *testing.T) {
 	service, err := NewService()
@@ -200,6 +252,56 @@ func TestParseGeometry_EmptyInput(t *testing.T) {
 	}
 }
 
+// TestParseGeometries tests parsing a batch of inputs with per-input errors
+func TestParseGeometries(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	inputs := []GeometryInput{
+		{WKT: "POINT(1 2)"},
+		{WKT: "NOT WKT"},
+		{GeoJSON: map[string]interface{}{"type": "LineString", "coordinates": []interface{}{[]interface{}{0.0, 0.0}, []interface{}{1.0, 1.0}}}},
+		{},
+		{WKT: "SRID=4326;POINT(3 4)"},
+		{WKT: "POLYGON((0 0, 2 2, 2 0, 0 2, 0 0))"},
+	}
+	expected := []string{"POINT (1 2)", "", "LINESTRING (0 0, 1 1)", "", "POINT (3 4)", ""}
+
+	geoms, errs := helper.service.ParseGeometries(inputs)
+	if len(geoms) != len(inputs) || len(errs) != len(inputs) {
+		t.Fatalf("Expected %d results, got %d geometries and %d errors", len(inputs), len(geoms), len(errs))
+	}
+	for i, want := range expected {
+		if want == "" {
+			if errs[i] == nil || geoms[i] != nil {
+				t.Errorf("Input %d: expected an error and no geometry, got %v and %v", i, geoms[i], errs[i])
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Errorf("Input %d: unexpected error: %v", i, errs[i])
+			continue
+		}
+		wkt, err := helper.service.ToWKTWithOptions(geoms[i], WKTOptions{Trim: true})
+		if err != nil || wkt != want {
+			t.Errorf("Input %d: expected %s, got %s (%v)", i, want, wkt, err)
+		}
+	}
+	if geoms[4].SRID() != 4326 {
+		t.Errorf("Expected SRID 4326 from EWKT, got %d", geoms[4].SRID())
+	}
+
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create GEOS service: %v", err)
+	}
+	service.Close()
+	_, errs = service.ParseGeometries(inputs[:2])
+	if errs[0] == nil || errs[1] == nil {
+		t.Error("Expected every input to fail on a closed service")
+	}
+}
+
 // TestToWKT tests converting geometry to WKT
 func TestToWKT(t *testing.T) {
 	service, err := NewService()
@@ -852,4 +954,243 @@ func TestServiceClosedContext(t *testing.T) {
 	if !strings.Contains(err.Error(), "not initialized") {
 		t.Errorf("Expected 'not initialized' error, got: %v", err)
 	}
-}
\ No newline at end of file
+}
+// TestServiceCloseDuringOperations tests closing a service while operations are in flight
+func TestServiceCloseDuringOperations(t *testing.T) {
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create GEOS service: %v", err)
+	}
+
+	polygon, err := service.ParseGeometry(GeometryInput{WKT: "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))"})
+	if err != nil {
+		t.Fatalf("Failed to parse geometry: %v", err)
+	}
+
+	const numGoroutines = 8
+	var wg sync.WaitGroup
+	failures := make(chan error, numGoroutines)
+
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if _, err := service.Buffer(polygon, 1.0); err != nil {
+					if !errors.Is(err, ErrServiceClosed) {
+						failures <- err
+					}
+					return
+				}
+			}
+		}()
+	}
+
+	service.Close()
+	wg.Wait()
+	close(failures)
+
+	for err := range failures {
+		t.Errorf("Expected ErrServiceClosed or success, got: %v", err)
+	}
+
+	if _, err := service.Buffer(polygon, 1.0); !errors.Is(err, ErrServiceClosed) {
+		t.Errorf("Expected ErrServiceClosed after Close, got: %v", err)
+	}
+}
+
+// TestServiceReset tests rebuilding the GEOS context
+func TestServiceReset(t *testing.T) {
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create GEOS service: %v", err)
+	}
+	defer service.Close()
+
+	before, err := service.ParseGeometry(GeometryInput{WKT: "POINT(1 1)"})
+	if err != nil {
+		t.Fatalf("Failed to parse geometry: %v", err)
+	}
+
+	if err := service.Reset(); err != nil {
+		t.Fatalf("Failed to reset service: %v", err)
+	}
+
+	// Geometries from before the reset are invalidated
+	if _, err := service.Buffer(before, 1.0); !errors.Is(err, ErrGeometryInvalidated) {
+		t.Errorf("Expected ErrGeometryInvalidated, got: %v", err)
+	}
+
+	// New geometries work as usual
+	after, err := service.ParseGeometry(GeometryInput{WKT: "POINT(1 1)"})
+	if err != nil {
+		t.Fatalf("Failed to parse geometry after reset: %v", err)
+	}
+	if _, err := service.Buffer(after, 1.0); err != nil {
+		t.Errorf("Unexpected error after reset: %v", err)
+	}
+
+	service.Close()
+	if err := service.Reset(); !errors.Is(err, ErrServiceClosed) {
+		t.Errorf("Expected ErrServiceClosed resetting a closed service, got: %v", err)
+	}
+}
+
+// TestContextPool tests that concurrent operations check out contexts of their own
+func TestContextPool(t *testing.T) {
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create GEOS service: %v", err)
+	}
+	defer service.Close()
+
+	polygon, err := service.ParseGeometry(GeometryInput{WKT: "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))"})
+	if err != nil {
+		t.Fatalf("Failed to parse geometry: %v", err)
+	}
+
+	// Hold several sessions at once, as concurrent operations do
+	sessions := make([]*session, 4)
+	for i := range sessions {
+		if sessions[i], err = service.acquire(polygon); err != nil {
+			t.Fatalf("Failed to acquire session: %v", err)
+		}
+	}
+	for i, c := range sessions {
+		for _, other := range sessions[:i] {
+			if c.geosContext == other.geosContext {
+				t.Fatal("Concurrent sessions share a GEOS context")
+			}
+		}
+	}
+	for _, c := range sessions {
+		c.release()
+	}
+
+	// Released contexts are reused rather than created again
+	if n := len(service.pool.idle); n != len(sessions) {
+		t.Fatalf("Expected %d idle contexts, got %d", len(sessions), n)
+	}
+	if _, err := service.Buffer(polygon, 1.0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := len(service.pool.idle); n != len(sessions) {
+		t.Errorf("Expected %d idle contexts after reuse, got %d", len(sessions), n)
+	}
+
+	// Reset keeps a single fresh context
+	if err := service.Reset(); err != nil {
+		t.Fatalf("Failed to reset service: %v", err)
+	}
+	if n := len(service.pool.idle); n != 1 {
+		t.Errorf("Expected 1 idle context after Reset, got %d", n)
+	}
+}
+
+// TestGeometryDestroy tests releasing geometries explicitly
+func TestGeometryDestroy(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	geom := helper.ParseWKT("POINT (1 1)")
+	geom.Destroy()
+	if _, err := helper.service.Buffer(geom, 1.0); err == nil {
+		t.Error("Expected error using a destroyed geometry")
+	}
+
+	// Destroying again, or a nil geometry, is a no-op
+	geom.Destroy()
+	var missing *Geometry
+	missing.Destroy()
+
+	// Without finalizers geometries are released by Destroy alone
+	helper.service.SetGeometryFinalizers(false)
+	for i := 0; i < 100; i++ {
+		point := helper.ParseWKT("POINT (1 1)")
+		buffered, err := helper.service.Buffer(point, 1.0)
+		if err != nil {
+			t.Fatalf("Buffer failed: %v", err)
+		}
+		point.Destroy()
+		if empty, err := helper.service.IsEmpty(buffered); err != nil || empty {
+			t.Errorf("Expected the buffer to outlive its input, got empty %v, %v", empty, err)
+		}
+		buffered.Destroy()
+	}
+	helper.service.SetGeometryFinalizers(true)
+
+	// Destroying after the service is closed is safe
+	kept := helper.ParseWKT("POINT (2 2)")
+	helper.service.Close()
+	kept.Destroy()
+}
+
+// TestNoFinalizerLeaks tests that operations release every geometry they
+// give up on when finalizers are off
+func TestNoFinalizerLeaks(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	helper.service.SetGeometryFinalizers(false)
+	defer helper.service.SetGeometryFinalizers(true)
+	before := helper.service.live.Load()
+
+	// Union keeps its intermediate results to itself
+	polygons := []*Geometry{
+		helper.ParseWKT("POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))"),
+		helper.ParseWKT("POLYGON((1 1, 3 1, 3 3, 1 3, 1 1))"),
+		nil,
+		helper.ParseWKT("POLYGON((2 2, 4 2, 4 4, 2 4, 2 2))"),
+	}
+	union := helper.AssertUnion(polygons)
+	if n := helper.service.live.Load() - before; n != 4 {
+		t.Errorf("Expected 3 inputs and 1 union alive, got %d geometries", n)
+	}
+	union.Destroy()
+
+	// Rows parsed before a failing row are released with the batch
+	arr, err := helper.service.ToWKBArray(polygons)
+	if err != nil {
+		t.Fatalf("ToWKBArray failed: %v", err)
+	}
+	end := arr.Offsets[len(arr.Offsets)-1]
+	arr.Offsets = append(arr.Offsets, end+3)
+	arr.Data = append(arr.Data, 1, 99, 0)
+	arr.Validity[0] |= 1 << 4
+	if _, err := helper.service.FromWKBArray(arr); err == nil {
+		t.Error("Expected error for the garbage row")
+	}
+
+	for _, p := range polygons {
+		p.Destroy()
+	}
+	if n := helper.service.live.Load() - before; n != 0 {
+		t.Errorf("Expected every geometry to be released, %d leaked", n)
+	}
+}
+
+// TestGEOSErrorMessages tests that GEOS error details are reported and cleared per operation
+func TestGEOSErrorMessages(t *testing.T) {
+	service, err := NewService()
+	if err != nil {
+		t.Fatalf("Failed to create GEOS service: %v", err)
+	}
+	defer service.Close()
+
+	_, err = service.ParseGeometry(GeometryInput{WKT: "POINT(1"})
+	if err == nil {
+		t.Fatal("Expected error for malformed WKT")
+	}
+	if !strings.Contains(err.Error(), "ParseException") {
+		t.Errorf("Expected GEOS error detail, got: %v", err)
+	}
+
+	// The error state does not leak into later operations
+	geom, err := service.ParseGeometry(GeometryInput{WKT: "POINT(1 1)"})
+	if err != nil {
+		t.Fatalf("Unexpected error after failed parse: %v", err)
+	}
+	if _, err := service.Buffer(geom, 1.0); err != nil {
+		t.Errorf("Unexpected error after failed parse: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+// ioHandles are the WKT and WKB readers and writers of a GEOS context. They
+// are created on first use and kept until the context is released, so hot
+// parse and serialize loops do not allocate a reader or writer per call.
+// Writers are configured by every call that uses them, since the previous
+// user may have left other settings behind.
+type ioHandles struct {
+	wktReader *C.GEOSWKTReader
+	wkbReader *C.GEOSWKBReader
+
+	// wktWriter keeps the default settings of GEOS apart from the output
+	// dimension, while wktOptionsWriter is configured from WKTOptions
+	wktWriter        *C.GEOSWKTWriter
+	wktOptionsWriter *C.GEOSWKTWriter
+	wkbWriter        *C.GEOSWKBWriter
+}
+
+// wktReader returns the WKT reader of the context
+func (g *geosContext) wktReader() (*C.GEOSWKTReader, error) {
+	if g.io.wktReader == nil {
+		if g.io.wktReader = C.GEOSWKTReader_create_r(g.context); g.io.wktReader == nil {
+			return nil, g.geosError("failed to create WKT reader")
+		}
+	}
+	return g.io.wktReader, nil
+}
+
+// wkbReader returns the WKB reader of the context
+func (g *geosContext) wkbReader() (*C.GEOSWKBReader, error) {
+	if g.io.wkbReader == nil {
+		if g.io.wkbReader = C.GEOSWKBReader_create_r(g.context); g.io.wkbReader == nil {
+			return nil, g.geosError("failed to create WKB reader")
+		}
+	}
+	return g.io.wkbReader, nil
+}
+
+// wktWriter returns the WKT writer of the context with the default settings
+// of GEOS, except that Z and M values are always written and trimming is set
+// explicitly
+func (g *geosContext) wktWriter() (*C.GEOSWKTWriter, error) {
+	if g.io.wktWriter == nil {
+		if g.io.wktWriter = C.GEOSWKTWriter_create_r(g.context); g.io.wktWriter == nil {
+			return nil, g.geosError("failed to create WKT writer")
+		}
+
+		// Dimension 4 needs GEOS 3.12, the minimum version checked in geos.go;
+		// earlier versions reject anything but 2 or 3 and fail every write
+		C.GEOSWKTWriter_setOutputDimension_r(g.context, g.io.wktWriter, 4)
+		C.GEOSWKTWriter_setTrim_r(g.context, g.io.wktWriter, 1)
+	}
+	return g.io.wktWriter, nil
+}
+
+// wktOptionsWriter returns a WKT writer of the context configured with the
+// given output dimension and options, which must have been validated
+func (g *geosContext) wktOptionsWriter(dimension int, opts WKTOptions) (*C.GEOSWKTWriter, error) {
+	if g.io.wktOptionsWriter == nil {
+		if g.io.wktOptionsWriter = C.GEOSWKTWriter_create_r(g.context); g.io.wktOptionsWriter == nil {
+			return nil, g.geosError("failed to create WKT writer")
+		}
+	}
+	writer := g.io.wktOptionsWriter
+
+	precision := -1 // full precision
+	if opts.Precision > 0 {
+		precision = opts.Precision
+	}
+	C.GEOSWKTWriter_setOutputDimension_r(g.context, writer, C.int(dimension))
+	C.GEOSWKTWriter_setOld3D_r(g.context, writer, boolToCInt(opts.Old3D))
+	C.GEOSWKTWriter_setTrim_r(g.context, writer, C.char(boolToCInt(opts.Trim)))
+	C.GEOSWKTWriter_setRoundingPrecision_r(g.context, writer, C.int(precision))
+
+	return writer, nil
+}
+
+// wkbWriter returns the WKB writer of the context configured to write
+// little-endian WKB of the given flavor with Z and M values, and the SRID if
+// includeSRID is set
+func (g *geosContext) wkbWriter(flavor C.int, includeSRID bool) (*C.GEOSWKBWriter, error) {
+	if g.io.wkbWriter == nil {
+		if g.io.wkbWriter = C.GEOSWKBWriter_create_r(g.context); g.io.wkbWriter == nil {
+			return nil, g.geosError("failed to create WKB writer")
+		}
+	}
+	writer := g.io.wkbWriter
+
+	C.GEOSWKBWriter_setOutputDimension_r(g.context, writer, 4) // GEOS 3.12, as for WKT
+	C.GEOSWKBWriter_setByteOrder_r(g.context, writer, C.GEOS_WKB_NDR)
+	C.GEOSWKBWriter_setFlavor_r(g.context, writer, flavor)
+	C.GEOSWKBWriter_setIncludeSRID_r(g.context, writer, C.char(boolToCInt(includeSRID)))
+
+	return writer, nil
+}
+
+// destroy releases the handles that were created
+func (h *ioHandles) destroy(ctx C.GEOSContextHandle_t) {
+	if h.wktReader != nil {
+		C.GEOSWKTReader_destroy_r(ctx, h.wktReader)
+	}
+	if h.wkbReader != nil {
+		C.GEOSWKBReader_destroy_r(ctx, h.wkbReader)
+	}
+	if h.wktWriter != nil {
+		C.GEOSWKTWriter_destroy_r(ctx, h.wktWriter)
+	}
+	if h.wktOptionsWriter != nil {
+		C.GEOSWKTWriter_destroy_r(ctx, h.wktOptionsWriter)
+	}
+	if h.wkbWriter != nil {
+		C.GEOSWKBWriter_destroy_r(ctx, h.wkbWriter)
+	}
+	*h = ioHandles{}
+}
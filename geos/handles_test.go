@@ -0,0 +1,62 @@
+package geos
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestIOHandles tests that readers and writers are reused without leaking settings between calls
+func TestIOHandles(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	geom := helper.ParseWKT("SRID=4326;POINT (1.123456 2.5)")
+
+	// Rounding from one call does not affect the next
+	rounded, err := helper.service.ToWKTWithOptions(geom, WKTOptions{Precision: 2, Trim: true})
+	if err != nil {
+		t.Fatalf("Failed to convert to WKT: %v", err)
+	}
+	if rounded != "POINT (1.12 2.5)" {
+		t.Errorf("Expected POINT (1.12 2.5), got %s", rounded)
+	}
+	full, err := helper.service.ToWKTWithOptions(geom, WKTOptions{Trim: true})
+	if err != nil {
+		t.Fatalf("Failed to convert to WKT: %v", err)
+	}
+	if full != "POINT (1.123456 2.5)" {
+		t.Errorf("Expected POINT (1.123456 2.5), got %s", full)
+	}
+
+	// EWKB settings do not leak into plain WKB
+	ewkb, err := helper.service.ToEWKB(geom)
+	if err != nil {
+		t.Fatalf("Failed to convert to EWKB: %v", err)
+	}
+	arr, err := helper.service.ToWKBArray([]*Geometry{geom})
+	if err != nil {
+		t.Fatalf("Failed to convert to WKB: %v", err)
+	}
+	if wkb := arr.Value(0); len(wkb) != len(ewkb)-4 || bytes.Equal(wkb, ewkb) {
+		t.Errorf("Expected plain WKB without SRID, got %x", wkb)
+	}
+
+	// The handles are created once per context and reused afterwards
+	c, err := helper.service.acquire()
+	if err != nil {
+		t.Fatalf("Failed to acquire session: %v", err)
+	}
+	defer c.release()
+
+	reader, err := c.wktReader()
+	if err != nil {
+		t.Fatalf("Failed to get WKT reader: %v", err)
+	}
+	again, err := c.wktReader()
+	if err != nil {
+		t.Fatalf("Failed to get WKT reader: %v", err)
+	}
+	if reader != again {
+		t.Error("Expected the WKT reader to be reused")
+	}
+}
@@ -0,0 +1,360 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// HierarchyLayer is one level of a containment hierarchy, e.g. all countries
+// or all cities. Layers are ordered from the coarsest to the finest.
+type HierarchyLayer struct {
+	// Name identifies the layer in lookup results, e.g. "region"
+	Name string
+
+	// Features are the areas of the layer; results refer to them by index
+	Features []*Geometry
+}
+
+// HierarchyMatch is a feature containing a looked up point
+type HierarchyMatch struct {
+	// Level is the index of the layer the feature belongs to
+	Level int `json:"level"`
+
+	// Layer is the name of that layer
+	Layer string `json:"layer"`
+
+	// Index is the index of the feature within the layer
+	Index int `json:"index"`
+}
+
+// ContainmentHierarchy resolves points to the chain of nested areas that
+// contain them, such as country, region and city, which is the core of
+// offline reverse geocoding. Every feature is prepared once when the
+// hierarchy is built and linked to the feature of the layer above that
+// contains it, so a lookup only tests the children of the previous match
+// instead of every feature of every layer.
+//
+// A ContainmentHierarchy is safe for concurrent use, although lookups run
+// one at a time, since GEOS prepared geometries must not be evaluated from
+// several threads at once. The features must not be
+// destroyed while it is in use, and it must be closed, or left to the garbage
+// collector, to release the prepared geometries. Like geometries, it is
+// invalidated by Service.Reset.
+type ContainmentHierarchy struct {
+	service    *Service
+	mutex      sync.Mutex
+	generation uint64
+	levels     []hierarchyLevel
+}
+
+// hierarchyLevel holds the prepared features of one layer
+type hierarchyLevel struct {
+	name  string
+	nodes []hierarchyNode
+	// orphans are features without a parent in the layer above; they are
+	// tested on every lookup that reaches this level
+	orphans []int
+}
+
+// hierarchyNode is one prepared feature
+type hierarchyNode struct {
+	geom     *Geometry
+	prepared *C.GEOSPreparedGeometry
+	env      envelope
+	children []int
+}
+
+// NewContainmentHierarchy builds a containment hierarchy from nested layers.
+// Each feature is linked to the feature of the previous layer that contains
+// a point on its surface. Features whose parent is missing, e.g. because of
+// gaps in the data, are still found by lookups, only less efficiently.
+//
+// Parameters:
+//   - layers: The layers from coarsest to finest; features should be polygonal
+//
+// Returns:
+//   - *ContainmentHierarchy: The hierarchy, ready for lookups
+//   - error: An error if a feature is invalid or cannot be prepared
+//
+// Example:
+//
+//	hierarchy, err := service.NewContainmentHierarchy([]geos.HierarchyLayer{
+//		{Name: "country", Features: countries},
+//		{Name: "region", Features: regions},
+//		{Name: "city", Features: cities},
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer hierarchy.Close()
+//
+//	matches, err := hierarchy.LookupXY(28.97, 41.01)
+//	// matches lists the country, region and city containing the location
+func (s *Service) NewContainmentHierarchy(layers []HierarchyLayer) (*ContainmentHierarchy, error) {
+	if len(layers) == 0 {
+		return nil, errors.New("at least one layer is required")
+	}
+	for l, layer := range layers {
+		for i, geom := range layer.Features {
+			if geom == nil || geom.geom == nil {
+				return nil, fmt.Errorf("invalid geometry at index %d of layer %q", i, layer.Name)
+			}
+		}
+		if len(layer.Features) == 0 {
+			return nil, fmt.Errorf("layer %d (%q) has no features", l, layer.Name)
+		}
+	}
+
+	h := &ContainmentHierarchy{service: s, levels: make([]hierarchyLevel, 0, len(layers))}
+	runtime.SetFinalizer(h, (*ContainmentHierarchy).Close)
+
+	c, err := s.acquire()
+	if err == nil {
+		err = h.build(c, layers)
+		c.release()
+	}
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// build prepares and links the features of every layer
+func (h *ContainmentHierarchy) build(c *session, layers []HierarchyLayer) error {
+	for _, layer := range layers {
+		if err := c.checkState(layer.Features...); err != nil {
+			return err
+		}
+	}
+	h.generation = c.generation
+
+	for l, layer := range layers {
+		level, err := h.buildLevel(c, l, layer)
+		if err != nil {
+			return err
+		}
+		h.levels = append(h.levels, level)
+	}
+
+	return nil
+}
+
+// buildLevel prepares the features of one layer and links them to the last
+// built level. On failure every feature prepared so far is released.
+func (h *ContainmentHierarchy) buildLevel(c *session, l int, layer HierarchyLayer) (level hierarchyLevel, err error) {
+	level = hierarchyLevel{name: layer.Name, nodes: make([]hierarchyNode, 0, len(layer.Features))}
+	defer func() {
+		if err != nil {
+			for _, node := range level.nodes {
+				C.GEOSPreparedGeom_destroy_r(c.context, node.prepared)
+			}
+		}
+	}()
+
+	for i, geom := range layer.Features {
+		env, ok, err := c.envelopeOf(geom.geom)
+		if err != nil {
+			return level, err
+		}
+		if !ok {
+			return level, fmt.Errorf("empty geometry at index %d of layer %q", i, layer.Name)
+		}
+
+		parent := -1
+		if l > 0 {
+			if parent, err = h.findParent(c, geom.geom); err != nil {
+				return level, fmt.Errorf("feature %d of layer %q: %w", i, layer.Name, err)
+			}
+		}
+
+		prepared := C.GEOSPrepare_r(c.context, geom.geom)
+		if prepared == nil {
+			return level, c.geosError(fmt.Sprintf("failed to prepare feature %d of layer %q", i, layer.Name))
+		}
+		level.nodes = append(level.nodes, hierarchyNode{geom: geom, prepared: prepared, env: env})
+
+		if parent >= 0 {
+			above := &h.levels[l-1].nodes[parent]
+			above.children = append(above.children, i)
+		} else {
+			level.orphans = append(level.orphans, i)
+		}
+	}
+
+	return level, nil
+}
+
+// findParent returns the index of the feature in the last built level that
+// contains a point on the surface of g, or -1 if there is none
+func (h *ContainmentHierarchy) findParent(c *session, g *C.GEOSGeometry) (int, error) {
+	surface := C.GEOSPointOnSurface_r(c.context, g)
+	if surface == nil {
+		return -1, c.geosError("failed to compute point on surface")
+	}
+	defer C.GEOSGeom_destroy_r(c.context, surface)
+
+	var x, y C.double
+	if C.GEOSGeomGetX_r(c.context, surface, &x) == 0 || C.GEOSGeomGetY_r(c.context, surface, &y) == 0 {
+		return -1, c.geosError("failed to read point on surface")
+	}
+
+	matches, err := h.lookup(c, surface, float64(x), float64(y))
+	if err != nil {
+		return -1, err
+	}
+	if len(matches) > 0 && matches[len(matches)-1].Level == len(h.levels)-1 {
+		return matches[len(matches)-1].Index, nil
+	}
+	return -1, nil
+}
+
+// Lookup returns the features containing a point, one per layer from the
+// coarsest to the finest, skipping layers where no feature contains it.
+// Points on a shared boundary match one of the features that share it. If
+// features of a layer overlap, the first one in layer order wins.
+//
+// Parameters:
+//   - point: The Point geometry to resolve
+//
+// Returns:
+//   - []HierarchyMatch: The containing features, empty if there are none
+//   - error: An error if point is not a Point or the operation fails
+func (h *ContainmentHierarchy) Lookup(point *Geometry) ([]HierarchyMatch, error) {
+	if point == nil || point.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	c, err := h.acquire(point)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	if C.GEOSGeomTypeId_r(c.context, point.geom) != C.GEOS_POINT || C.GEOSisEmpty_r(c.context, point.geom) != 0 {
+		return nil, errors.New("geometry must be a non-empty Point")
+	}
+	var x, y C.double
+	if C.GEOSGeomGetX_r(c.context, point.geom, &x) == 0 || C.GEOSGeomGetY_r(c.context, point.geom, &y) == 0 {
+		return nil, c.geosError("failed to read point coordinates")
+	}
+
+	return h.lookup(c, point.geom, float64(x), float64(y))
+}
+
+// LookupXY is like Lookup for a point given by its coordinates.
+//
+// Example:
+//
+//	matches, err := hierarchy.LookupXY(lon, lat)
+//	for _, m := range matches {
+//		fmt.Printf("%s: %s\n", m.Layer, names[m.Level][m.Index])
+//	}
+func (h *ContainmentHierarchy) LookupXY(x, y float64) ([]HierarchyMatch, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	c, err := h.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	point := C.GEOSGeom_createPointFromXY_r(c.context, C.double(x), C.double(y))
+	if point == nil {
+		return nil, c.geosError("failed to create point")
+	}
+	defer C.GEOSGeom_destroy_r(c.context, point)
+
+	return h.lookup(c, point, x, y)
+}
+
+// acquire starts a lookup after checking that the hierarchy and the given
+// geometries can be used. The hierarchy lock must be held.
+func (h *ContainmentHierarchy) acquire(geoms ...*Geometry) (*session, error) {
+	if h.levels == nil {
+		return nil, errors.New("containment hierarchy is closed")
+	}
+	c, err := h.service.acquire(geoms...)
+	if err != nil {
+		return nil, err
+	}
+	if h.generation != c.generation {
+		c.release()
+		return nil, ErrGeometryInvalidated
+	}
+	return c, nil
+}
+
+// lookup descends the built levels with a point
+func (h *ContainmentHierarchy) lookup(c *session, point *C.GEOSGeometry, x, y float64) ([]HierarchyMatch, error) {
+	at := envelope{x, y, x, y}
+
+	var matches []HierarchyMatch
+	var candidates []int
+	for l := range h.levels {
+		level := &h.levels[l]
+
+		found := -1
+		for _, group := range [2][]int{candidates, level.orphans} {
+			for _, i := range group {
+				node := &level.nodes[i]
+				if !node.env.intersects(at) {
+					continue
+				}
+				result := C.GEOSPreparedIntersects_r(c.context, node.prepared, point)
+				if result == 2 {
+					return nil, c.geosError("GEOS prepared intersects operation failed")
+				}
+				if result == 1 {
+					found = i
+					break
+				}
+			}
+			if found >= 0 {
+				break
+			}
+		}
+
+		candidates = nil
+		if found >= 0 {
+			matches = append(matches, HierarchyMatch{Level: l, Layer: level.name, Index: found})
+			candidates = level.nodes[found].children
+		}
+	}
+
+	return matches, nil
+}
+
+// Close releases the prepared geometries of the hierarchy. It is safe to call
+// multiple times; lookups afterwards return an error.
+func (h *ContainmentHierarchy) Close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	// The prepared geometries of a closed service are left alone, since all
+	// of its contexts have been released
+	if c, err := h.service.acquire(); err == nil {
+		for _, level := range h.levels {
+			for _, node := range level.nodes {
+				C.GEOSPreparedGeom_destroy_r(c.context, node.prepared)
+			}
+		}
+		c.release()
+	}
+
+	h.levels = nil
+	runtime.SetFinalizer(h, nil)
+}
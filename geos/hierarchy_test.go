@@ -0,0 +1,91 @@
+package geos
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestContainmentHierarchy tests resolving points to nested containing areas
+func TestContainmentHierarchy(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	parseAll := func(wkts ...string) []*Geometry {
+		geoms := make([]*Geometry, len(wkts))
+		for i, wkt := range wkts {
+			geoms[i] = helper.ParseWKT(wkt)
+		}
+		return geoms
+	}
+
+	hierarchy, err := helper.service.NewContainmentHierarchy([]HierarchyLayer{
+		{Name: "country", Features: parseAll(
+			"POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))",
+			"POLYGON((10 0, 20 0, 20 10, 10 10, 10 0))",
+		)},
+		{Name: "region", Features: parseAll(
+			"POLYGON((0 0, 5 0, 5 10, 0 10, 0 0))",
+			"POLYGON((5 0, 10 0, 10 10, 5 10, 5 0))",
+			"POLYGON((10 0, 20 0, 20 10, 10 10, 10 0))",
+		)},
+		{Name: "city", Features: parseAll(
+			"POLYGON((1 1, 2 1, 2 2, 1 2, 1 1))",
+			"POLYGON((15 5, 16 5, 16 6, 15 6, 15 5))",
+			"POLYGON((30 30, 31 30, 31 31, 30 31, 30 30))",
+		)},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build hierarchy: %v", err)
+	}
+	defer hierarchy.Close()
+
+	testCases := []struct {
+		name     string
+		x, y     float64
+		expected []HierarchyMatch
+	}{
+		{"Full chain", 1.5, 1.5, []HierarchyMatch{{0, "country", 0}, {1, "region", 0}, {2, "city", 0}}},
+		{"Second country", 15.5, 5.5, []HierarchyMatch{{0, "country", 1}, {1, "region", 2}, {2, "city", 1}}},
+		{"No city", 7, 5, []HierarchyMatch{{0, "country", 0}, {1, "region", 1}}},
+		{"Orphan city", 30.5, 30.5, []HierarchyMatch{{2, "city", 2}}},
+		{"Outside", 50, 50, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matches, err := hierarchy.LookupXY(tc.x, tc.y)
+			if err != nil {
+				t.Fatalf("Failed to look up point: %v", err)
+			}
+			if !reflect.DeepEqual(matches, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, matches)
+			}
+
+			point := helper.ParseWKT(fmt.Sprintf("POINT(%g %g)", tc.x, tc.y))
+			matches, err = hierarchy.Lookup(point)
+			if err != nil {
+				t.Fatalf("Failed to look up point geometry: %v", err)
+			}
+			if !reflect.DeepEqual(matches, tc.expected) {
+				t.Errorf("Expected %v from Lookup, got %v", tc.expected, matches)
+			}
+		})
+	}
+
+	if _, err := hierarchy.Lookup(helper.LineGeometry()); err == nil {
+		t.Error("Expected error for non-point geometry")
+	}
+
+	if _, err := helper.service.NewContainmentHierarchy(nil); err == nil {
+		t.Error("Expected error for no layers")
+	}
+	if _, err := helper.service.NewContainmentHierarchy([]HierarchyLayer{{Name: "empty"}}); err == nil {
+		t.Error("Expected error for a layer without features")
+	}
+
+	hierarchy.Close()
+	if _, err := hierarchy.LookupXY(1.5, 1.5); err == nil {
+		t.Error("Expected error after Close")
+	}
+}
@@ -0,0 +1,54 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestSortHilbert tests that spatially close geometries end up together
+func TestSortHilbert(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	wkts := []string{
+		"POINT(0 0)",
+		"POLYGON((99 99, 100 99, 100 100, 99 99))",
+		"POINT EMPTY",
+		"POINT(1 1)",
+		"POINT(99 98)",
+		"LINESTRING(0 1, 1 2)",
+	}
+	original := make([]*Geometry, len(wkts))
+	for i, wkt := range wkts {
+		original[i] = helper.ParseWKT(wkt)
+	}
+	geoms := append([]*Geometry(nil), original...)
+
+	order, err := helper.service.SortHilbert(geoms)
+	if err != nil {
+		t.Fatalf("Failed to sort: %v", err)
+	}
+
+	for i, j := range order {
+		if geoms[i] != original[j] {
+			t.Errorf("Position %d: expected original geometry %d", i, j)
+		}
+	}
+
+	// The two clusters must each be contiguous, with the empty point last
+	near := map[int]bool{0: true, 3: true, 5: true}
+	if order[5] != 2 {
+		t.Errorf("Expected the empty geometry last, got order %v", order)
+	}
+	if near[order[0]] != near[order[1]] || near[order[1]] != near[order[2]] ||
+		near[order[3]] != near[order[4]] || near[order[2]] == near[order[3]] {
+		t.Errorf("Expected contiguous clusters, got order %v", order)
+	}
+
+	single := []*Geometry{helper.ParseWKT("POINT(5 5)")}
+	if _, err := helper.service.SortHilbert(single); err != nil {
+		t.Errorf("Failed to sort a single point: %v", err)
+	}
+	if _, err := helper.service.SortHilbert([]*Geometry{nil}); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
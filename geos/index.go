@@ -0,0 +1,392 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+// gogeos_items collects the items reported by an STRtree query
+typedef struct {
+	uintptr_t *ids;
+	size_t len;
+	size_t cap;
+	int failed;
+} gogeos_items;
+
+static void gogeos_collect_item(void *item, void *userdata) {
+	gogeos_items *items = (gogeos_items *)userdata;
+	if (items->failed) {
+		return;
+	}
+	if (items->len == items->cap) {
+		size_t cap = items->cap ? 2 * items->cap : 16;
+		uintptr_t *ids = realloc(items->ids, cap * sizeof(uintptr_t));
+		if (ids == NULL) {
+			items->failed = 1;
+			return;
+		}
+		items->ids = ids;
+		items->cap = cap;
+	}
+	items->ids[items->len++] = (uintptr_t)item;
+}
+
+static void gogeos_strtree_query(GEOSContextHandle_t ctx, GEOSSTRtree *tree, const GEOSGeometry *g, gogeos_items *items) {
+	GEOSSTRtree_query_r(ctx, tree, g, gogeos_collect_item, items);
+}
+
+// gogeos_item turns an entry ID into the opaque item pointer stored in the tree
+static void *gogeos_item(uintptr_t id) {
+	return (void *)id;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"unsafe"
+)
+
+// indexNodeCapacity is the maximum number of children of an STRtree node
+const indexNodeCapacity = 10
+
+// Index is a spatial index over geometry envelopes backed by the GEOS STRtree.
+// It turns "which features could interact with this geometry" from a scan of
+// every feature into a tree lookup, which is what makes thousands of
+// Within or Intersects checks against a large layer practical: query the
+// index for candidates, then run the exact predicate on those only.
+//
+// The tree is packed when it is first queried. Inserting after that is
+// allowed, but the tree is rebuilt on the next query, so load the index
+// before querying where possible.
+//
+// An Index is safe for concurrent use. Indexed geometries must not be
+// destroyed while they are in the index, and the index must be closed, or
+// left to the garbage collector, to release the tree. Like geometries, it is
+// invalidated by Service.Reset.
+type Index struct {
+	service    *Service
+	mutex      sync.Mutex
+	generation uint64
+	tree       *C.GEOSSTRtree
+	entries    map[uintptr]indexEntry
+	byGeometry map[*Geometry][]uintptr // entry IDs of each geometry in insertion order, for Remove
+	nextID     uintptr
+	built      bool // the tree has been packed by a query or remove
+	stale      bool // entries were inserted after packing
+}
+
+// indexEntry is one geometry and item in an index
+type indexEntry struct {
+	geom *Geometry
+	item any
+}
+
+// NewIndex creates an empty spatial index.
+//
+// Returns:
+//   - *Index: The index, ready for inserts
+//   - error: An error if the service is closed or the tree cannot be created
+//
+// Example:
+//
+//	index, err := service.NewIndex()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer index.Close()
+//
+//	for i, parcel := range parcels {
+//		index.Insert(parcel, i)
+//	}
+//
+//	candidates, err := index.Query(point)
+//	for _, item := range candidates {
+//		i := item.(int)
+//		if within, _ := service.Within(point, parcels[i]); within {
+//			fmt.Println("point is in parcel", i)
+//		}
+//	}
+func (s *Service) NewIndex() (*Index, error) {
+	c, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	tree := C.GEOSSTRtree_create_r(c.context, indexNodeCapacity)
+	if tree == nil {
+		return nil, c.geosError("failed to create STRtree")
+	}
+
+	idx := &Index{
+		service:    s,
+		generation: c.generation,
+		tree:       tree,
+		entries:    make(map[uintptr]indexEntry),
+		byGeometry: make(map[*Geometry][]uintptr),
+		nextID:     1, // IDs double as item pointers, which must not be NULL
+	}
+	runtime.SetFinalizer(idx, (*Index).Close)
+
+	return idx, nil
+}
+
+// Insert adds a geometry to the index under its envelope. The item is what
+// queries return for the geometry, e.g. a feature ID or a slice index; it
+// may be nil.
+//
+// Parameters:
+//   - geom: The geometry to index; it must not be destroyed while indexed
+//   - item: The value returned by queries matching the geometry
+//
+// Returns:
+//   - error: An error if the geometry or index is invalid
+func (idx *Index) Insert(geom *Geometry, item any) error {
+	if geom == nil || geom.geom == nil {
+		return errors.New("invalid geometry")
+	}
+
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	c, err := idx.acquire(geom)
+	if err != nil {
+		return err
+	}
+	defer c.release()
+
+	id := idx.nextID
+	idx.nextID++
+	idx.entries[id] = indexEntry{geom: geom, item: item}
+	idx.byGeometry[geom] = append(idx.byGeometry[geom], id)
+
+	if idx.built {
+		// A packed tree cannot take more items; rebuild it on the next query
+		idx.stale = true
+		return nil
+	}
+	C.GEOSSTRtree_insert_r(c.context, idx.tree, geom.geom, C.gogeos_item(C.uintptr_t(id)))
+
+	return nil
+}
+
+// Remove deletes a geometry inserted with the given item from the index.
+// Items are compared with ==, so they must be comparable.
+//
+// Parameters:
+//   - geom: The geometry passed to Insert
+//   - item: The item passed to Insert with it
+//
+// Returns:
+//   - bool: True if the entry was found and removed
+//   - error: An error if the geometry or index is invalid or the operation fails
+func (idx *Index) Remove(geom *Geometry, item any) (bool, error) {
+	if geom == nil || geom.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	c, err := idx.acquire(geom)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+
+	ids := idx.byGeometry[geom]
+	found := -1
+	for i, candidate := range ids {
+		if idx.entries[candidate].item == item {
+			found = i
+			break
+		}
+	}
+	if found < 0 {
+		return false, nil
+	}
+	id := ids[found]
+
+	if !idx.stale {
+		result := C.GEOSSTRtree_remove_r(c.context, idx.tree, geom.geom, C.gogeos_item(C.uintptr_t(id)))
+		if result == 2 {
+			return false, c.geosError("failed to remove geometry from STRtree")
+		}
+		// GEOS packs the tree before removing from it
+		idx.built = true
+	}
+	delete(idx.entries, id)
+	if len(ids) == 1 {
+		delete(idx.byGeometry, geom)
+	} else {
+		idx.byGeometry[geom] = append(ids[:found:found], ids[found+1:]...)
+	}
+
+	return true, nil
+}
+
+// Query returns the items of the indexed geometries whose envelopes intersect
+// the envelope of a geometry, in insertion order. These are candidates: run
+// the exact predicate, e.g. Intersects or Within, to filter them.
+//
+// Parameters:
+//   - geom: The geometry to search with
+//
+// Returns:
+//   - []any: The items of the candidate geometries
+//   - error: An error if the geometry or index is invalid or the operation fails
+func (idx *Index) Query(geom *Geometry) ([]any, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	c, err := idx.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	return idx.query(c, geom.geom)
+}
+
+// QueryEnvelope is like Query for a rectangular search area.
+//
+// Example:
+//
+//	// Everything that may be visible in the viewport
+//	items, err := index.QueryEnvelope(minX, minY, maxX, maxY)
+func (idx *Index) QueryEnvelope(minX, minY, maxX, maxY float64) ([]any, error) {
+	if minX > maxX || minY > maxY {
+		return nil, fmt.Errorf("invalid envelope: min (%g %g) exceeds max (%g %g)", minX, minY, maxX, maxY)
+	}
+
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	c, err := idx.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	box := C.GEOSGeom_createRectangle_r(c.context, C.double(minX), C.double(minY), C.double(maxX), C.double(maxY))
+	if box == nil {
+		return nil, c.geosError("failed to create query envelope")
+	}
+	defer C.GEOSGeom_destroy_r(c.context, box)
+
+	return idx.query(c, box)
+}
+
+// Len returns the number of entries in the index.
+func (idx *Index) Len() int {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	return len(idx.entries)
+}
+
+// query collects the items matching a search geometry, rebuilding the tree
+// first if it is stale. The index lock must be held.
+func (idx *Index) query(c *session, g *C.GEOSGeometry) ([]any, error) {
+	if idx.stale {
+		if err := idx.rebuild(c); err != nil {
+			return nil, err
+		}
+	}
+
+	var found C.gogeos_items
+	C.gogeos_strtree_query(c.context, idx.tree, g, &found)
+	idx.built = true
+	defer C.free(unsafe.Pointer(found.ids))
+	if found.failed != 0 {
+		return nil, errors.New("out of memory collecting index query results")
+	}
+
+	ids := make([]uintptr, 0, int(found.len))
+	if found.len > 0 {
+		for _, id := range unsafe.Slice(found.ids, int(found.len)) {
+			ids = append(ids, uintptr(id))
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	items := make([]any, len(ids))
+	for i, id := range ids {
+		items[i] = idx.entries[id].item
+	}
+
+	return items, nil
+}
+
+// rebuild replaces the tree with a new one holding every entry. The index
+// lock must be held.
+func (idx *Index) rebuild(c *session) error {
+	tree := C.GEOSSTRtree_create_r(c.context, indexNodeCapacity)
+	if tree == nil {
+		return c.geosError("failed to create STRtree")
+	}
+
+	ids := make([]uintptr, 0, len(idx.entries))
+	for id := range idx.entries {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		C.GEOSSTRtree_insert_r(c.context, tree, idx.entries[id].geom.geom, C.gogeos_item(C.uintptr_t(id)))
+	}
+
+	C.GEOSSTRtree_destroy_r(c.context, idx.tree)
+	idx.tree = tree
+	idx.built = false
+	idx.stale = false
+
+	return nil
+}
+
+// acquire starts an index operation after checking that the index and the
+// given geometries can be used. The index lock must be held.
+func (idx *Index) acquire(geoms ...*Geometry) (*session, error) {
+	if idx.tree == nil {
+		return nil, errors.New("index is closed")
+	}
+	c, err := idx.service.acquire(geoms...)
+	if err != nil {
+		return nil, err
+	}
+	if idx.generation != c.generation {
+		c.release()
+		return nil, ErrGeometryInvalidated
+	}
+	return c, nil
+}
+
+// Close releases the tree. It is safe to call multiple times; operations
+// afterwards return an error.
+func (idx *Index) Close() {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	// The tree of a closed service is left alone, since all of its contexts
+	// have been released
+	if idx.tree != nil {
+		if c, err := idx.service.acquire(); err == nil {
+			C.GEOSSTRtree_destroy_r(c.context, idx.tree)
+			c.release()
+		}
+	}
+
+	idx.tree = nil
+	idx.entries = nil
+	idx.byGeometry = nil
+	runtime.SetFinalizer(idx, nil)
+}
@@ -0,0 +1,132 @@
+package geos
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestIndex tests inserting, querying and removing STRtree entries
+func TestIndex(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	index, err := helper.service.NewIndex()
+	if err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer index.Close()
+
+	// A 10x10 grid of unit squares
+	cells := make([]*Geometry, 0, 100)
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			wkt := fmt.Sprintf("POLYGON((%d %d, %d %d, %d %d, %d %d, %d %d))", x, y, x+1, y, x+1, y+1, x, y+1, x, y)
+			cell := helper.ParseWKT(wkt)
+			cells = append(cells, cell)
+			if err := index.Insert(cell, len(cells)-1); err != nil {
+				t.Fatalf("Failed to insert cell: %v", err)
+			}
+		}
+	}
+	if index.Len() != 100 {
+		t.Errorf("Expected 100 entries, got %d", index.Len())
+	}
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected []any
+	}{
+		{"Inside one cell", "POINT(3.5 2.5)", []any{23}},
+		{"On a shared corner", "POINT(1 1)", []any{0, 1, 10, 11}},
+		{"Outside the grid", "POINT(20 20)", []any{}},
+		{"Line across cells", "LINESTRING(0.5 0.5, 2.5 0.5)", []any{0, 1, 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			items, err := index.Query(helper.ParseWKT(tc.wkt))
+			if err != nil {
+				t.Fatalf("Failed to query: %v", err)
+			}
+			if !reflect.DeepEqual(items, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, items)
+			}
+		})
+	}
+
+	items, err := index.QueryEnvelope(8.5, 8.5, 9.5, 9.5)
+	if err != nil {
+		t.Fatalf("Failed to query envelope: %v", err)
+	}
+	if !reflect.DeepEqual(items, []any{88, 89, 98, 99}) {
+		t.Errorf("Expected the 4 top-right cells, got %v", items)
+	}
+
+	removed, err := index.Remove(cells[23], 23)
+	if err != nil || !removed {
+		t.Fatalf("Failed to remove cell: %v %v", removed, err)
+	}
+	if removed, _ := index.Remove(cells[23], 23); removed {
+		t.Error("Expected a second removal to find nothing")
+	}
+	if items, _ := index.Query(helper.ParseWKT("POINT(3.5 2.5)")); len(items) != 0 {
+		t.Errorf("Expected the removed cell to be gone, got %v", items)
+	}
+
+	// Inserting after the first query rebuilds the tree
+	extra := helper.ParseWKT("POLYGON((3 2, 4 2, 4 3, 3 3, 3 2))")
+	if err := index.Insert(extra, "extra"); err != nil {
+		t.Fatalf("Failed to insert after querying: %v", err)
+	}
+	if items, _ := index.Query(helper.ParseWKT("POINT(3.5 2.5)")); !reflect.DeepEqual(items, []any{"extra"}) {
+		t.Errorf("Expected the inserted entry, got %v", items)
+	}
+
+	if _, err := index.QueryEnvelope(1, 1, 0, 0); err == nil {
+		t.Error("Expected error for an inverted envelope")
+	}
+
+	index.Close()
+	if _, err := index.Query(helper.PointGeometry()); err == nil {
+		t.Error("Expected error after closing the index")
+	}
+}
+
+// TestIndexInsertAfterRemove tests that entries inserted after a removal,
+// which packs the tree, can still be queried
+func TestIndexInsertAfterRemove(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	index, err := helper.service.NewIndex()
+	if err != nil {
+		t.Fatalf("Failed to create index: %v", err)
+	}
+	defer index.Close()
+
+	first := helper.ParseWKT("POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))")
+	if err := index.Insert(first, "first"); err != nil {
+		t.Fatalf("Failed to insert: %v", err)
+	}
+	if removed, err := index.Remove(first, "first"); err != nil || !removed {
+		t.Fatalf("Failed to remove: %v %v", removed, err)
+	}
+
+	second := helper.ParseWKT("POLYGON((2 2, 3 2, 3 3, 2 3, 2 2))")
+	if err := index.Insert(second, "second"); err != nil {
+		t.Fatalf("Failed to insert after removing: %v", err)
+	}
+	if index.Len() != 1 {
+		t.Errorf("Expected 1 entry, got %d", index.Len())
+	}
+
+	items, err := index.Query(helper.ParseWKT("POINT(2.5 2.5)"))
+	if err != nil {
+		t.Fatalf("Failed to query: %v", err)
+	}
+	if !reflect.DeepEqual(items, []any{"second"}) {
+		t.Errorf("Expected the entry inserted after removing, got %v", items)
+	}
+}
@@ -0,0 +1,300 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// JournalEntry is one operation recorded in a Journal. Geometries are
+// identified by content hashes, see Service.GeometryHash, so the output of
+// one step can be matched to the input of the next.
+type JournalEntry struct {
+	// Time is when the operation completed, in UTC
+	Time time.Time `json:"time"`
+
+	// Op is the name of the Service method, e.g. "Buffer"
+	Op string `json:"op"`
+
+	// Inputs are the hashes of the input geometries in argument order. For
+	// parse operations it is the hash of the source text. Nil geometries in
+	// batches have an empty hash.
+	Inputs []string `json:"inputs,omitempty"`
+
+	// Params are the non-geometry arguments by parameter name
+	Params map[string]any `json:"params,omitempty"`
+
+	// Outputs are the hashes of the geometries the operation returned
+	Outputs []string `json:"outputs,omitempty"`
+}
+
+// Journal is an append-only log of the geometry operations of the services it
+// is attached to, written as one JSON object per line. Attaching a journal to
+// a pipeline makes a bad output discovered weeks later traceable: look up the
+// entry that produced its hash, then follow the input hashes back through the
+// steps that produced them, with the parameters each step used.
+//
+// Every Service method that returns new geometries is recorded once it
+// succeeds. Predicates, measurements and serialization are not recorded.
+// Writing to the journal never fails an operation; the first write error is
+// kept and reported by Err and Close. A Journal is safe for concurrent use.
+type Journal struct {
+	mutex sync.Mutex
+	file  *os.File
+	err   error
+}
+
+// OpenJournal opens a journal file for appending, creating it if needed.
+// Entries already in the file are kept.
+//
+// Parameters:
+//   - path: The journal file
+//
+// Returns:
+//   - *Journal: The journal, ready to attach with Service.SetJournal
+//   - error: An error if the file cannot be opened
+//
+// Example:
+//
+//	journal, err := geos.OpenJournal("pipeline.journal")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer journal.Close()
+//
+//	service.SetJournal(journal)
+func OpenJournal(path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	return &Journal{file: file}, nil
+}
+
+// Err returns the first error that occurred while writing entries, if any.
+func (j *Journal) Err() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return j.err
+}
+
+// Close closes the journal file. It returns the first write error, if any.
+// It is safe to call multiple times; entries recorded afterwards are dropped
+// and reported as an error by Err.
+func (j *Journal) Close() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.file != nil {
+		if err := j.file.Close(); err != nil && j.err == nil {
+			j.err = fmt.Errorf("failed to close journal: %w", err)
+		}
+		j.file = nil
+	}
+	return j.err
+}
+
+// append writes an entry as one line, so concurrent writers and a crash
+// cannot leave interleaved or partial entries in the middle of the file
+func (j *Journal) append(entry JournalEntry) {
+	line, err := json.Marshal(entry)
+	if err == nil {
+		line = append(line, '\n')
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.err != nil {
+		return
+	}
+	switch {
+	case err != nil:
+		j.err = fmt.Errorf("failed to encode journal entry for %s: %w", entry.Op, err)
+	case j.file == nil:
+		j.err = errors.New("journal is closed")
+	default:
+		if _, err := j.file.Write(line); err != nil {
+			j.err = fmt.Errorf("failed to write journal: %w", err)
+		}
+	}
+}
+
+// ReadJournal decodes the entries of a journal file in the order they were
+// recorded.
+//
+// Parameters:
+//   - r: The journal contents
+//
+// Returns:
+//   - []JournalEntry: The recorded entries
+//   - error: An error if a line is not a valid entry
+//
+// Example:
+//
+//	entries, err := geos.ReadJournal(file)
+//	for _, entry := range entries {
+//		for _, out := range entry.Outputs {
+//			if out == badHash {
+//				fmt.Println("produced by", entry.Op, entry.Params, entry.Inputs)
+//			}
+//		}
+//	}
+func ReadJournal(r io.Reader) ([]JournalEntry, error) {
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("journal line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return entries, nil
+}
+
+// SetJournal attaches a journal that records the operations of the service
+// from now on, or detaches the current one if j is nil. It waits for
+// in-flight operations to finish. The journal is not closed when the service
+// is.
+//
+// Parameters:
+//   - j: The journal to record to, or nil
+func (s *Service) SetJournal(j *Journal) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.journal = j
+}
+
+// GeometryHash returns the content hash identifying a geometry in journal
+// entries: the hex SHA-256 digest of its ISO WKB encoding with Z and M. Equal
+// coordinates in the same order give equal hashes.
+//
+// Parameters:
+//   - geom: The geometry to hash
+//
+// Returns:
+//   - string: The hash
+//   - error: An error if the geometry is invalid or cannot be encoded
+func (s *Service) GeometryHash(geom *Geometry) (string, error) {
+	if geom == nil || geom.geom == nil {
+		return "", errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return "", err
+	}
+	defer c.release()
+
+	return c.geometryHash(geom.geom)
+}
+
+// geometryHash hashes the WKB encoding of a geometry.
+func (c *session) geometryHash(g *C.GEOSGeometry) (string, error) {
+	writer, err := c.wkbWriter(C.GEOS_WKB_ISO, false)
+	if err != nil {
+		return "", err
+	}
+
+	var size C.size_t
+	wkb := C.GEOSWKBWriter_write_r(c.context, writer, g, &size)
+	if wkb == nil {
+		return "", c.geosError("failed to write WKB geometry")
+	}
+	defer C.GEOSFree_r(c.context, unsafe.Pointer(wkb))
+
+	return hashBytes(unsafe.Slice((*byte)(unsafe.Pointer(wkb)), int(size))), nil
+}
+
+// hashBytes returns the hex SHA-256 digest of data
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// newRecordedGeometry wraps a result like newGeometry and records the
+// operation that produced it.
+func (c *session) newRecordedGeometry(op string, params map[string]any, result *C.GEOSGeometry, inputs ...*Geometry) *Geometry {
+	g := c.newGeometry(result)
+	c.record(op, params, inputs, g)
+	return g
+}
+
+// record appends an operation to the journal, if one is attached.
+func (c *session) record(op string, params map[string]any, inputs []*Geometry, outputs ...*Geometry) {
+	if c.journal == nil {
+		return
+	}
+	c.recordHashes(op, params, c.journalHashes(inputs), outputs...)
+}
+
+// recordHashes is like record for inputs that are not geometries, e.g. the
+// source text of a parse.
+func (c *session) recordHashes(op string, params map[string]any, inputs []string, outputs ...*Geometry) {
+	if c.journal == nil {
+		return
+	}
+	c.journal.append(JournalEntry{
+		Time:    time.Now().UTC(),
+		Op:      op,
+		Inputs:  inputs,
+		Params:  params,
+		Outputs: c.journalHashes(outputs),
+	})
+}
+
+// journalHashes hashes geometries for a journal entry. A geometry that cannot
+// be encoded is recorded with an empty hash and the error is kept by the
+// journal.
+func (c *session) journalHashes(geoms []*Geometry) []string {
+	if len(geoms) == 0 {
+		return nil
+	}
+	hashes := make([]string, len(geoms))
+	for i, geom := range geoms {
+		if geom == nil || geom.geom == nil {
+			continue
+		}
+		hash, err := c.geometryHash(geom.geom)
+		if err != nil {
+			c.journal.fail(fmt.Errorf("failed to hash geometry for journal: %w", err))
+			continue
+		}
+		hashes[i] = hash
+	}
+	return hashes
+}
+
+// fail keeps err as the journal error unless one is already set
+func (j *Journal) fail(err error) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.err == nil {
+		j.err = err
+	}
+}
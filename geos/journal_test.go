@@ -0,0 +1,134 @@
+package geos
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestJournal tests that operations are recorded with chained geometry hashes
+func TestJournal(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	path := filepath.Join(t.TempDir(), "pipeline.journal")
+	journal, err := OpenJournal(path)
+	if err != nil {
+		t.Fatalf("Failed to open journal: %v", err)
+	}
+	helper.service.SetJournal(journal)
+
+	point := helper.ParseWKT("POINT(0 0)")
+	buffered, err := helper.service.Buffer(point, 2)
+	if err != nil {
+		t.Fatalf("Buffer failed: %v", err)
+	}
+	square := helper.ParseWKT("POLYGON((1 -1, 3 -1, 3 1, 1 1, 1 -1))")
+	diff, err := helper.service.Difference(buffered, square)
+	if err != nil {
+		t.Fatalf("Difference failed: %v", err)
+	}
+
+	// Predicates are not recorded, nor is anything after detaching
+	if _, err := helper.service.Intersects(diff, point); err != nil {
+		t.Fatalf("Intersects failed: %v", err)
+	}
+	helper.service.SetJournal(nil)
+	if _, err := helper.service.Buffer(point, 1); err != nil {
+		t.Fatalf("Buffer failed: %v", err)
+	}
+
+	if err := journal.Close(); err != nil {
+		t.Fatalf("Journal reported an error: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open journal file: %v", err)
+	}
+	defer file.Close()
+	entries, err := ReadJournal(file)
+	if err != nil {
+		t.Fatalf("Failed to read journal: %v", err)
+	}
+
+	var ops []string
+	for _, entry := range entries {
+		ops = append(ops, entry.Op)
+	}
+	expected := []string{"ParseGeometry", "Buffer", "ParseGeometry", "Difference"}
+	if !reflect.DeepEqual(ops, expected) {
+		t.Fatalf("Expected operations %v, got %v", expected, ops)
+	}
+
+	hash := func(g *Geometry) string {
+		h, err := helper.service.GeometryHash(g)
+		if err != nil {
+			t.Fatalf("GeometryHash failed: %v", err)
+		}
+		return h
+	}
+
+	if got := entries[0].Inputs[0]; got != hashBytes([]byte("POINT(0 0)")) {
+		t.Errorf("Expected parse input to be the hash of the WKT, got %s", got)
+	}
+	if got := entries[1].Params["radius"]; got != 2.0 {
+		t.Errorf("Expected radius 2, got %v", got)
+	}
+	for _, c := range []struct {
+		name     string
+		got      []string
+		expected []string
+	}{
+		{"Parse outputs", entries[0].Outputs, []string{hash(point)}},
+		{"Buffer inputs", entries[1].Inputs, []string{hash(point)}},
+		{"Buffer outputs", entries[1].Outputs, []string{hash(buffered)}},
+		{"Difference inputs", entries[3].Inputs, []string{hash(buffered), hash(square)}},
+		{"Difference outputs", entries[3].Outputs, []string{hash(diff)}},
+	} {
+		if !reflect.DeepEqual(c.got, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, c.got)
+		}
+	}
+}
+
+// TestJournalBackwardsSubstring tests that LineSubstring records the
+// fractions as given, so a backwards substring replays backwards
+func TestJournalBackwardsSubstring(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	path := filepath.Join(t.TempDir(), "substring.journal")
+	journal, err := OpenJournal(path)
+	if err != nil {
+		t.Fatalf("Failed to open journal: %v", err)
+	}
+	route := helper.ParseWKT("LINESTRING(0 0, 10 0, 10 10)")
+	helper.service.SetJournal(journal)
+	if _, err := helper.service.LineSubstring(route, 0.75, 0.25); err != nil {
+		t.Fatalf("LineSubstring failed: %v", err)
+	}
+	helper.service.SetJournal(nil)
+	if err := journal.Close(); err != nil {
+		t.Fatalf("Journal reported an error: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open journal file: %v", err)
+	}
+	defer file.Close()
+	entries, err := ReadJournal(file)
+	if err != nil {
+		t.Fatalf("Failed to read journal: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+
+	expected := map[string]any{"startFrac": 0.75, "endFrac": 0.25}
+	if !reflect.DeepEqual(entries[0].Params, expected) {
+		t.Errorf("Expected params %v, got %v", expected, entries[0].Params)
+	}
+}
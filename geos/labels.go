@@ -0,0 +1,140 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// LabelBox is a rotated rectangle along a line returned by LabelBoxes
+type LabelBox struct {
+	// Box is the rectangle Polygon
+	Box *Geometry
+
+	// Anchor is the center of the box, which lies on the line
+	Anchor *Geometry
+
+	// Angle is the rotation of the box in degrees, counter-clockwise from the
+	// +X axis. It is kept within (-90, 90] so text drawn at this angle is
+	// never upside down.
+	Angle float64
+}
+
+// LabelBoxes places rectangles for road shields or labels along line features
+// at a regular interval. Each box is centered on the line, with its long side
+// along the line direction at that point, so it can be used both to render a
+// label and to test it for collisions with other features. The first box is
+// placed half an interval from the start of each line, keeping boxes away
+// from line ends.
+//
+// Parameters:
+//   - line: The LineString or MultiLineString to label
+//   - interval: The distance along the line between box centers (must be positive)
+//   - width: The size of the boxes along the line (must be positive)
+//   - height: The size of the boxes across the line (must be positive)
+//
+// Returns:
+//   - []LabelBox: The boxes in order along the line, part by part
+//   - error: An error if the geometry is not lineal or a size is invalid
+//
+// Example:
+//
+//	road, _ := service.ParseGeometry(GeometryInput{WKT: "LINESTRING(0 0, 100 0, 100 100)"})
+//
+//	boxes, err := service.LabelBoxes(road, 50, 20, 6)
+//	// boxes[0] is centered on POINT (25 0): POLYGON ((15 -3, 35 -3, 35 3, 15 3, 15 -3))
+//	// boxes[2] is centered on POINT (100 25) and rotated by 90 degrees
+func (s *Service) LabelBoxes(line *Geometry, interval, width, height float64) ([]LabelBox, error) {
+	if line == nil || line.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	for _, v := range []struct {
+		name  string
+		value float64
+	}{{"interval", interval}, {"width", width}, {"height", height}} {
+		if !(v.value > 0) || math.IsInf(v.value, 0) {
+			return nil, fmt.Errorf("label %s must be positive, got %g", v.name, v.value)
+		}
+	}
+
+	c, err := s.acquire(line)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	if err := c.checkLineal(line.geom); err != nil {
+		return nil, err
+	}
+
+	sh, err := c.readShape(line.geom)
+	if err != nil {
+		return nil, err
+	}
+	parts := []*shape{sh}
+	if sh.typeID == C.GEOS_MULTILINESTRING {
+		parts = sh.parts
+	}
+
+	boxes := make([]LabelBox, 0)
+	for _, part := range parts {
+		err := part.walk(interval/2, interval, func(x, y, angle float64) error {
+			if angle <= -90 {
+				angle += 180
+			} else if angle > 90 {
+				angle -= 180
+			}
+			box, err := c.buildShape(labelBoxShape(x, y, angle, width, height))
+			if err != nil {
+				return err
+			}
+			anchor := C.GEOSGeom_createPointFromXY_r(c.context, C.double(x), C.double(y))
+			if anchor == nil {
+				C.GEOSGeom_destroy_r(c.context, box)
+				return c.geosError("failed to create label anchor")
+			}
+			boxes = append(boxes, LabelBox{Box: c.newGeometry(box), Anchor: c.newGeometry(anchor), Angle: angle})
+			return nil
+		})
+		if err != nil {
+			for _, b := range boxes {
+				c.discard(b.Box, b.Anchor)
+			}
+			return nil, err
+		}
+	}
+
+	if c.journal != nil {
+		outputs := make([]*Geometry, 0, 2*len(boxes))
+		for _, b := range boxes {
+			outputs = append(outputs, b.Box, b.Anchor)
+		}
+		c.inheritSRID([]*Geometry{line}, outputs...)
+		c.record("LabelBoxes", map[string]any{"interval": interval, "width": width, "height": height}, []*Geometry{line}, outputs...)
+	}
+
+	return boxes, nil
+}
+
+// labelBoxShape builds a width by height rectangle centered on (x, y) and
+// rotated by angle degrees
+func labelBoxShape(x, y, angle, width, height float64) *shape {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	ring := make([]float64, 0, 10)
+	for _, corner := range [][2]float64{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}, {-1, -1}} {
+		u, v := corner[0]*width/2, corner[1]*height/2
+		ring = append(ring, x+u*cos-v*sin, y+u*sin+v*cos)
+	}
+
+	return &shape{
+		typeID: C.GEOS_POLYGON,
+		parts:  []*shape{{typeID: C.GEOS_LINEARRING, coords: ring}},
+	}
+}
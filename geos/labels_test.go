@@ -0,0 +1,83 @@
+package geos
+
+import (
+	"math"
+	"testing"
+)
+
+// TestLabelBoxes tests rotated label rectangles along lines
+func TestLabelBoxes(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name    string
+		wkt     string
+		anchors []string
+		angles  []float64
+		first   string
+	}{
+		{
+			"Bent road",
+			"LINESTRING(0 0, 100 0, 100 100)",
+			[]string{"POINT (25 0)", "POINT (75 0)", "POINT (100 25)", "POINT (100 75)"},
+			[]float64{0, 0, 90, 90},
+			"POLYGON ((15 -3, 35 -3, 35 3, 15 3, 15 -3))",
+		},
+		{
+			"Westbound road is not upside down",
+			"LINESTRING(100 0, 0 0)",
+			[]string{"POINT (75 0)", "POINT (25 0)"},
+			[]float64{0, 0},
+			"POLYGON ((65 -3, 85 -3, 85 3, 65 3, 65 -3))",
+		},
+		{
+			"Southbound road",
+			"MULTILINESTRING((0 60, 0 0), (10 0, 20 0))",
+			[]string{"POINT (0 35)"},
+			[]float64{90},
+			"POLYGON ((3 25, 3 45, -3 45, -3 25, 3 25))",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			boxes, err := helper.service.LabelBoxes(helper.ParseWKT(tc.wkt), 50, 20, 6)
+			if err != nil {
+				t.Fatalf("Failed to place label boxes: %v", err)
+			}
+			if len(boxes) != len(tc.anchors) {
+				t.Fatalf("Expected %d boxes, got %d", len(tc.anchors), len(boxes))
+			}
+
+			options := WKTOptions{Trim: true, Precision: 6}
+			for i, box := range boxes {
+				wkt, err := helper.service.ToWKTWithOptions(box.Anchor, options)
+				if err != nil {
+					t.Fatalf("Failed to convert to WKT: %v", err)
+				}
+				if wkt != tc.anchors[i] {
+					t.Errorf("Box %d: expected anchor %s, got %s", i, tc.anchors[i], wkt)
+				}
+				if math.Abs(box.Angle-tc.angles[i]) > 1e-9 {
+					t.Errorf("Box %d: expected angle %g, got %g", i, tc.angles[i], box.Angle)
+				}
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(boxes[0].Box, options)
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.first {
+				t.Errorf("Expected first box %s, got %s", tc.first, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.LabelBoxes(helper.PolygonGeometry(), 50, 20, 6); err == nil {
+		t.Error("Expected error for a polygon")
+	}
+	if _, err := helper.service.LabelBoxes(helper.LineGeometry(), 50, 0, 6); err == nil {
+		t.Error("Expected error for zero width")
+	}
+}
@@ -0,0 +1,334 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// LineMerge sews together the linework of a geometry into maximal LineStrings.
+// Lines are joined where exactly two of them meet at an end point, which turns
+// a MultiLineString of contiguous segments into as few lines as possible. This
+// is a standard cleanup step for road and stream networks. Lines may be
+// reversed to be joined; use LineMergeDirected to preserve their direction.
+//
+// Parameters:
+//   - geom: The linear geometry to merge
+//
+// Returns:
+//   - *Geometry: A LineString or MultiLineString of merged lines
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	segments := GeometryInput{WKT: "MULTILINESTRING((0 0, 1 0), (1 0, 2 1), (2 1, 3 1))"}
+//	geom, _ := service.ParseGeometry(segments)
+//
+//	merged, err := service.LineMerge(geom)
+//	// merged will be LINESTRING (0 0, 1 0, 2 1, 3 1)
+func (s *Service) LineMerge(geom *Geometry) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	merged := C.GEOSLineMerge_r(c.context, geom.geom)
+	if merged == nil {
+		return nil, c.geosError("failed to merge lines")
+	}
+
+	return c.newDerivedGeometry("LineMerge", nil, merged, geom), nil
+}
+
+// LineMergeDirected sews together linework like LineMerge, but only joins
+// lines whose directions agree, so the end of one line must meet the start of
+// the next. Use it for networks where direction matters, such as one-way
+// streets or flow lines.
+//
+// Parameters:
+//   - geom: The linear geometry to merge
+//
+// Returns:
+//   - *Geometry: A LineString or MultiLineString of merged lines
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	segments := GeometryInput{WKT: "MULTILINESTRING((0 0, 1 0), (2 0, 1 0))"}
+//	geom, _ := service.ParseGeometry(segments)
+//
+//	merged, err := service.LineMergeDirected(geom)
+//	// merged stays MULTILINESTRING ((0 0, 1 0), (2 0, 1 0)) since the directions conflict
+func (s *Service) LineMergeDirected(geom *Geometry) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	merged := C.GEOSLineMergeDirected_r(c.context, geom.geom)
+	if merged == nil {
+		return nil, c.geosError("failed to merge directed lines")
+	}
+
+	return c.newDerivedGeometry("LineMergeDirected", nil, merged, geom), nil
+}
+
+// Interpolate returns the point at a fraction of the length along a line,
+// e.g. 0.5 for the midpoint. This is the basis of milepost and chainage
+// computations when positions are stored as a share of the route.
+//
+// Parameters:
+//   - line: The LineString or MultiLineString to walk along
+//   - fraction: The position along the line, from 0 (start) to 1 (end)
+//
+// Returns:
+//   - *Geometry: A new point on the line
+//   - error: An error if the fraction is out of range or the geometry is not linear
+//
+// Example:
+//
+//	route := GeometryInput{WKT: "LINESTRING(0 0, 10 0, 10 10)"}
+//	geom, _ := service.ParseGeometry(route)
+//
+//	midpoint, err := service.Interpolate(geom, 0.5)
+//	// midpoint will be POINT (10 0)
+func (s *Service) Interpolate(line *Geometry, fraction float64) (*Geometry, error) {
+	if line == nil || line.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if !(fraction >= 0 && fraction <= 1) {
+		return nil, fmt.Errorf("fraction must be between 0 and 1, got %g", fraction)
+	}
+
+	c, err := s.acquire(line)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+	if err := c.checkLineal(line.geom); err != nil {
+		return nil, err
+	}
+
+	point := C.GEOSInterpolateNormalized_r(c.context, line.geom, C.double(fraction))
+	if point == nil {
+		return nil, c.geosError("failed to interpolate point")
+	}
+
+	return c.newDerivedGeometry("Interpolate", map[string]any{"fraction": fraction}, point, line), nil
+}
+
+// InterpolateDistance returns the point at a distance along a line, measured
+// in the units of the coordinates. A negative distance is measured back from
+// the end of the line, and distances beyond the line's length are clamped to
+// its end points.
+//
+// Parameters:
+//   - line: The LineString or MultiLineString to walk along
+//   - distance: The distance from the start (or from the end, if negative)
+//
+// Returns:
+//   - *Geometry: A new point on the line
+//   - error: An error if the distance is not finite or the geometry is not linear
+//
+// Example:
+//
+//	route := GeometryInput{WKT: "LINESTRING(0 0, 10 0, 10 10)"}
+//	geom, _ := service.ParseGeometry(route)
+//
+//	marker, err := service.InterpolateDistance(geom, 15)
+//	// marker will be POINT (10 5)
+func (s *Service) InterpolateDistance(line *Geometry, distance float64) (*Geometry, error) {
+	if line == nil || line.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if math.IsNaN(distance) || math.IsInf(distance, 0) {
+		return nil, fmt.Errorf("distance must be finite, got %g", distance)
+	}
+
+	c, err := s.acquire(line)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+	if err := c.checkLineal(line.geom); err != nil {
+		return nil, err
+	}
+
+	point := C.GEOSInterpolate_r(c.context, line.geom, C.double(distance))
+	if point == nil {
+		return nil, c.geosError("failed to interpolate point")
+	}
+
+	return c.newDerivedGeometry("InterpolateDistance", map[string]any{"distance": distance}, point, line), nil
+}
+
+// Project returns the position along a line nearest to a point, as a fraction
+// of the line's length from 0 (start) to 1 (end). It is the inverse of
+// Interpolate, and the two together provide linear referencing.
+//
+// Parameters:
+//   - line: The LineString or MultiLineString to measure along
+//   - point: The Point to locate
+//
+// Returns:
+//   - float64: The fraction of the length at the closest position
+//   - error: An error if the geometries have the wrong types or the operation fails
+//
+// Example:
+//
+//	route := GeometryInput{WKT: "LINESTRING(0 0, 10 0, 10 10)"}
+//	site := GeometryInput{WKT: "POINT(12 5)"}
+//
+//	line, _ := service.ParseGeometry(route)
+//	point, _ := service.ParseGeometry(site)
+//
+//	fraction, err := service.Project(line, point)
+//	// fraction will be 0.75
+func (s *Service) Project(line, point *Geometry) (float64, error) {
+	return s.project(line, point, true)
+}
+
+// ProjectDistance returns the position along a line nearest to a point, as a
+// distance from the start of the line in the units of the coordinates. It is
+// the inverse of InterpolateDistance.
+//
+// Parameters:
+//   - line: The LineString or MultiLineString to measure along
+//   - point: The Point to locate
+//
+// Returns:
+//   - float64: The distance along the line to the closest position
+//   - error: An error if the geometries have the wrong types or the operation fails
+//
+// Example:
+//
+//	chainage, err := service.ProjectDistance(line, point)
+//	// chainage will be 15 for the line and point above
+func (s *Service) ProjectDistance(line, point *Geometry) (float64, error) {
+	return s.project(line, point, false)
+}
+
+// project implements Project and ProjectDistance
+func (s *Service) project(line, point *Geometry, normalized bool) (float64, error) {
+	if line == nil || point == nil || line.geom == nil || point.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(line, point)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+	if err := c.checkLineal(line.geom); err != nil {
+		return 0, err
+	}
+	if C.GEOSGeomTypeId_r(c.context, point.geom) != C.GEOS_POINT || C.GEOSisEmpty_r(c.context, point.geom) == 1 {
+		return 0, errors.New("geometry to project must be a non-empty Point")
+	}
+
+	var result C.double
+	if normalized {
+		result = C.GEOSProjectNormalized_r(c.context, line.geom, point.geom)
+	} else {
+		result = C.GEOSProject_r(c.context, line.geom, point.geom)
+	}
+	if result == -1 {
+		return 0, c.geosError("failed to project point onto line")
+	}
+
+	return float64(result), nil
+}
+
+// LineSubstring returns the part of a line between two fractions of its
+// length, e.g. to segment a route by stationing. When startFrac is greater
+// than endFrac the substring runs backwards, from the later position to the
+// earlier one.
+//
+// Parameters:
+//   - line: The LineString to extract from
+//   - startFrac: Where the substring starts, from 0 (start) to 1 (end)
+//   - endFrac: Where the substring ends, from 0 (start) to 1 (end)
+//
+// Returns:
+//   - *Geometry: A new LineString, or a Point if both fractions are equal
+//   - error: An error if a fraction is out of range or the operation fails
+//
+// Example:
+//
+//	route := GeometryInput{WKT: "LINESTRING(0 0, 10 0, 10 10)"}
+//	geom, _ := service.ParseGeometry(route)
+//
+//	section, err := service.LineSubstring(geom, 0.25, 0.75)
+//	// section will be LINESTRING (5 0, 10 0, 10 5)
+func (s *Service) LineSubstring(line *Geometry, startFrac, endFrac float64) (*Geometry, error) {
+	if line == nil || line.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	for _, fraction := range []float64{startFrac, endFrac} {
+		if !(fraction >= 0 && fraction <= 1) {
+			return nil, fmt.Errorf("fraction must be between 0 and 1, got %g", fraction)
+		}
+	}
+
+	c, err := s.acquire(line)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+	if err := c.checkLineal(line.geom); err != nil {
+		return nil, err
+	}
+
+	from, to := startFrac, endFrac
+	reverse := from > to
+	if reverse {
+		from, to = to, from
+	}
+
+	substring := C.GEOSLineSubstring_r(c.context, line.geom, C.double(from), C.double(to))
+	if substring == nil {
+		return nil, c.geosError("failed to extract line substring")
+	}
+
+	if reverse {
+		reversed := C.GEOSReverse_r(c.context, substring)
+		C.GEOSGeom_destroy_r(c.context, substring)
+		if reversed == nil {
+			return nil, c.geosError("failed to reverse line substring")
+		}
+		substring = reversed
+	}
+
+	return c.newDerivedGeometry("LineSubstring", map[string]any{"startFrac": startFrac, "endFrac": endFrac}, substring, line), nil
+}
+
+// checkLineal returns an error unless the geometry is a LineString, LinearRing
+// or MultiLineString.
+func (c *session) checkLineal(g *C.GEOSGeometry) error {
+	switch C.GEOSGeomTypeId_r(c.context, g) {
+	case C.GEOS_LINESTRING, C.GEOS_LINEARRING, C.GEOS_MULTILINESTRING:
+		return nil
+	case -1:
+		return c.geosError("failed to get geometry type")
+	}
+	return errors.New("geometry must be a LineString or MultiLineString")
+}
@@ -0,0 +1,223 @@
+package geos
+
+import (
+	"math"
+	"testing"
+)
+
+// TestLineMerge tests sewing line segments into maximal lines
+func TestLineMerge(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		directed bool
+		expected string
+	}{
+		{"Contiguous segments", "MULTILINESTRING((0 0, 1 0), (1 0, 2 1), (2 1, 3 1))", false, "LINESTRING (0 0, 1 0, 2 1, 3 1)"},
+		{"Disjoint segments", "MULTILINESTRING((0 0, 1 0), (5 5, 6 5))", false, "MULTILINESTRING ((0 0, 1 0), (5 5, 6 5))"},
+		{"Mostly forward segments", "MULTILINESTRING((0 0, 1 0), (1 0, 2 0), (3 0, 2 0))", false, "LINESTRING (0 0, 1 0, 2 0, 3 0)"},
+		{"Directed opposing segments", "MULTILINESTRING((0 0, 1 0), (2 0, 1 0))", true, "MULTILINESTRING ((0 0, 1 0), (2 0, 1 0))"},
+		{"Directed contiguous segments", "MULTILINESTRING((0 0, 1 0), (1 0, 2 0))", true, "LINESTRING (0 0, 1 0, 2 0)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.wkt)
+
+			var merged *Geometry
+			var err error
+			if tc.directed {
+				merged, err = helper.service.LineMergeDirected(geom)
+			} else {
+				merged, err = helper.service.LineMerge(geom)
+			}
+			if err != nil {
+				t.Fatalf("Failed to merge lines: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(merged, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.LineMerge(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+	if _, err := helper.service.LineMergeDirected(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestInterpolate tests finding points along a line
+func TestInterpolate(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	route := helper.ParseWKT("LINESTRING(0 0, 10 0, 10 10)")
+
+	testCases := []struct {
+		name       string
+		byDistance bool
+		value      float64
+		expected   string
+	}{
+		{"Start", false, 0, "POINT (0 0)"},
+		{"Midpoint", false, 0.5, "POINT (10 0)"},
+		{"Three quarters", false, 0.75, "POINT (10 5)"},
+		{"End", false, 1, "POINT (10 10)"},
+		{"Distance", true, 15, "POINT (10 5)"},
+		{"Distance from end", true, -5, "POINT (10 5)"},
+		{"Distance beyond end", true, 100, "POINT (10 10)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var point *Geometry
+			var err error
+			if tc.byDistance {
+				point, err = helper.service.InterpolateDistance(route, tc.value)
+			} else {
+				point, err = helper.service.Interpolate(route, tc.value)
+			}
+			if err != nil {
+				t.Fatalf("Failed to interpolate: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(point, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	for _, fraction := range []float64{-0.1, 1.1, math.NaN()} {
+		if _, err := helper.service.Interpolate(route, fraction); err == nil {
+			t.Errorf("Expected error for fraction %g", fraction)
+		}
+	}
+	if _, err := helper.service.InterpolateDistance(route, math.Inf(1)); err == nil {
+		t.Error("Expected error for infinite distance")
+	}
+	if _, err := helper.service.Interpolate(helper.PolygonGeometry(), 0.5); err == nil {
+		t.Error("Expected error for polygon")
+	}
+	if _, err := helper.service.Interpolate(nil, 0.5); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestProject tests locating points along a line
+func TestProject(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	route := helper.ParseWKT("LINESTRING(0 0, 10 0, 10 10)")
+
+	testCases := []struct {
+		name     string
+		point    string
+		fraction float64
+		distance float64
+	}{
+		{"On start", "POINT(0 0)", 0, 0},
+		{"Beside second segment", "POINT(12 5)", 0.75, 15},
+		{"Before start", "POINT(-5 -5)", 0, 0},
+		{"Past end", "POINT(10 20)", 1, 20},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			point := helper.ParseWKT(tc.point)
+
+			fraction, err := helper.service.Project(route, point)
+			if err != nil {
+				t.Fatalf("Failed to project point: %v", err)
+			}
+			if math.Abs(fraction-tc.fraction) > 1e-9 {
+				t.Errorf("Expected fraction %g, got %g", tc.fraction, fraction)
+			}
+
+			distance, err := helper.service.ProjectDistance(route, point)
+			if err != nil {
+				t.Fatalf("Failed to project point: %v", err)
+			}
+			if math.Abs(distance-tc.distance) > 1e-9 {
+				t.Errorf("Expected distance %g, got %g", tc.distance, distance)
+			}
+
+			// Interpolating the fraction lands on the closest point of the line
+			located, err := helper.service.Interpolate(route, fraction)
+			if err != nil {
+				t.Fatalf("Failed to interpolate: %v", err)
+			}
+			helper.AssertIntersects(located, route, true)
+		})
+	}
+
+	if _, err := helper.service.Project(route, route); err == nil {
+		t.Error("Expected error for non-point geometry")
+	}
+	if _, err := helper.service.Project(helper.PolygonGeometry(), helper.PointGeometry()); err == nil {
+		t.Error("Expected error for polygon")
+	}
+	if _, err := helper.service.ProjectDistance(nil, helper.PointGeometry()); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestLineSubstring tests extracting part of a line
+func TestLineSubstring(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	route := helper.ParseWKT("LINESTRING(0 0, 10 0, 10 10)")
+
+	testCases := []struct {
+		name       string
+		start, end float64
+		expected   string
+	}{
+		{"Whole line", 0, 1, "LINESTRING (0 0, 10 0, 10 10)"},
+		{"Middle", 0.25, 0.75, "LINESTRING (5 0, 10 0, 10 5)"},
+		{"First segment part", 0, 0.25, "LINESTRING (0 0, 5 0)"},
+		{"Backwards", 0.75, 0.25, "LINESTRING (10 5, 10 0, 5 0)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			section, err := helper.service.LineSubstring(route, tc.start, tc.end)
+			if err != nil {
+				t.Fatalf("Failed to extract substring: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(section, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.LineSubstring(route, -0.1, 0.5); err == nil {
+		t.Error("Expected error for negative fraction")
+	}
+	if _, err := helper.service.LineSubstring(route, 0.5, 1.5); err == nil {
+		t.Error("Expected error for fraction above 1")
+	}
+	if _, err := helper.service.LineSubstring(nil, 0, 1); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,215 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+)
+
+// HausdorffDistance calculates the discrete Hausdorff distance between two geometries.
+// It is the largest distance from a vertex of one geometry to the nearest point of
+// the other, which makes it a measure of how similar two shapes are: identical
+// shapes have a distance of 0.
+//
+// Parameters:
+//   - a: First geometry
+//   - b: Second geometry
+//
+// Returns:
+//   - float64: The discrete Hausdorff distance
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	original, _ := service.ParseGeometry(GeometryInput{WKT: "LINESTRING(0 0, 1 0.5, 2 0)"})
+//	simplified, _ := service.Simplify(original, 1.0)
+//
+//	deviation, err := service.HausdorffDistance(original, simplified)
+//	// deviation will be 0.5, the displacement of the removed vertex
+func (s *Service) HausdorffDistance(a, b *Geometry) (float64, error) {
+	if a == nil || b == nil || a.geom == nil || b.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+
+	var distance C.double
+	result := C.GEOSHausdorffDistance_r(c.context, a.geom, b.geom, &distance)
+	if result == 0 {
+		return 0, c.geosError("failed to calculate Hausdorff distance")
+	}
+
+	return float64(distance), nil
+}
+
+// HausdorffDistanceDensify calculates the Hausdorff distance after densifying the
+// segments of both geometries. Each segment is split into pieces of at most
+// densifyFrac of its length, so deviations located in the middle of long segments
+// are detected as well. Smaller fractions are more accurate but slower.
+//
+// Parameters:
+//   - a: First geometry
+//   - b: Second geometry
+//   - densifyFrac: Fraction of segment length to densify by, in (0, 1]
+//
+// Returns:
+//   - float64: The densified Hausdorff distance
+//   - error: An error if the fraction is out of range or the operation fails
+//
+// Example:
+//
+//	distance, err := service.HausdorffDistanceDensify(geom1, geom2, 0.1)
+func (s *Service) HausdorffDistanceDensify(a, b *Geometry, densifyFrac float64) (float64, error) {
+	if a == nil || b == nil || a.geom == nil || b.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+
+	if !(densifyFrac > 0 && densifyFrac <= 1) {
+		return 0, fmt.Errorf("densify fraction must be in (0, 1], got %g", densifyFrac)
+	}
+
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+
+	var distance C.double
+	result := C.GEOSHausdorffDistanceDensify_r(c.context, a.geom, b.geom, C.double(densifyFrac), &distance)
+	if result == 0 {
+		return 0, c.geosError("failed to calculate Hausdorff distance")
+	}
+
+	return float64(distance), nil
+}
+
+// NearestPoints finds the pair of points, one on each geometry, where the minimum
+// distance between the geometries occurs. The pair is returned as a 2-point
+// LineString from the point on A to the point on B, so it can be drawn directly
+// or inspected with its coordinates. Its length equals Distance(a, b).
+//
+// Parameters:
+//   - a: First geometry
+//   - b: Second geometry
+//
+// Returns:
+//   - *Geometry: A LineString from the nearest point on A to the nearest point on B
+//   - error: An error if either geometry is empty or the operation fails
+//
+// Example:
+//
+//	point := GeometryInput{WKT: "POINT(3 4)"}
+//	line := GeometryInput{WKT: "LINESTRING(0 0, 10 0)"}
+//
+//	geom1, _ := service.ParseGeometry(point)
+//	geom2, _ := service.ParseGeometry(line)
+//
+//	nearest, err := service.NearestPoints(geom1, geom2)
+//	// nearest will be LINESTRING (3 4, 3 0)
+func (s *Service) NearestPoints(a, b *Geometry) (*Geometry, error) {
+	if a == nil || b == nil || a.geom == nil || b.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	seq := C.GEOSNearestPoints_r(c.context, a.geom, b.geom)
+	if seq == nil {
+		return nil, c.geosError("failed to find nearest points")
+	}
+
+	// The line takes ownership of the coordinate sequence
+	line := C.GEOSGeom_createLineString_r(c.context, seq)
+	if line == nil {
+		return nil, c.geosError("failed to create nearest points line")
+	}
+
+	return c.newDerivedGeometry("NearestPoints", nil, line, a, b), nil
+}
+
+// MinimumClearance calculates the minimum clearance of a geometry: the
+// smallest distance by which a vertex could be moved to make the geometry
+// invalid or collapse it. It quantifies how fragile a geometry is; precision
+// reduction or simplification with a tolerance of the same order as the
+// clearance is likely to break it. Geometries without a clearance, such as a
+// single point, return +Inf.
+//
+// Parameters:
+//   - geom: The geometry to measure
+//
+// Returns:
+//   - float64: The minimum clearance, or +Inf if there is none
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	sliver, _ := service.ParseGeometry(GeometryInput{WKT: "POLYGON((0 0, 10 0, 10 0.01, 0 0))"})
+//
+//	clearance, err := service.MinimumClearance(sliver)
+//	// clearance is about 0.01; snapping to a 0.1 grid would collapse the polygon
+func (s *Service) MinimumClearance(geom *Geometry) (float64, error) {
+	if geom == nil || geom.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+
+	var clearance C.double
+	result := C.GEOSMinimumClearance_r(c.context, geom.geom, &clearance)
+	if result != 0 {
+		return 0, c.geosError("failed to calculate minimum clearance")
+	}
+
+	return float64(clearance), nil
+}
+
+// MinimumClearanceLine returns the 2-point LineString whose length is the
+// minimum clearance of a geometry, showing where the geometry is most
+// fragile. Geometries without a clearance return an empty LineString.
+//
+// Parameters:
+//   - geom: The geometry to measure
+//
+// Returns:
+//   - *Geometry: A LineString spanning the minimum clearance
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	line, err := service.MinimumClearanceLine(sliver)
+//	// line connects the vertex (10 0) to the long edge of the sliver
+func (s *Service) MinimumClearanceLine(geom *Geometry) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	result := C.GEOSMinimumClearanceLine_r(c.context, geom.geom)
+	if result == nil {
+		return nil, c.geosError("failed to calculate minimum clearance line")
+	}
+
+	return c.newDerivedGeometry("MinimumClearanceLine", nil, result, geom), nil
+}
@@ -0,0 +1,177 @@
+package geos
+
+import (
+	"math"
+	"testing"
+)
+
+// TestHausdorffDistance tests the discrete Hausdorff distance
+func TestHausdorffDistance(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		geomA    string
+		geomB    string
+		expected float64
+	}{
+		{"Identical lines", "LINESTRING(0 0, 2 0)", "LINESTRING(0 0, 2 0)", 0},
+		{"Simplified line", "LINESTRING(0 0, 1 0.5, 2 0)", "LINESTRING(0 0, 2 0)", 0.5},
+		{"Offset squares", "POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))", "POLYGON((0 0, 1 0, 1 2, 0 2, 0 0))", 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := helper.ParseWKT(tc.geomA)
+			b := helper.ParseWKT(tc.geomB)
+
+			distance, err := helper.service.HausdorffDistance(a, b)
+			if err != nil {
+				t.Fatalf("Failed to calculate Hausdorff distance: %v", err)
+			}
+			if math.Abs(distance-tc.expected) > 1e-9 {
+				t.Errorf("Expected %g, got %g", tc.expected, distance)
+			}
+		})
+	}
+}
+
+// TestHausdorffDistanceDensify tests that densification detects mid-segment deviations
+func TestHausdorffDistanceDensify(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	// The discrete distance only looks at vertices and misses the true maximum
+	a := helper.ParseWKT("LINESTRING(130 0, 0 0, 0 150)")
+	b := helper.ParseWKT("LINESTRING(10 10, 10 150, 130 10)")
+
+	discrete, err := helper.service.HausdorffDistance(a, b)
+	if err != nil {
+		t.Fatalf("Failed to calculate Hausdorff distance: %v", err)
+	}
+
+	densified, err := helper.service.HausdorffDistanceDensify(a, b, 0.5)
+	if err != nil {
+		t.Fatalf("Failed to calculate densified Hausdorff distance: %v", err)
+	}
+	if densified < discrete {
+		t.Errorf("Expected densified distance %g to be at least %g", densified, discrete)
+	}
+	if math.Abs(densified-70) > 1e-9 {
+		t.Errorf("Expected densified distance 70, got %g", densified)
+	}
+
+	for _, frac := range []float64{0, -0.5, 1.5, math.NaN()} {
+		if _, err := helper.service.HausdorffDistanceDensify(a, b, frac); err == nil {
+			t.Errorf("Expected error for densify fraction %g", frac)
+		}
+	}
+
+	if _, err := helper.service.HausdorffDistance(nil, b); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestNearestPoints tests finding the closest pair of points
+func TestNearestPoints(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		geomA    string
+		geomB    string
+		expected string
+	}{
+		{"Point to line", "POINT(3 4)", "LINESTRING(0 0, 10 0)", "LINESTRING (3 4, 3 0)"},
+		{"Polygon to point", "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))", "POINT(5 1)", "LINESTRING (2 1, 5 1)"},
+		{"Intersecting", "LINESTRING(0 0, 2 2)", "LINESTRING(0 2, 2 0)", "LINESTRING (1 1, 1 1)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := helper.ParseWKT(tc.geomA)
+			b := helper.ParseWKT(tc.geomB)
+
+			nearest, err := helper.service.NearestPoints(a, b)
+			if err != nil {
+				t.Fatalf("Failed to find nearest points: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(nearest, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.NearestPoints(nil, helper.PointGeometry()); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+	if _, err := helper.service.NearestPoints(helper.ParseWKT("POINT EMPTY"), helper.PointGeometry()); err == nil {
+		t.Error("Expected error for empty geometry")
+	}
+}
+
+// TestMinimumClearance tests the minimum clearance and its line
+func TestMinimumClearance(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected float64
+		line     string
+	}{
+		{"Rectangle", "POLYGON((0 0, 2 0, 2 1, 0 1, 0 0))", 1, ""},
+		{"Narrow notch", "POLYGON((0 0, 4 0, 4 1, 2 1, 2 1.1, 4 1.1, 4 2, 0 2, 0 0))", 0.1, ""},
+		{"Bent line", "LINESTRING(0 0, 1 0, 1 0.5)", 0.5, "LINESTRING (1 0, 1 0.5)"},
+		{"Single point", "POINT(1 1)", math.Inf(1), "LINESTRING EMPTY"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.wkt)
+
+			clearance, err := helper.service.MinimumClearance(geom)
+			if err != nil {
+				t.Fatalf("Failed to calculate minimum clearance: %v", err)
+			}
+			if math.IsInf(tc.expected, 1) {
+				if !math.IsInf(clearance, 1) {
+					t.Errorf("Expected +Inf, got %g", clearance)
+				}
+			} else if math.Abs(clearance-tc.expected) > 1e-9 {
+				t.Errorf("Expected %g, got %g", tc.expected, clearance)
+			}
+
+			line, err := helper.service.MinimumClearanceLine(geom)
+			if err != nil {
+				t.Fatalf("Failed to calculate minimum clearance line: %v", err)
+			}
+			if tc.line == "" {
+				return
+			}
+			normalized, err := helper.service.Normalize(line)
+			if err != nil {
+				t.Fatalf("Failed to normalize: %v", err)
+			}
+			wkt, err := helper.service.ToWKTWithOptions(normalized, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.line {
+				t.Errorf("Expected %s, got %s", tc.line, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.MinimumClearance(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,209 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// MigrationStatus classifies a stored geometry in a MigrationReport
+type MigrationStatus int
+
+const (
+	// MigrationValid means the geometry is valid under the linked GEOS and
+	// was re-serialized unchanged
+	MigrationValid MigrationStatus = iota
+	// MigrationRepaired means the geometry is invalid under the linked GEOS
+	// and was repaired with the same geometry type and dimension
+	MigrationRepaired
+	// MigrationTopologyChanged means the geometry is invalid under the linked
+	// GEOS and its repair changed the geometry type or dimension, e.g. a
+	// Polygon split into a MultiPolygon or collapsed into a line
+	MigrationTopologyChanged
+	// MigrationUnreadable means the stored WKB could not be parsed
+	MigrationUnreadable
+)
+
+// String returns the lowercase name of the status
+func (m MigrationStatus) String() string {
+	switch m {
+	case MigrationValid:
+		return "valid"
+	case MigrationRepaired:
+		return "repaired"
+	case MigrationTopologyChanged:
+		return "topology-changed"
+	case MigrationUnreadable:
+		return "unreadable"
+	}
+	return fmt.Sprintf("MigrationStatus(%d)", int(m))
+}
+
+// MarshalText encodes the status by name, e.g. in JSON reports
+func (m MigrationStatus) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// MigrationRecord is a stored geometry to migrate
+type MigrationRecord struct {
+	// ID identifies the record in the report, e.g. a primary key
+	ID string
+
+	// WKB is the stored geometry
+	WKB []byte
+}
+
+// MigrationResult is the outcome of migrating one record
+type MigrationResult struct {
+	// ID is the ID of the record
+	ID string `json:"id"`
+
+	// Status classifies the record
+	Status MigrationStatus `json:"status"`
+
+	// Reason explains why the stored geometry is invalid or unreadable
+	Reason string `json:"reason,omitempty"`
+
+	// WKB is the geometry re-serialized by the linked GEOS, repaired if it
+	// was invalid; nil for unreadable records
+	WKB []byte `json:"-"`
+}
+
+// MigrationReport is the result of MigrateWKB
+type MigrationReport struct {
+	// GEOSVersion is the version of the linked GEOS library
+	GEOSVersion string `json:"geos_version"`
+
+	// Results holds one result per record, in input order
+	Results []MigrationResult `json:"results"`
+}
+
+// Changed returns the results whose status is not MigrationValid
+func (r *MigrationReport) Changed() []MigrationResult {
+	changed := make([]MigrationResult, 0)
+	for _, result := range r.Results {
+		if result.Status != MigrationValid {
+			changed = append(changed, result)
+		}
+	}
+	return changed
+}
+
+// MigrateWKB audits stored geometries when upgrading libgeos. Validity rules
+// and robustness fixes differ between GEOS versions, so geometries written
+// with an older version may be invalid, or be interpreted differently, under
+// the linked one. Every record is parsed, checked and re-serialized as
+// little-endian ISO WKB; invalid geometries are repaired with MakeValid and
+// classified by whether the repair preserved their type and dimension. The
+// report lists the records that need attention before the new version goes
+// into production.
+//
+// Parameters:
+//   - records: The stored geometries to migrate
+//
+// Returns:
+//   - *MigrationReport: One result per record and the GEOS version used
+//   - error: An error if the service is closed or a GEOS operation fails;
+//     unreadable records are reported rather than returned as errors
+//
+// Example:
+//
+//	report, err := service.MigrateWKB(records)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, result := range report.Changed() {
+//		log.Printf("%s: %s (%s)", result.ID, result.Status, result.Reason)
+//	}
+func (s *Service) MigrateWKB(records []MigrationRecord) (*MigrationReport, error) {
+	c, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	reader, err := c.wkbReader()
+	if err != nil {
+		return nil, err
+	}
+
+	writer, err := c.wkbWriter(C.GEOS_WKB_ISO, false)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &MigrationReport{
+		GEOSVersion: C.GoString(C.GEOSversion()),
+		Results:     make([]MigrationResult, len(records)),
+	}
+	for i, record := range records {
+		result, err := c.migrate(reader, writer, record)
+		if err != nil {
+			return nil, fmt.Errorf("record %q: %w", record.ID, err)
+		}
+		report.Results[i] = result
+	}
+
+	return report, nil
+}
+
+// migrate parses, checks and re-serializes one record.
+func (c *session) migrate(reader *C.GEOSWKBReader, writer *C.GEOSWKBWriter, record MigrationRecord) (MigrationResult, error) {
+	result := MigrationResult{ID: record.ID}
+	if len(record.WKB) == 0 {
+		result.Status = MigrationUnreadable
+		result.Reason = "empty WKB value"
+		return result, nil
+	}
+
+	g := C.GEOSWKBReader_read_r(c.context, reader, (*C.uchar)(unsafe.Pointer(&record.WKB[0])), C.size_t(len(record.WKB)))
+	if g == nil {
+		result.Status = MigrationUnreadable
+		result.Reason = c.geosError("failed to parse WKB geometry").Error()
+		return result, nil
+	}
+	defer C.GEOSGeom_destroy_r(c.context, g)
+
+	valid := C.GEOSisValid_r(c.context, g)
+	if valid == 2 { // Error case
+		return result, c.geosError("GEOS is valid operation failed")
+	}
+
+	out := g
+	if valid == 0 {
+		reason := C.GEOSisValidReason_r(c.context, g)
+		if reason == nil {
+			return result, c.geosError("failed to get validity reason")
+		}
+		result.Reason = C.GoString(reason)
+		C.GEOSFree_r(c.context, unsafe.Pointer(reason))
+
+		repaired := C.GEOSMakeValid_r(c.context, g)
+		if repaired == nil {
+			return result, c.geosError("failed to repair geometry")
+		}
+		defer C.GEOSGeom_destroy_r(c.context, repaired)
+		out = repaired
+
+		result.Status = MigrationRepaired
+		if C.GEOSGeomTypeId_r(c.context, repaired) != C.GEOSGeomTypeId_r(c.context, g) ||
+			C.GEOSGeom_getDimensions_r(c.context, repaired) != C.GEOSGeom_getDimensions_r(c.context, g) {
+			result.Status = MigrationTopologyChanged
+		}
+	}
+
+	var size C.size_t
+	wkb := C.GEOSWKBWriter_write_r(c.context, writer, out, &size)
+	if wkb == nil {
+		return result, c.geosError("failed to write WKB geometry")
+	}
+	result.WKB = C.GoBytes(unsafe.Pointer(wkb), C.int(size))
+	C.GEOSFree_r(c.context, unsafe.Pointer(wkb))
+
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package geos
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestMigrateWKB tests auditing stored WKB against the linked GEOS
+func TestMigrateWKB(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	encode := func(wkt string) []byte {
+		geom, err := helper.service.ParseGeometry(GeometryInput{WKT: wkt, AllowInvalid: true})
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", wkt, err)
+		}
+		arr, err := helper.service.ToWKBArray([]*Geometry{geom})
+		if err != nil {
+			t.Fatalf("Failed to encode %s: %v", wkt, err)
+		}
+		return arr.Value(0)
+	}
+
+	records := []MigrationRecord{
+		{ID: "square", WKB: encode("POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))")},
+		{ID: "bowtie", WKB: encode("POLYGON((0 0, 2 2, 2 0, 0 2, 0 0))")},
+		{ID: "garbage", WKB: []byte{1, 2, 3}},
+		{ID: "empty"},
+	}
+
+	report, err := helper.service.MigrateWKB(records)
+	if err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if report.GEOSVersion == "" {
+		t.Error("Expected the GEOS version in the report")
+	}
+
+	expected := []MigrationStatus{MigrationValid, MigrationTopologyChanged, MigrationUnreadable, MigrationUnreadable}
+	for i, result := range report.Results {
+		if result.ID != records[i].ID {
+			t.Errorf("Result %d: expected ID %q, got %q", i, records[i].ID, result.ID)
+		}
+		if result.Status != expected[i] {
+			t.Errorf("%s: expected status %s, got %s", result.ID, expected[i], result.Status)
+		}
+		if result.Status == MigrationValid && result.Reason != "" {
+			t.Errorf("%s: unexpected reason %q", result.ID, result.Reason)
+		}
+		if result.Status != MigrationValid && result.Reason == "" {
+			t.Errorf("%s: expected a reason", result.ID)
+		}
+		if (result.Status == MigrationUnreadable) != (result.WKB == nil) {
+			t.Errorf("%s: unexpected WKB %x", result.ID, result.WKB)
+		}
+	}
+
+	if changed := report.Changed(); len(changed) != 3 || changed[0].ID != "bowtie" {
+		t.Errorf("Expected the last 3 records to be changed, got %+v", changed)
+	}
+
+	// The repaired geometry must be readable and valid
+	repaired := report.Results[1].WKB
+	geoms, err := helper.service.FromWKBArray(WKBArray{Offsets: []int32{0, int32(len(repaired))}, Data: repaired})
+	if err != nil {
+		t.Fatalf("Failed to read repaired WKB: %v", err)
+	}
+	if valid, err := helper.service.IsValid(geoms[0]); err != nil || !valid {
+		t.Errorf("Expected the repaired geometry to be valid, got %v (%v)", valid, err)
+	}
+
+	data, err := json.Marshal(report.Results[1])
+	if err != nil {
+		t.Fatalf("Failed to marshal result: %v", err)
+	}
+	if !strings.Contains(string(data), `"status":"topology-changed"`) {
+		t.Errorf("Expected the status by name, got %s", data)
+	}
+}
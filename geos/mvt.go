@@ -0,0 +1,469 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mehmetymw/gogeos/mercator"
+)
+
+// VectorTileLayer is a decoded Mapbox Vector Tile layer.
+type VectorTileLayer struct {
+	Name     string
+	Version  int
+	Extent   int
+	Features []VectorTileFeature
+}
+
+// VectorTileFeature is a decoded Mapbox Vector Tile feature.
+type VectorTileFeature struct {
+	// ID is the feature id, valid only when HasID is true
+	ID    uint64
+	HasID bool
+
+	// Properties maps attribute keys to string, float64, int64, uint64 or bool values
+	Properties map[string]interface{}
+
+	// Geometry is a Point, LineString or Polygon, or the Multi variant when the
+	// feature has several parts
+	Geometry *Geometry
+
+	// Err is set instead of Geometry when the geometry of the feature cannot
+	// be decoded or built, e.g. a polygon ring that clipping left with fewer
+	// than four points
+	Err error
+}
+
+// DecodeTileOptions controls the coordinates produced by DecodeVectorTile.
+// The zero value keeps tile coordinates, ranging from 0 to the layer extent
+// with Y pointing down.
+type DecodeTileOptions struct {
+	// Geographic converts coordinates to WGS84 longitude and latitude using
+	// the Web Mercator address of the tile in Z, X and Y.
+	Geographic bool `json:"geographic,omitempty"`
+	Z          int  `json:"z,omitempty"`
+	X          int  `json:"x,omitempty"`
+	Y          int  `json:"y,omitempty"`
+}
+
+// Vector tile geometry types and commands from the MVT 2.1 specification
+const (
+	mvtPoint      = 1
+	mvtLineString = 2
+	mvtPolygon    = 3
+
+	mvtMoveTo    = 1
+	mvtLineTo    = 2
+	mvtClosePath = 7
+
+	mvtDefaultExtent = 4096
+)
+
+// DecodeVectorTile decodes a Mapbox Vector Tile (the uncompressed protobuf
+// bytes) into layers of geometries, so that spatial checks can be run directly
+// against produced tiles. Features with an unknown geometry type are skipped.
+// A feature whose geometry is truncated or degenerate does not fail the tile:
+// it is kept with a nil Geometry and the reason in Err.
+//
+// Parameters:
+//   - data: The tile, already decompressed if it was served gzipped
+//   - opts: Whether to keep tile coordinates or convert to longitude/latitude
+//
+// Returns:
+//   - []VectorTileLayer: The layers in tile order
+//   - error: An error if the tile is malformed
+//
+// Example:
+//
+//	layers, err := service.DecodeVectorTile(data, geos.DecodeTileOptions{
+//		Geographic: true, Z: 14, X: 8185, Y: 5448,
+//	})
+//	for _, layer := range layers {
+//		for _, feature := range layer.Features {
+//			if feature.Err != nil {
+//				log.Printf("layer %s: %v", layer.Name, feature.Err)
+//				continue
+//			}
+//			inside, _ := service.Within(feature.Geometry, boundary)
+//			// ...
+//		}
+//	}
+func (s *Service) DecodeVectorTile(data []byte, opts DecodeTileOptions) ([]VectorTileLayer, error) {
+	if opts.Geographic {
+		if !mercator.ValidTile(opts.Z, opts.X, opts.Y) {
+			return nil, fmt.Errorf("invalid tile %d/%d/%d", opts.Z, opts.X, opts.Y)
+		}
+	}
+
+	type pendingLayer struct {
+		layer  VectorTileLayer
+		shapes []*shape
+	}
+
+	// Parse everything before touching GEOS so the lock is held only for building
+	var pending []pendingLayer
+	err := readMessage(data, func(field int, wire int, value uint64, bytes []byte) error {
+		if field != 3 || wire != 2 {
+			return nil
+		}
+		layer, shapes, err := decodeLayer(bytes, opts)
+		if err != nil {
+			return err
+		}
+		pending = append(pending, pendingLayer{layer, shapes})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	layers := make([]VectorTileLayer, len(pending))
+	for i, p := range pending {
+		for j, sh := range p.shapes {
+			if sh == nil {
+				continue // the feature carries its decoding error
+			}
+			g, err := c.buildShape(sh)
+			if err != nil {
+				p.layer.Features[j].Err = fmt.Errorf("layer %q feature %d: %w", p.layer.Name, j, err)
+				continue
+			}
+			p.layer.Features[j].Geometry = c.newGeometry(g)
+		}
+		layers[i] = p.layer
+	}
+
+	if c.journal != nil {
+		var outputs []*Geometry
+		for _, layer := range layers {
+			for _, f := range layer.Features {
+				outputs = append(outputs, f.Geometry)
+			}
+		}
+		c.recordHashes("DecodeVectorTile", map[string]any{"opts": opts}, []string{hashBytes(data)}, outputs...)
+	}
+
+	return layers, nil
+}
+
+// decodeLayer decodes a layer message, returning its features along with the
+// shape of each feature's geometry. Features whose geometry cannot be decoded
+// have their Err set and a nil shape.
+func decodeLayer(data []byte, opts DecodeTileOptions) (VectorTileLayer, []*shape, error) {
+	layer := VectorTileLayer{Version: 1, Extent: mvtDefaultExtent}
+
+	var keys []string
+	var values []interface{}
+	var rawFeatures [][]byte
+
+	err := readMessage(data, func(field int, wire int, value uint64, bytes []byte) error {
+		switch {
+		case field == 1 && wire == 2:
+			layer.Name = string(bytes)
+		case field == 2 && wire == 2:
+			rawFeatures = append(rawFeatures, bytes)
+		case field == 3 && wire == 2:
+			keys = append(keys, string(bytes))
+		case field == 4 && wire == 2:
+			v, err := decodeValue(bytes)
+			if err != nil {
+				return err
+			}
+			values = append(values, v)
+		case field == 5 && wire == 0:
+			layer.Extent = int(value)
+		case field == 15 && wire == 0:
+			layer.Version = int(value)
+		}
+		return nil
+	})
+	if err != nil {
+		return layer, nil, err
+	}
+	if layer.Extent <= 0 {
+		return layer, nil, fmt.Errorf("layer %q has invalid extent %d", layer.Name, layer.Extent)
+	}
+
+	shapes := make([]*shape, 0, len(rawFeatures))
+	for i, raw := range rawFeatures {
+		feature, geomType, commands, err := decodeFeature(raw, keys, values)
+		if err != nil {
+			return layer, nil, fmt.Errorf("layer %q feature %d: %w", layer.Name, i, err)
+		}
+		sh, err := decodeGeometry(geomType, commands)
+		if err != nil {
+			feature.Err = fmt.Errorf("layer %q feature %d: %w", layer.Name, i, err)
+			layer.Features = append(layer.Features, feature)
+			shapes = append(shapes, nil)
+			continue
+		}
+		if sh == nil {
+			continue
+		}
+		if opts.Geographic {
+			sh.transformXY(tileToLonLat(opts, layer.Extent))
+		}
+		layer.Features = append(layer.Features, feature)
+		shapes = append(shapes, sh)
+	}
+
+	return layer, shapes, nil
+}
+
+// decodeFeature decodes a feature message, resolving its tags against the
+// layer's keys and values. The geometry commands are returned undecoded.
+func decodeFeature(data []byte, keys []string, values []interface{}) (VectorTileFeature, int, []uint32, error) {
+	var feature VectorTileFeature
+	var geomType int
+	var tags, commands []uint32
+
+	err := readMessage(data, func(field int, wire int, value uint64, bytes []byte) error {
+		var err error
+		switch {
+		case field == 1 && wire == 0:
+			feature.ID = value
+			feature.HasID = true
+		case field == 2:
+			tags, err = appendPacked(tags, wire, value, bytes)
+		case field == 3 && wire == 0:
+			geomType = int(value)
+		case field == 4:
+			commands, err = appendPacked(commands, wire, value, bytes)
+		}
+		return err
+	})
+	if err != nil {
+		return feature, 0, nil, err
+	}
+
+	if len(tags)%2 != 0 {
+		return feature, 0, nil, errors.New("odd number of feature tags")
+	}
+	feature.Properties = make(map[string]interface{}, len(tags)/2)
+	for i := 0; i < len(tags); i += 2 {
+		k, v := int(tags[i]), int(tags[i+1])
+		if k >= len(keys) || v >= len(values) {
+			return feature, 0, nil, errors.New("feature tag out of range")
+		}
+		feature.Properties[keys[k]] = values[v]
+	}
+
+	return feature, geomType, commands, nil
+}
+
+// decodeValue decodes a layer value message
+func decodeValue(data []byte) (interface{}, error) {
+	var result interface{}
+	err := readMessage(data, func(field int, wire int, value uint64, bytes []byte) error {
+		switch field {
+		case 1:
+			result = string(bytes)
+		case 2:
+			result = float64(math.Float32frombits(uint32(value)))
+		case 3:
+			result = math.Float64frombits(value)
+		case 4:
+			result = int64(value)
+		case 5:
+			result = value
+		case 6:
+			result = unzigzag(value)
+		case 7:
+			result = value != 0
+		}
+		return nil
+	})
+	return result, err
+}
+
+// decodeGeometry turns a feature's command stream into a shape in tile
+// coordinates. Unknown geometry types yield a nil shape.
+func decodeGeometry(geomType int, commands []uint32) (*shape, error) {
+	if geomType != mvtPoint && geomType != mvtLineString && geomType != mvtPolygon {
+		return nil, nil
+	}
+
+	var parts [][]float64
+	var current []float64
+	var x, y int64
+
+	for i := 0; i < len(commands); {
+		id, count := commands[i]&7, int(commands[i]>>3)
+		i++
+
+		switch id {
+		case mvtMoveTo, mvtLineTo:
+			if i+2*count > len(commands) {
+				return nil, errors.New("truncated geometry command")
+			}
+			if id == mvtMoveTo && geomType != mvtPoint {
+				if current != nil {
+					parts = append(parts, current)
+				}
+				current = nil
+			}
+			for j := 0; j < count; j++ {
+				x += unzigzag(uint64(commands[i]))
+				y += unzigzag(uint64(commands[i+1]))
+				i += 2
+				current = append(current, float64(x), float64(y))
+			}
+		case mvtClosePath:
+			if len(current) < 2 {
+				return nil, errors.New("close path without a ring")
+			}
+			current = append(current, current[0], current[1])
+		default:
+			return nil, fmt.Errorf("unknown geometry command %d", id)
+		}
+	}
+	if current != nil {
+		parts = append(parts, current)
+	}
+
+	switch geomType {
+	case mvtPoint:
+		if len(parts) == 0 {
+			return &shape{typeID: C.GEOS_MULTIPOINT}, nil
+		}
+		if len(parts[0]) == 2 {
+			return &shape{typeID: C.GEOS_POINT, coords: parts[0]}, nil
+		}
+		multi := &shape{typeID: C.GEOS_MULTIPOINT}
+		for k := 0; k < len(parts[0]); k += 2 {
+			multi.parts = append(multi.parts, &shape{typeID: C.GEOS_POINT, coords: parts[0][k : k+2]})
+		}
+		return multi, nil
+
+	case mvtLineString:
+		if len(parts) == 1 {
+			return &shape{typeID: C.GEOS_LINESTRING, coords: parts[0]}, nil
+		}
+		multi := &shape{typeID: C.GEOS_MULTILINESTRING}
+		for _, part := range parts {
+			multi.parts = append(multi.parts, &shape{typeID: C.GEOS_LINESTRING, coords: part})
+		}
+		return multi, nil
+	}
+
+	// Exterior rings have a positive signed area in tile coordinates (Y down)
+	// and start a new polygon; interior rings belong to the preceding one
+	var polygons []*shape
+	for _, ring := range parts {
+		r := &shape{typeID: C.GEOS_LINEARRING, coords: ring}
+		area := r.signedRingArea()
+		if area == 0 {
+			continue
+		}
+		if area > 0 || len(polygons) == 0 {
+			polygons = append(polygons, &shape{typeID: C.GEOS_POLYGON, parts: []*shape{r}})
+			continue
+		}
+		last := polygons[len(polygons)-1]
+		last.parts = append(last.parts, r)
+	}
+	if len(polygons) == 1 {
+		return polygons[0], nil
+	}
+	return &shape{typeID: C.GEOS_MULTIPOLYGON, parts: polygons}, nil
+}
+
+// tileToLonLat returns a function converting tile coordinates of the given
+// tile to WGS84 longitude and latitude
+func tileToLonLat(opts DecodeTileOptions, extent int) func(x, y float64) (float64, float64) {
+	scale := float64(mercator.TileSize) / float64(extent)
+	return func(x, y float64) (float64, float64) {
+		px := float64(opts.X*mercator.TileSize) + x*scale
+		py := float64(opts.Y*mercator.TileSize) + y*scale
+		return mercator.PixelToLonLat(px, py, opts.Z)
+	}
+}
+
+// readMessage walks the fields of a protobuf message. For varint and fixed
+// width fields value holds the raw bits; for length-delimited fields bytes
+// holds the payload.
+func readMessage(data []byte, fn func(field int, wire int, value uint64, bytes []byte) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errors.New("malformed vector tile: bad field key")
+		}
+		data = data[n:]
+		field, wire := int(key>>3), int(key&7)
+
+		var value uint64
+		var bytes []byte
+		switch wire {
+		case 0:
+			value, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errors.New("malformed vector tile: bad varint")
+			}
+			data = data[n:]
+		case 1:
+			if len(data) < 8 {
+				return errors.New("malformed vector tile: truncated fixed64")
+			}
+			value = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		case 2:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return errors.New("malformed vector tile: bad length")
+			}
+			bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		case 5:
+			if len(data) < 4 {
+				return errors.New("malformed vector tile: truncated fixed32")
+			}
+			value = uint64(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+		default:
+			return fmt.Errorf("malformed vector tile: unsupported wire type %d", wire)
+		}
+
+		if err := fn(field, wire, value, bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendPacked appends a repeated uint32 field in either packed or unpacked form
+func appendPacked(dst []uint32, wire int, value uint64, bytes []byte) ([]uint32, error) {
+	if wire == 0 {
+		return append(dst, uint32(value)), nil
+	}
+	if wire != 2 {
+		return dst, fmt.Errorf("malformed vector tile: unexpected wire type %d", wire)
+	}
+	for len(bytes) > 0 {
+		v, n := binary.Uvarint(bytes)
+		if n <= 0 {
+			return dst, errors.New("malformed vector tile: bad packed varint")
+		}
+		dst = append(dst, uint32(v))
+		bytes = bytes[n:]
+	}
+	return dst, nil
+}
+
+// unzigzag decodes a zigzag encoded signed integer
+func unzigzag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
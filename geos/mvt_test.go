@@ -0,0 +1,244 @@
+package geos
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+)
+
+// pbKey appends a protobuf field key
+func pbKey(b []byte, field, wire int) []byte {
+	return binary.AppendUvarint(b, uint64(field<<3|wire))
+}
+
+// pbVarint appends a varint field
+func pbVarint(b []byte, field int, v uint64) []byte {
+	return binary.AppendUvarint(pbKey(b, field, 0), v)
+}
+
+// pbBytes appends a length-delimited field
+func pbBytes(b []byte, field int, data []byte) []byte {
+	b = binary.AppendUvarint(pbKey(b, field, 2), uint64(len(data)))
+	return append(b, data...)
+}
+
+// pbPacked appends a packed repeated uint32 field
+func pbPacked(b []byte, field int, values ...uint32) []byte {
+	var packed []byte
+	for _, v := range values {
+		packed = binary.AppendUvarint(packed, uint64(v))
+	}
+	return pbBytes(b, field, packed)
+}
+
+// zz zigzag encodes a geometry parameter
+func zz(v int64) uint32 {
+	return uint32((v << 1) ^ (v >> 63))
+}
+
+// command encodes an MVT geometry command
+func command(id, count uint32) uint32 {
+	return id | count<<3
+}
+
+// TestDecodeVectorTile tests decoding layers, properties and geometries
+func TestDecodeVectorTile(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	var road []byte
+	road = pbVarint(road, 1, 7)
+	road = pbPacked(road, 2, 0, 0, 1, 1)
+	road = pbVarint(road, 3, mvtLineString)
+	road = pbPacked(road, 4, command(mvtMoveTo, 1), zz(10), zz(10), command(mvtLineTo, 2), zz(10), zz(0), zz(0), zz(10))
+
+	var stops []byte
+	stops = pbVarint(stops, 3, mvtPoint)
+	stops = pbPacked(stops, 4, command(mvtMoveTo, 2), zz(5), zz(5), zz(1), zz(1))
+
+	var building []byte
+	building = pbVarint(building, 3, mvtPolygon)
+	building = pbPacked(building, 4,
+		command(mvtMoveTo, 1), zz(0), zz(0),
+		command(mvtLineTo, 3), zz(10), zz(0), zz(0), zz(10), zz(-10), zz(0),
+		command(mvtClosePath, 1),
+		command(mvtMoveTo, 1), zz(2), zz(-8),
+		command(mvtLineTo, 3), zz(0), zz(2), zz(2), zz(0), zz(0), zz(-2),
+		command(mvtClosePath, 1))
+
+	var unknown []byte
+	unknown = pbVarint(unknown, 3, 0)
+
+	var layer []byte
+	layer = pbVarint(layer, 15, 2)
+	layer = pbBytes(layer, 1, []byte("transport"))
+	layer = pbBytes(layer, 2, road)
+	layer = pbBytes(layer, 2, stops)
+	layer = pbBytes(layer, 2, building)
+	layer = pbBytes(layer, 2, unknown)
+	layer = pbBytes(layer, 3, []byte("name"))
+	layer = pbBytes(layer, 3, []byte("lanes"))
+	layer = pbBytes(layer, 4, pbBytes(nil, 1, []byte("Main")))
+	layer = pbBytes(layer, 4, pbVarint(nil, 4, 2))
+	layer = pbVarint(layer, 5, 4096)
+
+	tile := pbBytes(nil, 3, layer)
+
+	layers, err := helper.service.DecodeVectorTile(tile, DecodeTileOptions{})
+	if err != nil {
+		t.Fatalf("Failed to decode tile: %v", err)
+	}
+	if len(layers) != 1 {
+		t.Fatalf("Expected 1 layer, got %d", len(layers))
+	}
+
+	l := layers[0]
+	if l.Name != "transport" || l.Version != 2 || l.Extent != 4096 {
+		t.Errorf("Unexpected layer header: %q v%d extent %d", l.Name, l.Version, l.Extent)
+	}
+	if len(l.Features) != 3 {
+		t.Fatalf("Expected 3 features, got %d", len(l.Features))
+	}
+
+	first := l.Features[0]
+	if !first.HasID || first.ID != 7 {
+		t.Errorf("Expected feature id 7, got %d (has id %t)", first.ID, first.HasID)
+	}
+	if first.Properties["name"] != "Main" || first.Properties["lanes"] != int64(2) {
+		t.Errorf("Unexpected properties: %v", first.Properties)
+	}
+	if l.Features[1].HasID {
+		t.Error("Expected second feature to have no id")
+	}
+
+	expected := []string{
+		"LINESTRING (10 10, 20 10, 20 20)",
+		"MULTIPOINT ((5 5), (6 6))",
+		"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 4, 4 4, 4 2, 2 2))",
+	}
+	for i, want := range expected {
+		wkt, err := helper.service.ToWKTWithOptions(l.Features[i].Geometry, WKTOptions{Trim: true})
+		if err != nil {
+			t.Fatalf("Failed to convert to WKT: %v", err)
+		}
+		if wkt != want {
+			t.Errorf("Feature %d: expected %s, got %s", i, want, wkt)
+		}
+	}
+}
+
+// TestDecodeVectorTileGeographic tests conversion to longitude and latitude
+func TestDecodeVectorTileGeographic(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	var point []byte
+	point = pbVarint(point, 3, mvtPoint)
+	point = pbPacked(point, 4, command(mvtMoveTo, 1), zz(2048), zz(2048))
+
+	var layer []byte
+	layer = pbBytes(layer, 1, []byte("points"))
+	layer = pbBytes(layer, 2, point)
+
+	tile := pbBytes(nil, 3, layer)
+
+	testCases := []struct {
+		name     string
+		opts     DecodeTileOptions
+		lon, lat float64
+	}{
+		{"World tile", DecodeTileOptions{Geographic: true}, 0, 0},
+		{"Zoom 1 north-east", DecodeTileOptions{Geographic: true, Z: 1, X: 1, Y: 0}, 90, 66.51326044311186},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			layers, err := helper.service.DecodeVectorTile(tile, tc.opts)
+			if err != nil {
+				t.Fatalf("Failed to decode tile: %v", err)
+			}
+
+			expected := helper.ParseWKT(fmt.Sprintf("POINT(%.17g %.17g)", tc.lon, tc.lat))
+			distance, err := helper.service.Distance(layers[0].Features[0].Geometry, expected)
+			if err != nil {
+				t.Fatalf("Failed to calculate distance: %v", err)
+			}
+			if distance > 1e-9 {
+				t.Errorf("Expected point near (%g %g), off by %g", tc.lon, tc.lat, distance)
+			}
+		})
+	}
+
+	if _, err := helper.service.DecodeVectorTile(tile, DecodeTileOptions{Geographic: true, Z: 1, X: 2}); err == nil {
+		t.Error("Expected error for tile outside the zoom level")
+	}
+}
+
+// TestDecodeVectorTileMalformed tests rejection of corrupt tiles
+func TestDecodeVectorTileMalformed(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"Bad length", []byte{0x1a, 0x10, 0x01}},
+		{"Tag out of range", pbBytes(nil, 3, pbBytes(nil, 2, pbPacked(nil, 2, 0, 0)))},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := helper.service.DecodeVectorTile(tc.data, DecodeTileOptions{}); err == nil {
+				t.Error("Expected error for malformed tile")
+			}
+		})
+	}
+}
+
+// TestDecodeVectorTileBadFeatures tests that broken features are reported
+// one by one without failing the rest of the tile
+func TestDecodeVectorTileBadFeatures(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	var truncated []byte
+	truncated = pbVarint(truncated, 3, mvtLineString)
+	truncated = pbPacked(truncated, 4, command(mvtMoveTo, 2), zz(1))
+
+	// A clipped ring that lost its closing point
+	var sliver []byte
+	sliver = pbVarint(sliver, 3, mvtPolygon)
+	sliver = pbPacked(sliver, 4, command(mvtMoveTo, 1), zz(0), zz(0), command(mvtLineTo, 2), zz(10), zz(0), zz(0), zz(10))
+
+	var road []byte
+	road = pbVarint(road, 3, mvtLineString)
+	road = pbPacked(road, 4, command(mvtMoveTo, 1), zz(0), zz(0), command(mvtLineTo, 1), zz(10), zz(0))
+
+	var layer []byte
+	layer = pbBytes(layer, 1, []byte("roads"))
+	layer = pbBytes(layer, 2, truncated)
+	layer = pbBytes(layer, 2, sliver)
+	layer = pbBytes(layer, 2, road)
+
+	layers, err := helper.service.DecodeVectorTile(pbBytes(nil, 3, layer), DecodeTileOptions{})
+	if err != nil {
+		t.Fatalf("Failed to decode tile: %v", err)
+	}
+	features := layers[0].Features
+	if len(features) != 3 {
+		t.Fatalf("Expected 3 features, got %d", len(features))
+	}
+	for i, f := range features[:2] {
+		if f.Err == nil || f.Geometry != nil {
+			t.Errorf("Feature %d: expected an error and no geometry, got %v", i, f.Err)
+		}
+	}
+	if features[2].Err != nil {
+		t.Fatalf("Expected the road to decode, got %v", features[2].Err)
+	}
+	wkt, err := helper.service.ToWKTWithOptions(features[2].Geometry, WKTOptions{Trim: true})
+	if err != nil || wkt != "LINESTRING (0 0, 10 0)" {
+		t.Errorf("Expected the road, got %s (%v)", wkt, err)
+	}
+}
@@ -0,0 +1,242 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+)
+
+// Reverse flips the coordinate order of a geometry: lines run the other way
+// and rings change orientation. Multi-part geometries keep their part order.
+// This is how line direction and ring winding conventions are enforced before
+// export.
+//
+// Parameters:
+//   - geom: The geometry to reverse
+//
+// Returns:
+//   - *Geometry: A new geometry with reversed coordinates
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	line := GeometryInput{WKT: "LINESTRING(0 0, 1 1, 2 0)"}
+//	geom, _ := service.ParseGeometry(line)
+//
+//	reversed, err := service.Reverse(geom)
+//	// reversed will be LINESTRING (2 0, 1 1, 0 0)
+func (s *Service) Reverse(geom *Geometry) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	reversed := C.GEOSReverse_r(c.context, geom.geom)
+	if reversed == nil {
+		return nil, c.geosError("failed to reverse geometry")
+	}
+
+	return c.newDerivedGeometry("Reverse", nil, reversed, geom), nil
+}
+
+// Normalize returns a copy of a geometry in canonical form: rings start at
+// their lowest vertex with a standard orientation, and the parts of
+// multi-geometries and collections are sorted. Two geometries with the same
+// structure and coordinates normalize to identical WKT, so the result can be
+// compared, hashed and diffed deterministically.
+//
+// Parameters:
+//   - geom: The geometry to normalize
+//
+// Returns:
+//   - *Geometry: A new normalized geometry
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	points := GeometryInput{WKT: "MULTIPOINT((2 2), (1 1))"}
+//	geom, _ := service.ParseGeometry(points)
+//
+//	normalized, err := service.Normalize(geom)
+//	// normalized lists the points in GEOS canonical order
+func (s *Service) Normalize(geom *Geometry) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	normalized, err := c.normalizedCopy(geom.geom)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newDerivedGeometry("Normalize", nil, normalized, geom), nil
+}
+
+// normalizedCopy returns a normalized copy of a geometry, which the caller
+// must destroy.
+func (c *session) normalizedCopy(g *C.GEOSGeometry) (*C.GEOSGeometry, error) {
+	copied := C.GEOSGeom_clone_r(c.context, g)
+	if copied == nil {
+		return nil, c.geosError("failed to copy geometry")
+	}
+
+	if C.GEOSNormalize_r(c.context, copied) == -1 {
+		C.GEOSGeom_destroy_r(c.context, copied)
+		return nil, c.geosError("failed to normalize geometry")
+	}
+
+	return copied, nil
+}
+
+// IsCCW tests whether a ring runs counter-clockwise. This is the winding of
+// polygon shells required by the GeoJSON specification (RFC 7946), while
+// Shapefiles expect clockwise shells.
+//
+// Parameters:
+//   - ring: A LinearRing or closed LineString, e.g. the shell of a polygon
+//
+// Returns:
+//   - bool: True if the ring is counter-clockwise
+//   - error: An error if ring is not a closed line or the operation fails
+//
+// Example:
+//
+//	ring := GeometryInput{WKT: "LINESTRING(0 0, 1 0, 1 1, 0 0)"}
+//	geom, _ := service.ParseGeometry(ring)
+//
+//	ccw, err := service.IsCCW(geom)
+//	// ccw will be true
+func (s *Service) IsCCW(ring *Geometry) (bool, error) {
+	if ring == nil || ring.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(ring)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+
+	switch C.GEOSGeomTypeId_r(c.context, ring.geom) {
+	case C.GEOS_LINEARRING, C.GEOS_LINESTRING:
+	default:
+		return false, errors.New("geometry must be a LinearRing or closed LineString")
+	}
+	if C.GEOSisEmpty_r(c.context, ring.geom) != 0 || C.GEOSisClosed_r(c.context, ring.geom) != 1 {
+		return false, errors.New("ring must be closed and not empty")
+	}
+
+	seq := C.GEOSGeom_getCoordSeq_r(c.context, ring.geom)
+	if seq == nil {
+		return false, c.geosError("failed to get coordinate sequence")
+	}
+
+	var ccw C.char
+	if C.GEOSCoordSeq_isCCW_r(c.context, seq, &ccw) == 0 {
+		return false, c.geosError("failed to compute ring orientation")
+	}
+
+	return ccw == 1, nil
+}
+
+// ForceCW returns a copy of a geometry whose polygon shells run clockwise and
+// whose holes run counter-clockwise, the ring order of Shapefiles. Other
+// components are copied unchanged.
+//
+// Parameters:
+//   - geom: The geometry to orient
+//
+// Returns:
+//   - *Geometry: A new geometry with oriented polygon rings
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	square := GeometryInput{WKT: "POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))"}
+//	geom, _ := service.ParseGeometry(square)
+//
+//	oriented, err := service.ForceCW(geom)
+//	// oriented will be POLYGON ((0 0, 0 1, 1 1, 1 0, 0 0))
+func (s *Service) ForceCW(geom *Geometry) (*Geometry, error) {
+	return s.orientPolygons("ForceCW", geom, true)
+}
+
+// ForceCCW returns a copy of a geometry whose polygon shells run
+// counter-clockwise and whose holes run clockwise. Other components are
+// copied unchanged.
+//
+// Parameters:
+//   - geom: The geometry to orient
+//
+// Returns:
+//   - *Geometry: A new geometry with oriented polygon rings
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	oriented, err := service.ForceCCW(geom)
+func (s *Service) ForceCCW(geom *Geometry) (*Geometry, error) {
+	return s.orientPolygons("ForceCCW", geom, false)
+}
+
+// ForceRHR orients polygon rings by the right-hand rule of the GeoJSON
+// specification (RFC 7946): the area bounded by a ring is on the left, so
+// shells run counter-clockwise and holes clockwise. It is the same as
+// ForceCCW. Note that PostGIS ST_ForceRHR uses the opposite convention, which
+// corresponds to ForceCW.
+//
+// Parameters:
+//   - geom: The geometry to orient
+//
+// Returns:
+//   - *Geometry: A new geometry with oriented polygon rings
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	oriented, err := service.ForceRHR(geom)
+//	// oriented is ready to be exported as RFC 7946 GeoJSON
+func (s *Service) ForceRHR(geom *Geometry) (*Geometry, error) {
+	return s.orientPolygons("ForceRHR", geom, false)
+}
+
+// orientPolygons copies a geometry and orients its polygon rings, recorded
+// under the name of the calling method
+func (s *Service) orientPolygons(method string, geom *Geometry, exteriorCW bool) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	oriented := C.GEOSGeom_clone_r(c.context, geom.geom)
+	if oriented == nil {
+		return nil, c.geosError("failed to copy geometry")
+	}
+
+	if C.GEOSOrientPolygons_r(c.context, oriented, boolToCInt(exteriorCW)) == -1 {
+		C.GEOSGeom_destroy_r(c.context, oriented)
+		return nil, c.geosError("failed to orient polygons")
+	}
+
+	return c.newDerivedGeometry(method, nil, oriented, geom), nil
+}
@@ -0,0 +1,167 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestReverse tests flipping coordinate order
+func TestReverse(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected string
+	}{
+		{"Point", "POINT(1 2)", "POINT (1 2)"},
+		{"Line", "LINESTRING(0 0, 1 1, 2 0)", "LINESTRING (2 0, 1 1, 0 0)"},
+		{"Polygon", "POLYGON((0 0, 2 0, 2 2, 0 0))", "POLYGON ((0 0, 2 2, 2 0, 0 0))"},
+		{"MultiLineString keeps part order", "MULTILINESTRING((0 0, 1 0), (5 5, 6 6))", "MULTILINESTRING ((1 0, 0 0), (6 6, 5 5))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.wkt)
+
+			reversed, err := helper.service.Reverse(geom)
+			if err != nil {
+				t.Fatalf("Failed to reverse: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(reversed, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.Reverse(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestNormalize tests canonical ordering of equivalent geometries
+func TestNormalize(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name string
+		a, b string
+	}{
+		{"Ring start and orientation", "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))", "POLYGON((2 2, 0 2, 0 0, 2 0, 2 2))"},
+		{"Point order", "MULTIPOINT((1 1), (2 2), (0 5))", "MULTIPOINT((0 5), (2 2), (1 1))"},
+		{"Line direction", "MULTILINESTRING((0 0, 1 1), (3 3, 2 2))", "MULTILINESTRING((2 2, 3 3), (1 1, 0 0))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var wkts [2]string
+			for i, input := range []string{tc.a, tc.b} {
+				normalized, err := helper.service.Normalize(helper.ParseWKT(input))
+				if err != nil {
+					t.Fatalf("Failed to normalize: %v", err)
+				}
+				wkts[i] = helper.AssertToWKT(normalized)
+			}
+			if wkts[0] != wkts[1] {
+				t.Errorf("Expected identical normalized forms, got %s and %s", wkts[0], wkts[1])
+			}
+		})
+	}
+
+	// The input is left untouched
+	geom := helper.ParseWKT("MULTIPOINT((2 2), (1 1))")
+	if _, err := helper.service.Normalize(geom); err != nil {
+		t.Fatalf("Failed to normalize: %v", err)
+	}
+	if wkt, _ := helper.service.ToWKTWithOptions(geom, WKTOptions{Trim: true}); wkt != "MULTIPOINT ((2 2), (1 1))" {
+		t.Errorf("Expected input to be unchanged, got %s", wkt)
+	}
+
+	if _, err := helper.service.Normalize(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestIsCCW tests ring orientation
+func TestIsCCW(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected bool
+	}{
+		{"Counter-clockwise", "LINESTRING(0 0, 1 0, 1 1, 0 0)", true},
+		{"Clockwise", "LINESTRING(0 0, 1 1, 1 0, 0 0)", false},
+		{"Linear ring", "LINEARRING(0 0, 0 1, 1 1, 1 0, 0 0)", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ccw, err := helper.service.IsCCW(helper.ParseWKT(tc.wkt))
+			if err != nil {
+				t.Fatalf("Failed to test orientation: %v", err)
+			}
+			if ccw != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, ccw)
+			}
+		})
+	}
+
+	if _, err := helper.service.IsCCW(helper.ParseWKT("LINESTRING(0 0, 1 0, 1 1)")); err == nil {
+		t.Error("Expected error for an open line")
+	}
+	if _, err := helper.service.IsCCW(helper.PolygonGeometry()); err == nil {
+		t.Error("Expected error for a polygon")
+	}
+}
+
+// TestForceOrientation tests orienting polygon rings
+func TestForceOrientation(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	ccwSquare := "POLYGON((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 1 2, 2 2, 2 1, 1 1))"
+	cwSquare := "POLYGON ((0 0, 0 4, 4 4, 4 0, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1))"
+
+	testCases := []struct {
+		name     string
+		force    func(*Geometry) (*Geometry, error)
+		wkt      string
+		expected string
+	}{
+		{"ForceCW reverses a counter-clockwise polygon", helper.service.ForceCW, ccwSquare, cwSquare},
+		{"ForceCCW keeps a counter-clockwise polygon", helper.service.ForceCCW, ccwSquare, "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 1 2, 2 2, 2 1, 1 1))"},
+		{"ForceRHR shells run counter-clockwise", helper.service.ForceRHR, cwSquare, "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 1 2, 2 2, 2 1, 1 1))"},
+		{"Lines are unchanged", helper.service.ForceCW, "LINESTRING(0 0, 1 0, 1 1, 0 0)", "LINESTRING (0 0, 1 0, 1 1, 0 0)"},
+		{"MultiPolygon", helper.service.ForceCW, "MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)), ((5 5, 5 6, 6 6, 5 5)))", "MULTIPOLYGON (((0 0, 1 1, 1 0, 0 0)), ((5 5, 5 6, 6 6, 5 5)))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			oriented, err := tc.force(helper.ParseWKT(tc.wkt))
+			if err != nil {
+				t.Fatalf("Failed to orient: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(oriented, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.ForceCW(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
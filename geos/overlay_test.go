@@ -0,0 +1,129 @@
+package geos
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestOverlayPrec tests overlay operations on a precision grid
+func TestOverlayPrec(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	a := helper.ParseWKT("POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))")
+	b := helper.ParseWKT("POLYGON((1.04 1.04, 3 1.04, 3 3, 1.04 3, 1.04 1.04))")
+
+	testCases := []struct {
+		name     string
+		op       func(a, b *Geometry, grid float64) (*Geometry, error)
+		grid     float64
+		expected string
+	}{
+		{"Intersection", helper.service.IntersectionPrec, 0.1, "POLYGON ((1 1, 1 2, 2 2, 2 1, 1 1))"},
+		{"Intersection floating", helper.service.IntersectionPrec, 0, "POLYGON ((1.04 1.04, 1.04 2, 2 2, 2 1.04, 1.04 1.04))"},
+		{"Difference", helper.service.DifferencePrec, 0.1, "POLYGON ((0 0, 0 2, 1 2, 1 1, 2 1, 2 0, 0 0))"},
+		{"Union", func(a, b *Geometry, grid float64) (*Geometry, error) {
+			return helper.service.UnionPrec([]*Geometry{a, nil, b}, grid)
+		}, 0.1, "POLYGON ((0 0, 0 2, 1 2, 1 3, 3 3, 3 1, 2 1, 2 0, 0 0))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.op(a, b, tc.grid)
+			if err != nil {
+				t.Fatalf("Failed to run overlay: %v", err)
+			}
+
+			// Compare normalized forms, since overlays do not fix the ring start
+			result, err = helper.service.Normalize(result)
+			if err != nil {
+				t.Fatalf("Failed to normalize: %v", err)
+			}
+			wkt, err := helper.service.ToWKTWithOptions(result, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	t.Run("SymDifference", func(t *testing.T) {
+		result, err := helper.service.SymDifferencePrec(a, b, 0.1)
+		if err != nil {
+			t.Fatalf("Failed to run overlay: %v", err)
+		}
+		for _, p := range []struct {
+			wkt    string
+			inside bool
+		}{
+			{"POINT(0.5 0.5)", true},
+			{"POINT(1.5 1.5)", false},
+			{"POINT(2.5 2.5)", true},
+			{"POINT(1.02 2.5)", true}, // b was snapped from x=1.04 to x=1
+		} {
+			within, err := helper.service.Within(helper.ParseWKT(p.wkt), result)
+			if err != nil {
+				t.Fatalf("Failed to test within: %v", err)
+			}
+			if within != p.inside {
+				t.Errorf("Expected %s within %v, got %v", p.wkt, p.inside, within)
+			}
+		}
+	})
+
+	if _, err := helper.service.IntersectionPrec(a, b, -1); err == nil {
+		t.Error("Expected error for negative grid size")
+	}
+	if _, err := helper.service.DifferencePrec(a, nil, 1); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+	if _, err := helper.service.UnionPrec([]*Geometry{nil}, 1); err == nil {
+		t.Error("Expected error for no geometries")
+	}
+}
+
+// TestDisjointSubsetUnion tests unioning a collection of mostly disjoint parts
+func TestDisjointSubsetUnion(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	collection := helper.ParseWKT("GEOMETRYCOLLECTION(POLYGON((0 0, 2 0, 2 2, 0 2, 0 0)), POLYGON((1 1, 3 1, 3 3, 1 3, 1 1)), POLYGON((10 10, 11 10, 11 11, 10 11, 10 10)))")
+
+	union, err := helper.service.DisjointSubsetUnion(collection)
+	if err != nil {
+		t.Fatalf("Failed to union: %v", err)
+	}
+
+	wkt, err := helper.service.ToWKTWithOptions(union, WKTOptions{Trim: true})
+	if err != nil {
+		t.Fatalf("Failed to convert to WKT: %v", err)
+	}
+	if !strings.HasPrefix(wkt, "MULTIPOLYGON") {
+		t.Errorf("Expected a MultiPolygon, got %s", wkt)
+	}
+
+	for _, p := range []struct {
+		wkt    string
+		inside bool
+	}{
+		{"POINT(1.5 1.5)", true},
+		{"POINT(2.5 2.5)", true},
+		{"POINT(10.5 10.5)", true},
+		{"POINT(0.5 2.5)", false},
+		{"POINT(5 5)", false},
+	} {
+		within, err := helper.service.Within(helper.ParseWKT(p.wkt), union)
+		if err != nil {
+			t.Fatalf("Failed to test within: %v", err)
+		}
+		if within != p.inside {
+			t.Errorf("Expected %s within %v, got %v", p.wkt, p.inside, within)
+		}
+	}
+
+	if _, err := helper.service.DisjointSubsetUnion(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,359 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ExtractHoles returns the interior rings of a polygon or multipolygon as
+// standalone polygons, in ring order. This is useful for processing lakes in
+// land masses or building inverse masks. A geometry without holes yields an
+// empty slice.
+//
+// Parameters:
+//   - geom: The Polygon or MultiPolygon to extract holes from
+//
+// Returns:
+//   - []*Geometry: One polygon per interior ring
+//   - error: An error if the geometry is not polygonal or the operation fails
+//
+// Example:
+//
+//	donut := GeometryInput{WKT: "POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 6 4, 6 6, 4 6, 4 4))"}
+//	geom, _ := service.ParseGeometry(donut)
+//
+//	holes, err := service.ExtractHoles(geom)
+//	// holes[0] will be POLYGON ((4 4, 6 4, 6 6, 4 6, 4 4))
+func (s *Service) ExtractHoles(geom *Geometry) ([]*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	sh, err := c.readShape(geom.geom)
+	if err != nil {
+		return nil, err
+	}
+
+	var polygons []*shape
+	switch sh.typeID {
+	case C.GEOS_POLYGON:
+		polygons = []*shape{sh}
+	case C.GEOS_MULTIPOLYGON:
+		polygons = sh.parts
+	default:
+		return nil, fmt.Errorf("holes can only be extracted from polygons, got type id %d", int(sh.typeID))
+	}
+
+	holes := make([]*Geometry, 0)
+	for _, polygon := range polygons {
+		if len(polygon.parts) < 2 {
+			continue
+		}
+		for _, ring := range polygon.parts[1:] {
+			hole, err := c.buildShape(&shape{
+				typeID: C.GEOS_POLYGON,
+				hasZ:   polygon.hasZ,
+				hasM:   polygon.hasM,
+				parts:  []*shape{ring},
+			})
+			if err != nil {
+				c.discard(holes...)
+				return nil, err
+			}
+			holes = append(holes, c.newGeometry(hole))
+		}
+	}
+	c.inheritSRID([]*Geometry{geom}, holes...)
+	c.record("ExtractHoles", nil, []*Geometry{geom}, holes...)
+
+	return holes, nil
+}
+
+// Invert returns the complement of a geometry within a bounding box, i.e. the
+// box minus the geometry. This builds "everything except the area of interest"
+// masks for rendering. Parts of the geometry outside the box are ignored.
+//
+// Parameters:
+//   - geom: The geometry to cut out of the box
+//   - minX, minY, maxX, maxY: The bounding box of the mask
+//
+// Returns:
+//   - *Geometry: A new polygonal geometry covering the box outside geom
+//   - error: An error if the box is malformed or the operation fails
+//
+// Example:
+//
+//	aoi := GeometryInput{WKT: "POLYGON((2 2, 8 2, 8 8, 2 8, 2 2))"}
+//	geom, _ := service.ParseGeometry(aoi)
+//
+//	mask, err := service.Invert(geom, 0, 0, 10, 10)
+//	// mask will be POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (2 2, 2 8, 8 8, 8 2, 2 2))
+func (s *Service) Invert(geom *Geometry, minX, minY, maxX, maxY float64) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	for _, v := range []float64{minX, minY, maxX, maxY} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.New("extent coordinates must be finite")
+		}
+	}
+	if minX >= maxX || minY >= maxY {
+		return nil, fmt.Errorf("invalid extent: (%g %g, %g %g)", minX, minY, maxX, maxY)
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	box := C.GEOSGeom_createRectangle_r(c.context, C.double(minX), C.double(minY), C.double(maxX), C.double(maxY))
+	if box == nil {
+		return nil, c.geosError("failed to create extent")
+	}
+	defer C.GEOSGeom_destroy_r(c.context, box)
+
+	inverted := C.GEOSDifference_r(c.context, box, geom.geom)
+	if inverted == nil {
+		return nil, c.geosError("failed to invert geometry")
+	}
+
+	return c.newDerivedGeometry("Invert", map[string]any{"minX": minX, "minY": minY, "maxX": maxX, "maxY": maxY}, inverted, geom), nil
+}
+
+// BoundaryPoint is a point on a polygon boundary returned by
+// PointsAlongBoundary
+type BoundaryPoint struct {
+	// Point is the location on the boundary
+	Point *Geometry
+
+	// Angle is the direction of the boundary at the point in degrees,
+	// counter-clockwise from the +X axis, following the ring orientation
+	Angle float64
+}
+
+// PointsAlongBoundary places points at a fixed spacing along every ring of a
+// polygon or multipolygon, together with the tangent direction of the
+// boundary, for edge labels and tick marks. Each ring starts a point at its
+// first vertex and continues along the ring orientation; the angle of a
+// point on a vertex is that of the following segment. Rings shorter than the
+// spacing get a single point.
+//
+// Parameters:
+//   - poly: The Polygon or MultiPolygon whose boundary to sample
+//   - spacing: The distance between consecutive points (must be positive)
+//
+// Returns:
+//   - []BoundaryPoint: The points, ring by ring, shells before their holes
+//   - error: An error if the geometry is not polygonal or the operation fails
+//
+// Example:
+//
+//	square, _ := service.ParseGeometry(GeometryInput{WKT: "POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))"})
+//
+//	ticks, err := service.PointsAlongBoundary(square, 5)
+//	// ticks[1] is POINT (5 0) with angle 0, ticks[2] is POINT (10 0) with angle 90
+func (s *Service) PointsAlongBoundary(poly *Geometry, spacing float64) ([]BoundaryPoint, error) {
+	if poly == nil || poly.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if !(spacing > 0) || math.IsInf(spacing, 0) {
+		return nil, fmt.Errorf("spacing must be positive, got %g", spacing)
+	}
+
+	c, err := s.acquire(poly)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	sh, err := c.readShape(poly.geom)
+	if err != nil {
+		return nil, err
+	}
+
+	var polygons []*shape
+	switch sh.typeID {
+	case C.GEOS_POLYGON:
+		polygons = []*shape{sh}
+	case C.GEOS_MULTIPOLYGON:
+		polygons = sh.parts
+	default:
+		return nil, fmt.Errorf("boundary points can only be placed on polygons, got type id %d", int(sh.typeID))
+	}
+
+	points := make([]BoundaryPoint, 0)
+	for _, polygon := range polygons {
+		for _, ring := range polygon.parts {
+			err := ring.walk(0, spacing, func(x, y, angle float64) error {
+				point := C.GEOSGeom_createPointFromXY_r(c.context, C.double(x), C.double(y))
+				if point == nil {
+					return c.geosError("failed to create boundary point")
+				}
+				points = append(points, BoundaryPoint{Point: c.newGeometry(point), Angle: angle})
+				return nil
+			})
+			if err != nil {
+				for _, p := range points {
+					c.discard(p.Point)
+				}
+				return nil, err
+			}
+		}
+	}
+
+	if c.journal != nil {
+		outputs := make([]*Geometry, len(points))
+		for i, p := range points {
+			outputs[i] = p.Point
+		}
+		c.inheritSRID([]*Geometry{poly}, outputs...)
+		c.record("PointsAlongBoundary", map[string]any{"spacing": spacing}, []*Geometry{poly}, outputs...)
+	}
+
+	return points, nil
+}
+
+// ExteriorRing returns a copy of the shell of a polygon as a LinearRing,
+// keeping its Z and M values. The shell of an empty polygon is an empty ring.
+//
+// Parameters:
+//   - polygon: The Polygon to take the shell from
+//
+// Returns:
+//   - *Geometry: A new LinearRing
+//   - error: An error if the geometry is not a Polygon or the operation fails
+//
+// Example:
+//
+//	shell, err := service.ExteriorRing(parcel)
+//	n, _ := service.NumPoints(shell)
+func (s *Service) ExteriorRing(polygon *Geometry) (*Geometry, error) {
+	if polygon == nil || polygon.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	if !polygon.IsPolygon() {
+		return nil, fmt.Errorf("geometry must be a Polygon, got %v", polygon.Type())
+	}
+
+	c, err := s.acquire(polygon)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	return c.copyRing("ExteriorRing", nil, polygon, C.GEOSGetExteriorRing_r(c.context, polygon.geom))
+}
+
+// NumInteriorRings returns the number of holes of a polygon.
+//
+// Parameters:
+//   - polygon: The Polygon to count
+//
+// Returns:
+//   - int: The number of interior rings
+//   - error: An error if the geometry is not a Polygon or the operation fails
+//
+// Example:
+//
+//	n, err := service.NumInteriorRings(parcel)
+//	for i := 0; i < n; i++ {
+//		hole, _ := service.InteriorRingN(parcel, i)
+//		// ...
+//	}
+func (s *Service) NumInteriorRings(polygon *Geometry) (int, error) {
+	if polygon == nil || polygon.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+	if !polygon.IsPolygon() {
+		return 0, fmt.Errorf("geometry must be a Polygon, got %v", polygon.Type())
+	}
+
+	c, err := s.acquire(polygon)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+
+	n := C.GEOSGetNumInteriorRings_r(c.context, polygon.geom)
+	if n < 0 {
+		return 0, c.geosError("failed to count interior rings")
+	}
+
+	return int(n), nil
+}
+
+// InteriorRingN returns a copy of the hole at index n of a polygon as a
+// LinearRing. Unlike ExtractHoles, the ring keeps its own orientation and is
+// not turned into a polygon, which suits per-ring processing such as
+// dropping small holes before rebuilding the polygon.
+//
+// Parameters:
+//   - polygon: The Polygon to take the hole from
+//   - n: The zero-based index of the interior ring
+//
+// Returns:
+//   - *Geometry: A new LinearRing
+//   - error: An error if the geometry is not a Polygon, the index is out of
+//     range or the operation fails
+//
+// Example:
+//
+//	donut := GeometryInput{WKT: "POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 4 6, 6 6, 6 4, 4 4))"}
+//	geom, _ := service.ParseGeometry(donut)
+//
+//	hole, err := service.InteriorRingN(geom, 0)
+//	// hole will be LINEARRING (4 4, 4 6, 6 6, 6 4, 4 4)
+func (s *Service) InteriorRingN(polygon *Geometry, n int) (*Geometry, error) {
+	if polygon == nil || polygon.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	if !polygon.IsPolygon() {
+		return nil, fmt.Errorf("geometry must be a Polygon, got %v", polygon.Type())
+	}
+
+	c, err := s.acquire(polygon)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	count := C.GEOSGetNumInteriorRings_r(c.context, polygon.geom)
+	if count < 0 {
+		return nil, c.geosError("failed to count interior rings")
+	}
+	if n < 0 || n >= int(count) {
+		return nil, fmt.Errorf("interior ring index %d out of range [0, %d)", n, int(count))
+	}
+
+	return c.copyRing("InteriorRingN", map[string]any{"n": n}, polygon, C.GEOSGetInteriorRingN_r(c.context, polygon.geom, C.int(n)))
+}
+
+// copyRing copies a ring owned by a polygon into a new geometry, recorded as
+// the named method.
+func (c *session) copyRing(method string, params map[string]any, polygon *Geometry, ring *C.GEOSGeometry) (*Geometry, error) {
+	if ring == nil {
+		return nil, c.geosError("failed to get ring")
+	}
+	copied := C.GEOSGeom_clone_r(c.context, ring)
+	if copied == nil {
+		return nil, c.geosError("failed to copy ring")
+	}
+
+	return c.newDerivedGeometry(method, params, copied, polygon), nil
+}
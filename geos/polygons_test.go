@@ -0,0 +1,243 @@
+package geos
+
+import (
+	"math"
+	"testing"
+)
+
+// TestExtractHoles tests extracting interior rings as standalone polygons
+func TestExtractHoles(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected []string
+	}{
+		{"Polygon without holes", "POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))", nil},
+		{
+			"Polygon with two holes",
+			"POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1), (5 5, 6 5, 6 6, 5 6, 5 5))",
+			[]string{"POLYGON ((1 1, 2 1, 2 2, 1 2, 1 1))", "POLYGON ((5 5, 6 5, 6 6, 5 6, 5 5))"},
+		},
+		{
+			"MultiPolygon",
+			"MULTIPOLYGON(((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 2, 1 1)), ((10 10, 14 10, 14 14, 10 14, 10 10), (11 11, 12 11, 12 12, 11 12, 11 11)))",
+			[]string{"POLYGON ((1 1, 2 1, 2 2, 1 2, 1 1))", "POLYGON ((11 11, 12 11, 12 12, 11 12, 11 11))"},
+		},
+		{"Empty polygon", "POLYGON EMPTY", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.wkt)
+
+			holes, err := helper.service.ExtractHoles(geom)
+			if err != nil {
+				t.Fatalf("Failed to extract holes: %v", err)
+			}
+			if len(holes) != len(tc.expected) {
+				t.Fatalf("Expected %d holes, got %d", len(tc.expected), len(holes))
+			}
+			for i, hole := range holes {
+				wkt, err := helper.service.ToWKTWithOptions(hole, WKTOptions{Trim: true})
+				if err != nil {
+					t.Fatalf("Failed to convert to WKT: %v", err)
+				}
+				if wkt != tc.expected[i] {
+					t.Errorf("Hole %d: expected %s, got %s", i, tc.expected[i], wkt)
+				}
+			}
+		})
+	}
+
+	if _, err := helper.service.ExtractHoles(helper.LineGeometry()); err == nil {
+		t.Error("Expected error for non-polygonal geometry")
+	}
+	if _, err := helper.service.ExtractHoles(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestInvert tests building the complement of a geometry within an extent
+func TestInvert(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	aoi := helper.ParseWKT("POLYGON((2 2, 8 2, 8 8, 2 8, 2 2))")
+
+	mask, err := helper.service.Invert(aoi, 0, 0, 10, 10)
+	if err != nil {
+		t.Fatalf("Failed to invert geometry: %v", err)
+	}
+
+	helper.AssertIntersects(mask, helper.ParseWKT("POINT(1 1)"), true)
+	helper.AssertIntersects(mask, helper.ParseWKT("POINT(5 5)"), false)
+	helper.AssertWithin(mask, helper.ParseWKT("POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))"), true)
+
+	// A geometry covering the whole extent leaves nothing
+	cover := helper.ParseWKT("POLYGON((-1 -1, 11 -1, 11 11, -1 11, -1 -1))")
+	empty, err := helper.service.Invert(cover, 0, 0, 10, 10)
+	if err != nil {
+		t.Fatalf("Failed to invert geometry: %v", err)
+	}
+	if wkt := helper.AssertToWKT(empty); wkt != "POLYGON EMPTY" {
+		t.Errorf("Expected POLYGON EMPTY, got %s", wkt)
+	}
+
+	if _, err := helper.service.Invert(aoi, 10, 0, 0, 10); err == nil {
+		t.Error("Expected error for inverted extent")
+	}
+	if _, err := helper.service.Invert(nil, 0, 0, 10, 10); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestPointsAlongBoundary tests evenly spaced boundary points with tangent angles
+func TestPointsAlongBoundary(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		spacing  float64
+		expected []string
+		angles   []float64
+	}{
+		{
+			"Square",
+			"POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))",
+			5,
+			[]string{"POINT (0 0)", "POINT (5 0)", "POINT (10 0)", "POINT (10 5)", "POINT (10 10)", "POINT (5 10)", "POINT (0 10)", "POINT (0 5)"},
+			[]float64{0, 0, 90, 90, 180, 180, -90, -90},
+		},
+		{
+			"Spacing across vertices",
+			"POLYGON((0 0, 4 0, 4 4, 0 0))",
+			7,
+			[]string{"POINT (0 0)", "POINT (4 3)"},
+			[]float64{0, 90},
+		},
+		{
+			"Polygon with hole",
+			"POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 4 6, 6 6, 6 4, 4 4))",
+			20,
+			[]string{"POINT (0 0)", "POINT (10 10)", "POINT (4 4)"},
+			[]float64{0, 180, 90},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			points, err := helper.service.PointsAlongBoundary(helper.ParseWKT(tc.wkt), tc.spacing)
+			if err != nil {
+				t.Fatalf("Failed to place boundary points: %v", err)
+			}
+			if len(points) != len(tc.expected) {
+				t.Fatalf("Expected %d points, got %d", len(tc.expected), len(points))
+			}
+			for i, p := range points {
+				wkt, err := helper.service.ToWKTWithOptions(p.Point, WKTOptions{Trim: true})
+				if err != nil {
+					t.Fatalf("Failed to convert to WKT: %v", err)
+				}
+				if wkt != tc.expected[i] {
+					t.Errorf("Point %d: expected %s, got %s", i, tc.expected[i], wkt)
+				}
+				if math.Abs(p.Angle-tc.angles[i]) > 1e-9 {
+					t.Errorf("Point %d: expected angle %g, got %g", i, tc.angles[i], p.Angle)
+				}
+			}
+		})
+	}
+
+	if _, err := helper.service.PointsAlongBoundary(helper.LineGeometry(), 1); err == nil {
+		t.Error("Expected error for a non-polygonal geometry")
+	}
+	if _, err := helper.service.PointsAlongBoundary(helper.PolygonGeometry(), 0); err == nil {
+		t.Error("Expected error for zero spacing")
+	}
+}
+
+// TestPolygonRings tests reading the shell and holes of polygons
+func TestPolygonRings(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name  string
+		wkt   string
+		shell string
+		holes []string
+	}{
+		{
+			"Without holes",
+			"POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))",
+			"LINEARRING (0 0, 10 0, 10 10, 0 10, 0 0)",
+			nil,
+		},
+		{
+			"With holes",
+			"POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (1 1, 1 2, 2 2, 1 1), (5 5, 5 8, 8 8, 5 5))",
+			"LINEARRING (0 0, 10 0, 10 10, 0 10, 0 0)",
+			[]string{"LINEARRING (1 1, 1 2, 2 2, 1 1)", "LINEARRING (5 5, 5 8, 8 8, 5 5)"},
+		},
+		{
+			"Empty",
+			"POLYGON EMPTY",
+			"LINEARRING EMPTY",
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			polygon := helper.ParseWKT(tc.wkt)
+
+			shell, err := helper.service.ExteriorRing(polygon)
+			if err != nil {
+				t.Fatalf("Failed to get exterior ring: %v", err)
+			}
+			wkt, err := helper.service.ToWKTWithOptions(shell, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.shell {
+				t.Errorf("Expected shell %s, got %s", tc.shell, wkt)
+			}
+
+			n, err := helper.service.NumInteriorRings(polygon)
+			if err != nil {
+				t.Fatalf("Failed to count interior rings: %v", err)
+			}
+			if n != len(tc.holes) {
+				t.Fatalf("Expected %d interior rings, got %d", len(tc.holes), n)
+			}
+			for i, expected := range tc.holes {
+				hole, err := helper.service.InteriorRingN(polygon, i)
+				if err != nil {
+					t.Fatalf("Failed to get interior ring %d: %v", i, err)
+				}
+				wkt, err := helper.service.ToWKTWithOptions(hole, WKTOptions{Trim: true})
+				if err != nil {
+					t.Fatalf("Failed to convert to WKT: %v", err)
+				}
+				if wkt != expected {
+					t.Errorf("Ring %d: expected %s, got %s", i, expected, wkt)
+				}
+			}
+			if _, err := helper.service.InteriorRingN(polygon, n); err == nil {
+				t.Errorf("Expected error for index %d", n)
+			}
+		})
+	}
+
+	if _, err := helper.service.ExteriorRing(helper.LineGeometry()); err == nil {
+		t.Error("Expected error for a non-polygon")
+	}
+	if _, err := helper.service.NumInteriorRings(helper.ParseWKT("MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)))")); err == nil {
+		t.Error("Expected error for a MultiPolygon")
+	}
+}
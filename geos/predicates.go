@@ -0,0 +1,439 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// Relate computes the DE-9IM intersection matrix between two geometries.
+// The matrix is returned as a 9-character string (e.g. "212101212") describing
+// the dimension of the intersections between the interiors, boundaries and
+// exteriors of A and B.
+//
+// Parameters:
+//   - a: First geometry
+//   - b: Second geometry
+//
+// Returns:
+//   - string: The 9-character DE-9IM matrix
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	poly1 := GeometryInput{WKT: "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))"}
+//	poly2 := GeometryInput{WKT: "POLYGON((1 1, 3 1, 3 3, 1 3, 1 1))"}
+//
+//	geom1, _ := service.ParseGeometry(poly1)
+//	geom2, _ := service.ParseGeometry(poly2)
+//
+//	matrix, err := service.Relate(geom1, geom2)
+//	// matrix will be "212101212" (overlapping polygons)
+func (s *Service) Relate(a, b *Geometry) (string, error) {
+	if a == nil || b == nil || a.geom == nil || b.geom == nil {
+		return "", errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return "", err
+	}
+	defer c.release()
+
+	cMatrix := C.GEOSRelate_r(c.context, a.geom, b.geom)
+	if cMatrix == nil {
+		return "", c.geosError("GEOS relate operation failed")
+	}
+	defer C.GEOSFree_r(c.context, unsafe.Pointer(cMatrix))
+
+	return C.GoString(cMatrix), nil
+}
+
+// RelatePattern tests whether the DE-9IM matrix of two geometries matches a pattern.
+// The pattern is a 9-character string where each character is one of
+// 'T' (non-empty), 'F' (empty), '*' (any), '0', '1' or '2' (exact dimension).
+// This allows spatial relationships that the named predicates do not cover.
+//
+// Parameters:
+//   - a: First geometry
+//   - b: Second geometry
+//   - pattern: The 9-character DE-9IM pattern to match
+//
+// Returns:
+//   - bool: True if the relationship matches the pattern, false otherwise
+//   - error: An error if the pattern is malformed or the operation fails
+//
+// Example:
+//
+//	// Interiors intersect and A's boundary touches B's interior
+//	matches, err := service.RelatePattern(geom1, geom2, "T*T******")
+func (s *Service) RelatePattern(a, b *Geometry, pattern string) (bool, error) {
+	if a == nil || b == nil || a.geom == nil || b.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	if err := validateRelatePattern(pattern); err != nil {
+		return false, err
+	}
+
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+
+	cPattern := C.CString(pattern)
+	defer C.free(unsafe.Pointer(cPattern))
+
+	result := C.GEOSRelatePattern_r(c.context, a.geom, b.geom, cPattern)
+	if result == 2 { // Error case
+		return false, c.geosError("GEOS relate pattern operation failed")
+	}
+
+	return result == 1, nil
+}
+
+// PredicateSummary holds the outcome of every named spatial predicate for a
+// pair of geometries, as returned by RelateSummary
+type PredicateSummary struct {
+	// Matrix is the DE-9IM matrix the predicates were derived from
+	Matrix string `json:"matrix"`
+
+	Intersects bool `json:"intersects"`
+	Disjoint   bool `json:"disjoint"`
+	Touches    bool `json:"touches"`
+	Crosses    bool `json:"crosses"`
+	Within     bool `json:"within"`
+	Contains   bool `json:"contains"`
+	Overlaps   bool `json:"overlaps"`
+	Covers     bool `json:"covers"`
+	CoveredBy  bool `json:"covered_by"`
+	Equals     bool `json:"equals"`
+}
+
+// RelateSummary evaluates all named spatial predicates between two geometries
+// at once. The DE-9IM matrix is computed a single time and every predicate is
+// derived from it by its OGC definition, which is cheaper than calling each
+// predicate separately and gives a consistent truth table for debugging
+// confusing topology cases.
+//
+// Parameters:
+//   - a: First geometry
+//   - b: Second geometry
+//
+// Returns:
+//   - PredicateSummary: The matrix and the result of every predicate for "a <predicate> b"
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	square := GeometryInput{WKT: "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))"}
+//	edge := GeometryInput{WKT: "LINESTRING(0 0, 2 0)"}
+//
+//	geom1, _ := service.ParseGeometry(square)
+//	geom2, _ := service.ParseGeometry(edge)
+//
+//	summary, err := service.RelateSummary(geom1, geom2)
+//	// summary.Touches, summary.Covers and summary.Intersects are true;
+//	// summary.Contains is false because the line only lies on the boundary
+func (s *Service) RelateSummary(a, b *Geometry) (PredicateSummary, error) {
+	if a == nil || b == nil || a.geom == nil || b.geom == nil {
+		return PredicateSummary{}, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return PredicateSummary{}, err
+	}
+	defer c.release()
+
+	cMatrix := C.GEOSRelate_r(c.context, a.geom, b.geom)
+	if cMatrix == nil {
+		return PredicateSummary{}, c.geosError("GEOS relate operation failed")
+	}
+	matrix := C.GoString(cMatrix)
+	C.GEOSFree_r(c.context, unsafe.Pointer(cMatrix))
+
+	dimA := int(C.GEOSGeom_getDimensions_r(c.context, a.geom))
+	dimB := int(C.GEOSGeom_getDimensions_r(c.context, b.geom))
+
+	return summarizeMatrix(matrix, dimA, dimB), nil
+}
+
+// summarizeMatrix derives the named predicates from a DE-9IM matrix and the
+// dimensions of the two geometries
+func summarizeMatrix(matrix string, dimA, dimB int) PredicateSummary {
+	match := func(patterns ...string) bool {
+		for _, pattern := range patterns {
+			if matchesPattern(matrix, pattern) {
+				return true
+			}
+		}
+		return false
+	}
+
+	summary := PredicateSummary{Matrix: matrix}
+	summary.Disjoint = match("FF*FF****")
+	summary.Intersects = !summary.Disjoint
+	summary.Touches = match("FT*******", "F**T*****", "F***T****")
+	summary.Within = match("T*F**F***")
+	summary.Contains = match("T*****FF*")
+	summary.Covers = match("T*****FF*", "*T****FF*", "***T**FF*", "****T*FF*")
+	summary.CoveredBy = match("T*F**F***", "*TF**F***", "**FT*F***", "**F*TF***")
+	summary.Equals = match("T*F**FFF*")
+
+	switch {
+	case dimA < dimB:
+		summary.Crosses = match("T*T******")
+	case dimA > dimB:
+		summary.Crosses = match("T*****T**")
+	case dimA == 1:
+		summary.Crosses = match("0********")
+	}
+
+	if dimA == dimB {
+		if dimA == 1 {
+			summary.Overlaps = match("1*T***T**")
+		} else {
+			summary.Overlaps = match("T*T***T**")
+		}
+	}
+
+	return summary
+}
+
+// matchesPattern reports whether a DE-9IM matrix matches a pattern of 'T',
+// 'F', '*' and dimension characters
+func matchesPattern(matrix, pattern string) bool {
+	if len(matrix) != 9 || len(pattern) != 9 {
+		return false
+	}
+	for i := 0; i < 9; i++ {
+		switch p := pattern[i]; p {
+		case '*':
+		case 'T':
+			if matrix[i] == 'F' {
+				return false
+			}
+		default:
+			if matrix[i] != p {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validateRelatePattern checks that a DE-9IM pattern is well formed
+func validateRelatePattern(pattern string) error {
+	if len(pattern) != 9 {
+		return fmt.Errorf("invalid DE-9IM pattern %q: must be 9 characters", pattern)
+	}
+	for _, c := range pattern {
+		switch c {
+		case 'T', 'F', '*', '0', '1', '2', 't', 'f':
+		default:
+			return fmt.Errorf("invalid DE-9IM pattern %q: unexpected character %q", pattern, c)
+		}
+	}
+	return nil
+}
+
+// DWithin tests whether two geometries are within the given distance of each other.
+// It is equivalent to Distance(a, b) <= distance but much faster, because GEOS can
+// stop as soon as any pair of points closer than the distance is found. This makes
+// it the right choice for proximity filters over many candidates.
+//
+// Parameters:
+//   - a: First geometry
+//   - b: Second geometry
+//   - distance: The maximum distance (must not be negative)
+//
+// Returns:
+//   - bool: True if the geometries are within the distance, false otherwise
+//   - error: An error if the distance is negative or the operation fails
+//
+// Example:
+//
+//	store := GeometryInput{WKT: "POINT(0 0)"}
+//	customer := GeometryInput{WKT: "POINT(3 4)"}
+//
+//	geom1, _ := service.ParseGeometry(store)
+//	geom2, _ := service.ParseGeometry(customer)
+//
+//	near, err := service.DWithin(geom1, geom2, 5.0)
+//	// near will be true since the distance is exactly 5.0
+func (s *Service) DWithin(a, b *Geometry, distance float64) (bool, error) {
+	if a == nil || b == nil || a.geom == nil || b.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	if distance < 0 || math.IsNaN(distance) {
+		return false, fmt.Errorf("distance must not be negative, got %g", distance)
+	}
+
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+
+	result := C.GEOSDistanceWithin_r(c.context, a.geom, b.geom, C.double(distance))
+	if result == 2 { // Error case
+		return false, c.geosError("GEOS distance within operation failed")
+	}
+
+	return result == 1, nil
+}
+
+// EqualsWithTolerance tests whether two geometries have the same structure
+// and every pair of corresponding vertices lies within tolerance of each
+// other. Both are normalized first, so ring start points, ring direction and
+// component order do not matter. This suits tests and regression checks
+// across GEOS versions, whose results may differ in the last digits. Only X
+// and Y are compared, and vertices must correspond one to one, so a line
+// with an extra collinear vertex is not equal.
+//
+// Parameters:
+//   - a: First geometry
+//   - b: Second geometry
+//   - tolerance: The maximum distance between corresponding vertices (must not be negative)
+//
+// Returns:
+//   - bool: True if the geometries are equal within the tolerance
+//   - error: An error if the tolerance is negative or the operation fails
+//
+// Example:
+//
+//	expected := GeometryInput{WKT: "POLYGON((0 0, 1 0, 1 1, 0 0))"}
+//	actual := GeometryInput{WKT: "POLYGON((1 0.0000001, 1 1, 0 0, 1 0.0000001))"}
+//
+//	geom1, _ := service.ParseGeometry(expected)
+//	geom2, _ := service.ParseGeometry(actual)
+//
+//	equal, err := service.EqualsWithTolerance(geom1, geom2, 1e-6)
+//	// equal will be true
+func (s *Service) EqualsWithTolerance(a, b *Geometry, tolerance float64) (bool, error) {
+	if a == nil || b == nil || a.geom == nil || b.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	if tolerance < 0 || math.IsNaN(tolerance) {
+		return false, fmt.Errorf("tolerance must not be negative, got %g", tolerance)
+	}
+
+	c, err := s.acquire(a, b)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+
+	normalizedA, err := c.normalizedCopy(a.geom)
+	if err != nil {
+		return false, err
+	}
+	defer C.GEOSGeom_destroy_r(c.context, normalizedA)
+
+	normalizedB, err := c.normalizedCopy(b.geom)
+	if err != nil {
+		return false, err
+	}
+	defer C.GEOSGeom_destroy_r(c.context, normalizedB)
+
+	result := C.GEOSEqualsExact_r(c.context, normalizedA, normalizedB, C.double(tolerance))
+	if result == 2 { // Error case
+		return false, c.geosError("GEOS equals exact operation failed")
+	}
+
+	return result == 1, nil
+}
+
+// SpatialPredicate names a binary spatial relationship test. A predicate
+// is always read as "A <predicate> B", e.g. PredicateWithin tests A within B.
+type SpatialPredicate int
+
+const (
+	// PredicateIntersects tests whether A and B share any point
+	PredicateIntersects SpatialPredicate = iota
+	// PredicateDisjoint tests whether A and B share no point
+	PredicateDisjoint
+	// PredicateWithin tests whether A lies in the interior of B
+	PredicateWithin
+	// PredicateContains tests whether B lies in the interior of A
+	PredicateContains
+	// PredicateCoveredBy tests whether no point of A lies outside B
+	PredicateCoveredBy
+	// PredicateCovers tests whether no point of B lies outside A
+	PredicateCovers
+	// PredicateTouches tests whether A and B meet only at their boundaries
+	PredicateTouches
+	// PredicateCrosses tests whether A and B cross
+	PredicateCrosses
+	// PredicateOverlaps tests whether A and B overlap
+	PredicateOverlaps
+)
+
+// String returns the lowercase name of the predicate
+func (p SpatialPredicate) String() string {
+	switch p {
+	case PredicateIntersects:
+		return "intersects"
+	case PredicateDisjoint:
+		return "disjoint"
+	case PredicateWithin:
+		return "within"
+	case PredicateContains:
+		return "contains"
+	case PredicateCoveredBy:
+		return "coveredby"
+	case PredicateCovers:
+		return "covers"
+	case PredicateTouches:
+		return "touches"
+	case PredicateCrosses:
+		return "crosses"
+	case PredicateOverlaps:
+		return "overlaps"
+	}
+	return fmt.Sprintf("SpatialPredicate(%d)", int(p))
+}
+
+// valid reports whether p is a known predicate
+func (p SpatialPredicate) valid() bool {
+	return p >= PredicateIntersects && p <= PredicateOverlaps
+}
+
+// evaluatePrepared tests "a <p> B" where B is prepared, returning 2 on error.
+// Since prepared predicates are evaluated as "B <op> a", asymmetric
+// predicates use their converse.
+func (c *session) evaluatePrepared(prepared *C.GEOSPreparedGeometry, p SpatialPredicate, a *C.GEOSGeometry) C.char {
+	switch p {
+	case PredicateIntersects:
+		return C.GEOSPreparedIntersects_r(c.context, prepared, a)
+	case PredicateDisjoint:
+		return C.GEOSPreparedDisjoint_r(c.context, prepared, a)
+	case PredicateWithin:
+		return C.GEOSPreparedContains_r(c.context, prepared, a)
+	case PredicateContains:
+		return C.GEOSPreparedWithin_r(c.context, prepared, a)
+	case PredicateCoveredBy:
+		return C.GEOSPreparedCovers_r(c.context, prepared, a)
+	case PredicateCovers:
+		return C.GEOSPreparedCoveredBy_r(c.context, prepared, a)
+	case PredicateTouches:
+		return C.GEOSPreparedTouches_r(c.context, prepared, a)
+	case PredicateCrosses:
+		return C.GEOSPreparedCrosses_r(c.context, prepared, a)
+	case PredicateOverlaps:
+		return C.GEOSPreparedOverlaps_r(c.context, prepared, a)
+	}
+	return 2
+}
@@ -0,0 +1,248 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestRelate tests DE-9IM matrix computation
+func TestRelate(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		geomA    string
+		geomB    string
+		expected string
+	}{
+		{
+			"Overlapping polygons",
+			"POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))",
+			"POLYGON((1 1, 3 1, 3 3, 1 3, 1 1))",
+			"212101212",
+		},
+		{
+			"Point within polygon",
+			"POINT(1 1)",
+			"POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))",
+			"0FFFFF212",
+		},
+		{
+			"Disjoint points",
+			"POINT(0 0)",
+			"POINT(1 1)",
+			"FF0FFF0F2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := helper.ParseWKT(tc.geomA)
+			b := helper.ParseWKT(tc.geomB)
+
+			matrix, err := helper.service.Relate(a, b)
+			if err != nil {
+				t.Fatalf("Failed to relate: %v", err)
+			}
+			if matrix != tc.expected {
+				t.Errorf("Expected matrix %s, got %s", tc.expected, matrix)
+			}
+		})
+	}
+}
+
+// TestRelatePattern tests DE-9IM pattern matching
+func TestRelatePattern(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	point := helper.PointGeometry()
+	polygon := helper.PolygonGeometry()
+
+	testCases := []struct {
+		name     string
+		pattern  string
+		expected bool
+	}{
+		{"Within pattern", "T*F**F***", true},
+		{"Contains pattern", "T*****FF*", false},
+		{"Disjoint pattern", "FF*FF****", false},
+		{"Exact matrix", "0FFFFF212", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := helper.service.RelatePattern(point, polygon, tc.pattern)
+			if err != nil {
+				t.Fatalf("Failed to match pattern: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %t for pattern %s, got %t", tc.expected, tc.pattern, result)
+			}
+		})
+	}
+}
+
+// TestRelatePattern_Invalid tests malformed patterns and nil geometries
+func TestRelatePattern_Invalid(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	point := helper.PointGeometry()
+	polygon := helper.PolygonGeometry()
+
+	for _, pattern := range []string{"", "T*F**F**", "T*F**F***T", "X*F**F***"} {
+		if _, err := helper.service.RelatePattern(point, polygon, pattern); err == nil {
+			t.Errorf("Expected error for pattern %q", pattern)
+		}
+	}
+
+	if _, err := helper.service.Relate(nil, polygon); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+	if _, err := helper.service.RelatePattern(point, nil, "T********"); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestDWithin tests the distance within predicate
+func TestDWithin(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	origin := helper.ParseWKT("POINT(0 0)")
+	point := helper.ParseWKT("POINT(3 4)")
+	polygon := helper.ParseWKT("POLYGON((10 0, 12 0, 12 2, 10 2, 10 0))")
+
+	testCases := []struct {
+		name     string
+		a, b     *Geometry
+		distance float64
+		expected bool
+	}{
+		{"Exactly at distance", origin, point, 5.0, true},
+		{"Just outside distance", origin, point, 4.99, false},
+		{"Zero distance same point", origin, origin, 0, true},
+		{"Point to polygon", point, polygon, 7.0, true},
+		{"Point to polygon too far", origin, polygon, 9.0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := helper.service.DWithin(tc.a, tc.b, tc.distance)
+			if err != nil {
+				t.Fatalf("Failed to test distance within: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, result)
+			}
+		})
+	}
+
+	if _, err := helper.service.DWithin(origin, point, -1); err == nil {
+		t.Error("Expected error for negative distance")
+	}
+	if _, err := helper.service.DWithin(nil, point, 1); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestRelateSummary tests deriving every named predicate from one matrix
+func TestRelateSummary(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	square := "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))"
+
+	testCases := []struct {
+		name     string
+		geomA    string
+		geomB    string
+		expected []string
+	}{
+		{"Overlapping squares", square, "POLYGON((1 1, 3 1, 3 3, 1 3, 1 1))", []string{"intersects", "overlaps"}},
+		{"Edge on boundary", square, "LINESTRING(0 0, 2 0)", []string{"intersects", "touches", "covers"}},
+		{"Point inside", "POINT(1 1)", square, []string{"intersects", "within", "coveredby"}},
+		{"Crossing lines", "LINESTRING(0 0, 2 2)", "LINESTRING(0 2, 2 0)", []string{"intersects", "crosses"}},
+		{"Line crossing polygon", "LINESTRING(-1 1, 1 1)", square, []string{"intersects", "crosses"}},
+		{"Disjoint points", "POINT(0 0)", "POINT(5 5)", []string{"disjoint"}},
+		{"Equal squares", square, "POLYGON((2 2, 0 2, 0 0, 2 0, 2 2))", []string{"intersects", "within", "contains", "covers", "coveredby", "equals"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			summary, err := helper.service.RelateSummary(helper.ParseWKT(tc.geomA), helper.ParseWKT(tc.geomB))
+			if err != nil {
+				t.Fatalf("Failed to summarize: %v", err)
+			}
+
+			predicates := map[string]bool{
+				"intersects": summary.Intersects,
+				"disjoint":   summary.Disjoint,
+				"touches":    summary.Touches,
+				"crosses":    summary.Crosses,
+				"within":     summary.Within,
+				"contains":   summary.Contains,
+				"overlaps":   summary.Overlaps,
+				"covers":     summary.Covers,
+				"coveredby":  summary.CoveredBy,
+				"equals":     summary.Equals,
+			}
+			expected := map[string]bool{}
+			for _, name := range tc.expected {
+				expected[name] = true
+			}
+			for name, value := range predicates {
+				if value != expected[name] {
+					t.Errorf("Expected %s to be %v with matrix %s", name, expected[name], summary.Matrix)
+				}
+			}
+		})
+	}
+
+	if _, err := helper.service.RelateSummary(nil, helper.PointGeometry()); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestEqualsWithTolerance tests fuzzy vertex-wise comparison of geometries
+func TestEqualsWithTolerance(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name      string
+		a, b      string
+		tolerance float64
+		expected  bool
+	}{
+		{"Identical", "POINT(1 1)", "POINT(1 1)", 0, true},
+		{"Within tolerance", "LINESTRING(0 0, 1 1)", "LINESTRING(0.0000001 0, 1 0.9999999)", 1e-6, true},
+		{"Outside tolerance", "LINESTRING(0 0, 1 1)", "LINESTRING(0.001 0, 1 1)", 1e-6, false},
+		{"Different ring start", "POLYGON((0 0, 1 0, 1 1, 0 0))", "POLYGON((1 0, 1 1, 0 0, 1 0))", 0, true},
+		{"Reversed ring", "POLYGON((0 0, 1 0, 1 1, 0 0))", "POLYGON((0 0, 1 1, 1 0, 0 0))", 0, true},
+		{"Component order", "MULTIPOINT((0 0), (5 5))", "MULTIPOINT((5 5), (0.0001 0))", 0.001, true},
+		{"Extra vertex", "LINESTRING(0 0, 2 0)", "LINESTRING(0 0, 1 0, 2 0)", 0.1, false},
+		{"Different types", "POINT(0 0)", "MULTIPOINT((0 0))", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			equal, err := helper.service.EqualsWithTolerance(helper.ParseWKT(tc.a), helper.ParseWKT(tc.b), tc.tolerance)
+			if err != nil {
+				t.Fatalf("Failed to compare: %v", err)
+			}
+			if equal != tc.expected {
+				t.Errorf("Expected %t, got %t", tc.expected, equal)
+			}
+		})
+	}
+
+	point := helper.PointGeometry()
+	if _, err := helper.service.EqualsWithTolerance(point, point, -1); err == nil {
+		t.Error("Expected error for negative tolerance")
+	}
+	if _, err := helper.service.EqualsWithTolerance(nil, point, 1); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
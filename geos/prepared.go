@@ -0,0 +1,244 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// PreparedGeometry is a geometry with cached spatial indexes over its
+// segments, built lazily by the first predicate evaluated against it. Testing
+// many geometries against the same one, e.g. thousands of points against a
+// district polygon, is typically 10 to 100 times faster with a prepared
+// geometry than with the plain predicates.
+//
+// Every predicate is evaluated as "prepared <op> other". A PreparedGeometry
+// is safe for concurrent use, but calls are serialized. The source geometry
+// must not be destroyed while it is prepared, and the prepared geometry must
+// be closed, or left to the garbage collector, to release the indexes. Like
+// geometries, it is invalidated by Service.Reset.
+type PreparedGeometry struct {
+	service    *Service
+	mutex      sync.Mutex
+	generation uint64
+	source     *Geometry // referenced by the prepared geometry, kept alive
+	prepared   *C.GEOSPreparedGeometry
+}
+
+// Prepare creates a prepared geometry for repeated predicate and distance
+// tests against the same geometry.
+//
+// Parameters:
+//   - geom: The geometry to prepare; it must not be destroyed while prepared
+//
+// Returns:
+//   - *PreparedGeometry: The prepared geometry
+//   - error: An error if the geometry is invalid or the operation fails
+//
+// Example:
+//
+//	district, err := service.Prepare(polygon)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer district.Close()
+//
+//	for _, point := range points {
+//		if inside, _ := district.Contains(point); inside {
+//			count++
+//		}
+//	}
+func (s *Service) Prepare(geom *Geometry) (*PreparedGeometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	prepared := C.GEOSPrepare_r(c.context, geom.geom)
+	if prepared == nil {
+		return nil, c.geosError("failed to prepare geometry")
+	}
+
+	p := &PreparedGeometry{
+		service:    s,
+		generation: c.generation,
+		source:     geom,
+		prepared:   prepared,
+	}
+	runtime.SetFinalizer(p, (*PreparedGeometry).Close)
+
+	return p, nil
+}
+
+// Geometry returns the geometry that was prepared.
+func (p *PreparedGeometry) Geometry() *Geometry {
+	return p.source
+}
+
+// Intersects checks whether the prepared geometry and another geometry share
+// at least one point.
+//
+// Parameters:
+//   - other: The geometry to test
+//
+// Returns:
+//   - bool: True if the geometries intersect
+//   - error: An error if a geometry is invalid or the operation fails
+func (p *PreparedGeometry) Intersects(other *Geometry) (bool, error) {
+	return p.predicate("intersects", other, func(c *session, g *C.GEOSGeometry) C.char {
+		return C.GEOSPreparedIntersects_r(c.context, p.prepared, g)
+	})
+}
+
+// Contains checks whether another geometry lies in the prepared geometry with
+// at least one point in its interior. Points on the boundary of a polygon are
+// not contained; use Covers to include them.
+//
+// Parameters:
+//   - other: The geometry to test
+//
+// Returns:
+//   - bool: True if the prepared geometry contains the other geometry
+//   - error: An error if a geometry is invalid or the operation fails
+//
+// Example:
+//
+//	inside, err := prepared.Contains(point)
+func (p *PreparedGeometry) Contains(other *Geometry) (bool, error) {
+	return p.predicate("contains", other, func(c *session, g *C.GEOSGeometry) C.char {
+		return C.GEOSPreparedContains_r(c.context, p.prepared, g)
+	})
+}
+
+// Covers checks whether no point of another geometry lies outside the
+// prepared geometry, so points on the boundary count as covered.
+//
+// Parameters:
+//   - other: The geometry to test
+//
+// Returns:
+//   - bool: True if the prepared geometry covers the other geometry
+//   - error: An error if a geometry is invalid or the operation fails
+func (p *PreparedGeometry) Covers(other *Geometry) (bool, error) {
+	return p.predicate("covers", other, func(c *session, g *C.GEOSGeometry) C.char {
+		return C.GEOSPreparedCovers_r(c.context, p.prepared, g)
+	})
+}
+
+// Within checks whether the prepared geometry lies within another geometry,
+// the converse of Contains.
+//
+// Parameters:
+//   - other: The geometry to test against
+//
+// Returns:
+//   - bool: True if the prepared geometry is within the other geometry
+//   - error: An error if a geometry is invalid or the operation fails
+func (p *PreparedGeometry) Within(other *Geometry) (bool, error) {
+	return p.predicate("within", other, func(c *session, g *C.GEOSGeometry) C.char {
+		return C.GEOSPreparedWithin_r(c.context, p.prepared, g)
+	})
+}
+
+// Distance calculates the minimum Cartesian distance between the prepared
+// geometry and another geometry.
+//
+// Parameters:
+//   - other: The geometry to measure to
+//
+// Returns:
+//   - float64: The distance, 0 if the geometries intersect
+//   - error: An error if a geometry is invalid or the operation fails
+func (p *PreparedGeometry) Distance(other *Geometry) (float64, error) {
+	if other == nil || other.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	c, err := p.acquire(other)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+
+	var distance C.double
+	if C.GEOSPreparedDistance_r(c.context, p.prepared, other.geom, &distance) == 0 {
+		return 0, c.geosError("failed to calculate prepared distance")
+	}
+
+	return float64(distance), nil
+}
+
+// predicate evaluates a prepared predicate against another geometry
+func (p *PreparedGeometry) predicate(name string, other *Geometry, eval func(*session, *C.GEOSGeometry) C.char) (bool, error) {
+	if other == nil || other.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	c, err := p.acquire(other)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+
+	result := eval(c, other.geom)
+	if result == 2 { // Error case
+		return false, c.geosError(fmt.Sprintf("GEOS prepared %s operation failed", name))
+	}
+
+	return result == 1, nil
+}
+
+// acquire starts an operation after checking that the prepared geometry and
+// the given geometries can be used. The prepared geometry lock must be held.
+func (p *PreparedGeometry) acquire(geoms ...*Geometry) (*session, error) {
+	if p.prepared == nil {
+		return nil, errors.New("prepared geometry is closed")
+	}
+	c, err := p.service.acquire(append(geoms, p.source)...)
+	if err != nil {
+		return nil, err
+	}
+	if p.generation != c.generation {
+		c.release()
+		return nil, ErrGeometryInvalidated
+	}
+	return c, nil
+}
+
+// Close releases the prepared geometry. It is safe to call multiple times;
+// operations afterwards return an error. The source geometry is not affected.
+func (p *PreparedGeometry) Close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// The prepared geometry of a closed service is left alone, since all of
+	// its contexts have been released
+	if p.prepared != nil {
+		if c, err := p.service.acquire(); err == nil {
+			C.GEOSPreparedGeom_destroy_r(c.context, p.prepared)
+			c.release()
+		}
+	}
+
+	p.prepared = nil
+	p.source = nil
+	runtime.SetFinalizer(p, nil)
+}
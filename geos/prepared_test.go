@@ -0,0 +1,72 @@
+package geos
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPreparedGeometry tests prepared predicates and distance against a polygon
+func TestPreparedGeometry(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	prepared, err := helper.service.Prepare(helper.ParseWKT("POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))"))
+	if err != nil {
+		t.Fatalf("Failed to prepare geometry: %v", err)
+	}
+	defer prepared.Close()
+
+	testCases := []struct {
+		name       string
+		wkt        string
+		intersects bool
+		contains   bool
+		covers     bool
+		within     bool
+		distance   float64
+	}{
+		{"Interior point", "POINT(5 5)", true, true, true, false, 0},
+		{"Boundary point", "POINT(10 5)", true, false, true, false, 0},
+		{"Outside point", "POINT(13 14)", false, false, false, false, 5},
+		{"Larger polygon", "POLYGON((-1 -1, 11 -1, 11 11, -1 11, -1 -1))", true, false, false, true, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			other := helper.ParseWKT(tc.wkt)
+
+			checks := []struct {
+				name     string
+				eval     func(*Geometry) (bool, error)
+				expected bool
+			}{
+				{"Intersects", prepared.Intersects, tc.intersects},
+				{"Contains", prepared.Contains, tc.contains},
+				{"Covers", prepared.Covers, tc.covers},
+				{"Within", prepared.Within, tc.within},
+			}
+			for _, check := range checks {
+				got, err := check.eval(other)
+				if err != nil {
+					t.Fatalf("%s failed: %v", check.name, err)
+				}
+				if got != check.expected {
+					t.Errorf("%s: expected %v, got %v", check.name, check.expected, got)
+				}
+			}
+
+			distance, err := prepared.Distance(other)
+			if err != nil {
+				t.Fatalf("Distance failed: %v", err)
+			}
+			if math.Abs(distance-tc.distance) > 1e-9 {
+				t.Errorf("Expected distance %v, got %v", tc.distance, distance)
+			}
+		})
+	}
+
+	prepared.Close()
+	if _, err := prepared.Contains(helper.PointGeometry()); err == nil {
+		t.Error("Expected an error after Close")
+	}
+}
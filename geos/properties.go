@@ -0,0 +1,353 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+)
+
+// IsEmpty tests whether a geometry has no points. Operations such as
+// Difference, Intersection or a negative Buffer return empty geometries when
+// nothing is left, so pipelines can use IsEmpty to branch on such results.
+//
+// Parameters:
+//   - geom: The geometry to test
+//
+// Returns:
+//   - bool: True if the geometry is empty, false otherwise
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	square := GeometryInput{WKT: "POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))"}
+//	geom, _ := service.ParseGeometry(square)
+//
+//	shrunk, _ := service.Buffer(geom, -1.0)
+//	empty, err := service.IsEmpty(shrunk)
+//	// empty will be true since the square vanishes entirely
+func (s *Service) IsEmpty(geom *Geometry) (bool, error) {
+	if geom == nil || geom.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+
+	result := C.GEOSisEmpty_r(c.context, geom.geom)
+	if result == 2 { // Error case
+		return false, c.geosError("GEOS is empty operation failed")
+	}
+
+	return result == 1, nil
+}
+
+// IsClosed tests whether a line starts and ends at the same point. A
+// MultiLineString is closed when every one of its lines is closed.
+//
+// Parameters:
+//   - geom: The LineString, LinearRing or MultiLineString to test
+//
+// Returns:
+//   - bool: True if the geometry is closed, false otherwise
+//   - error: An error if the geometry is not lineal or the operation fails
+//
+// Example:
+//
+//	loop := GeometryInput{WKT: "LINESTRING(0 0, 1 0, 1 1, 0 0)"}
+//	geom, _ := service.ParseGeometry(loop)
+//
+//	closed, err := service.IsClosed(geom)
+//	// closed will be true
+func (s *Service) IsClosed(geom *Geometry) (bool, error) {
+	if geom == nil || geom.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+	if err := c.checkLineal(geom.geom); err != nil {
+		return false, err
+	}
+
+	result := C.GEOSisClosed_r(c.context, geom.geom)
+	if result == 2 { // Error case
+		return false, c.geosError("GEOS is closed operation failed")
+	}
+
+	return result == 1, nil
+}
+
+// IsRing tests whether a geometry is a LineString that is both closed and
+// simple, i.e. one that could be used as a polygon ring. Other geometry types
+// are never rings.
+//
+// Parameters:
+//   - geom: The geometry to test
+//
+// Returns:
+//   - bool: True if the geometry is a ring, false otherwise
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	loop := GeometryInput{WKT: "LINESTRING(0 0, 2 2, 2 0, 0 2, 0 0)"}
+//	geom, _ := service.ParseGeometry(loop)
+//
+//	ring, err := service.IsRing(geom)
+//	// ring will be false since the closed line crosses itself
+func (s *Service) IsRing(geom *Geometry) (bool, error) {
+	if geom == nil || geom.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+
+	switch C.GEOSGeomTypeId_r(c.context, geom.geom) {
+	case C.GEOS_LINESTRING, C.GEOS_LINEARRING:
+	case -1:
+		return false, c.geosError("failed to get geometry type")
+	default:
+		return false, nil
+	}
+
+	result := C.GEOSisRing_r(c.context, geom.geom)
+	if result == 2 { // Error case
+		return false, c.geosError("GEOS is ring operation failed")
+	}
+
+	return result == 1, nil
+}
+
+// HasZ tests whether a geometry has Z values. Z is kept through parsing of
+// WKT, WKB and GeoJSON, through operations where GEOS supports it and by
+// every writer.
+//
+// Parameters:
+//   - geom: The geometry to test
+//
+// Returns:
+//   - bool: True if the geometry has Z values, false otherwise
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	track := GeometryInput{GeoJSON: map[string]interface{}{
+//		"type":        "LineString",
+//		"coordinates": []interface{}{[]interface{}{0.0, 0.0, 120.0}, []interface{}{1.0, 1.0, 135.0}},
+//	}}
+//	geom, _ := service.ParseGeometry(track)
+//
+//	hasZ, err := service.HasZ(geom)
+//	// hasZ will be true
+func (s *Service) HasZ(geom *Geometry) (bool, error) {
+	return s.hasOrdinate(geom, "Z", func(c *session, g *C.GEOSGeometry) C.char {
+		return C.GEOSHasZ_r(c.context, g)
+	})
+}
+
+// HasM tests whether a geometry has M (measure) values.
+//
+// Parameters:
+//   - geom: The geometry to test
+//
+// Returns:
+//   - bool: True if the geometry has M values, false otherwise
+//   - error: An error if the operation fails
+func (s *Service) HasM(geom *Geometry) (bool, error) {
+	return s.hasOrdinate(geom, "M", func(c *session, g *C.GEOSGeometry) C.char {
+		return C.GEOSHasM_r(c.context, g)
+	})
+}
+
+// hasOrdinate tests whether a geometry has the named ordinate
+func (s *Service) hasOrdinate(geom *Geometry, name string, has func(*session, *C.GEOSGeometry) C.char) (bool, error) {
+	if geom == nil || geom.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+
+	result := has(c, geom.geom)
+	if result == 2 { // Error case
+		return false, c.geosError(fmt.Sprintf("GEOS has %s operation failed", name))
+	}
+
+	return result == 1, nil
+}
+
+// CoordinateDimension returns the number of ordinates per coordinate of a
+// geometry: 2 for XY, 3 for XYZ or XYM and 4 for XYZM.
+//
+// Parameters:
+//   - geom: The geometry to inspect
+//
+// Returns:
+//   - int: The coordinate dimension
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	geom, _ := service.ParseGeometry(GeometryInput{WKT: "POINT ZM (1 2 3 4)"})
+//	dims, err := service.CoordinateDimension(geom)
+//	// dims will be 4
+func (s *Service) CoordinateDimension(geom *Geometry) (int, error) {
+	if geom == nil || geom.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+
+	dims := C.GEOSGeom_getCoordinateDimension_r(c.context, geom.geom)
+	if dims == 0 {
+		return 0, c.geosError("failed to get coordinate dimension")
+	}
+
+	return int(dims), nil
+}
+
+// X returns the X coordinate of a Point, e.g. of an Interpolate result or an
+// accumulated centroid.
+//
+// Parameters:
+//   - point: The non-empty Point to read
+//
+// Returns:
+//   - float64: The X coordinate
+//   - error: An error if the geometry is not a non-empty Point or the operation fails
+//
+// Example:
+//
+//	midpoint, _ := service.Interpolate(route, 0.5)
+//	x, err := service.X(midpoint)
+func (s *Service) X(point *Geometry) (float64, error) {
+	return s.pointOrdinate(point, "X", func(c *session, g *C.GEOSGeometry, v *C.double) C.int {
+		return C.GEOSGeomGetX_r(c.context, g, v)
+	})
+}
+
+// Y returns the Y coordinate of a Point.
+//
+// Parameters:
+//   - point: The non-empty Point to read
+//
+// Returns:
+//   - float64: The Y coordinate
+//   - error: An error if the geometry is not a non-empty Point or the operation fails
+func (s *Service) Y(point *Geometry) (float64, error) {
+	return s.pointOrdinate(point, "Y", func(c *session, g *C.GEOSGeometry, v *C.double) C.int {
+		return C.GEOSGeomGetY_r(c.context, g, v)
+	})
+}
+
+// Z returns the Z coordinate of a Point, or NaN if the point has no Z.
+//
+// Parameters:
+//   - point: The non-empty Point to read
+//
+// Returns:
+//   - float64: The Z coordinate or NaN
+//   - error: An error if the geometry is not a non-empty Point or the operation fails
+//
+// Example:
+//
+//	summit := GeometryInput{WKT: "POINT Z (86.925 27.988 8849)"}
+//	geom, _ := service.ParseGeometry(summit)
+//
+//	elevation, err := service.Z(geom)
+//	// elevation will be 8849
+func (s *Service) Z(point *Geometry) (float64, error) {
+	return s.pointOrdinate(point, "Z", func(c *session, g *C.GEOSGeometry, v *C.double) C.int {
+		return C.GEOSGeomGetZ_r(c.context, g, v)
+	})
+}
+
+// pointOrdinate reads one ordinate of a non-empty Point
+func (s *Service) pointOrdinate(point *Geometry, name string, get func(*session, *C.GEOSGeometry, *C.double) C.int) (float64, error) {
+	if point == nil || point.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+	if !point.IsPoint() {
+		return 0, fmt.Errorf("geometry must be a Point, got %v", point.Type())
+	}
+
+	c, err := s.acquire(point)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+
+	if C.GEOSisEmpty_r(c.context, point.geom) != 0 {
+		return 0, errors.New("point is empty")
+	}
+
+	var value C.double
+	if get(c, point.geom, &value) == 0 {
+		return 0, c.geosError(fmt.Sprintf("failed to read %s coordinate", name))
+	}
+
+	return float64(value), nil
+}
+
+// Bounds returns the bounding box of the geometry as plain numbers, without
+// building an envelope geometry. This makes it cheap to prefilter by bbox,
+// pick tiles or fit a viewport. Like Type, it is a method of the geometry,
+// and it uses the service the geometry was created by.
+//
+// Returns:
+//   - minX, minY, maxX, maxY: The bounding box
+//   - err: An error if the geometry is empty or the operation fails
+//
+// Example:
+//
+//	minX, minY, maxX, maxY, err := parcel.Bounds()
+//	if err == nil && maxX >= viewMinX && minX <= viewMaxX && maxY >= viewMinY && minY <= viewMaxY {
+//		// the parcel may be visible
+//	}
+func (g *Geometry) Bounds() (minX, minY, maxX, maxY float64, err error) {
+	if g == nil || g.geom == nil || g.service == nil {
+		return 0, 0, 0, 0, errors.New("invalid geometry")
+	}
+
+	c, err := g.service.acquire(g)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	defer c.release()
+
+	if C.GEOSisEmpty_r(c.context, g.geom) != 0 {
+		return 0, 0, 0, 0, errors.New("geometry is empty")
+	}
+
+	var values [4]C.double
+	if C.GEOSGeom_getXMin_r(c.context, g.geom, &values[0]) == 0 ||
+		C.GEOSGeom_getYMin_r(c.context, g.geom, &values[1]) == 0 ||
+		C.GEOSGeom_getXMax_r(c.context, g.geom, &values[2]) == 0 ||
+		C.GEOSGeom_getYMax_r(c.context, g.geom, &values[3]) == 0 {
+		return 0, 0, 0, 0, c.geosError("failed to get geometry bounds")
+	}
+
+	return float64(values[0]), float64(values[1]), float64(values[2]), float64(values[3]), nil
+}
@@ -0,0 +1,297 @@
+package geos
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestIsEmpty tests detection of empty geometries
+func TestIsEmpty(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected bool
+	}{
+		{"Point", "POINT(1 1)", false},
+		{"Empty point", "POINT EMPTY", true},
+		{"Empty polygon", "POLYGON EMPTY", true},
+		{"Empty collection", "GEOMETRYCOLLECTION EMPTY", true},
+		{"Polygon", "POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			empty, err := helper.service.IsEmpty(helper.ParseWKT(tc.wkt))
+			if err != nil {
+				t.Fatalf("Failed to test emptiness: %v", err)
+			}
+			if empty != tc.expected {
+				t.Errorf("Expected IsEmpty %v, got %v", tc.expected, empty)
+			}
+		})
+	}
+
+	t.Run("Collapsed buffer", func(t *testing.T) {
+		shrunk, err := helper.service.Buffer(helper.ParseWKT("POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))"), -1)
+		if err != nil {
+			t.Fatalf("Failed to buffer: %v", err)
+		}
+		empty, err := helper.service.IsEmpty(shrunk)
+		if err != nil {
+			t.Fatalf("Failed to test emptiness: %v", err)
+		}
+		if !empty {
+			t.Error("Expected negative buffer of a small square to be empty")
+		}
+	})
+
+	if _, err := helper.service.IsEmpty(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestIsClosed tests detection of closed lines
+func TestIsClosed(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected bool
+	}{
+		{"Open line", "LINESTRING(0 0, 1 0, 1 1)", false},
+		{"Closed line", "LINESTRING(0 0, 1 0, 1 1, 0 0)", true},
+		{"Self-crossing closed line", "LINESTRING(0 0, 2 2, 2 0, 0 2, 0 0)", true},
+		{"Closed multilinestring", "MULTILINESTRING((0 0, 1 0, 1 1, 0 0), (5 5, 6 5, 6 6, 5 5))", true},
+		{"Partly open multilinestring", "MULTILINESTRING((0 0, 1 0, 1 1, 0 0), (5 5, 6 5))", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			closed, err := helper.service.IsClosed(helper.ParseWKT(tc.wkt))
+			if err != nil {
+				t.Fatalf("Failed to test closedness: %v", err)
+			}
+			if closed != tc.expected {
+				t.Errorf("Expected IsClosed %v, got %v", tc.expected, closed)
+			}
+		})
+	}
+
+	if _, err := helper.service.IsClosed(helper.PolygonGeometry()); err == nil {
+		t.Error("Expected error for polygon")
+	}
+	if _, err := helper.service.IsClosed(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestIsRing tests detection of closed simple lines
+func TestIsRing(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected bool
+	}{
+		{"Open line", "LINESTRING(0 0, 1 0, 1 1)", false},
+		{"Closed line", "LINESTRING(0 0, 1 0, 1 1, 0 0)", true},
+		{"Self-crossing closed line", "LINESTRING(0 0, 2 2, 2 0, 0 2, 0 0)", false},
+		{"Polygon", "POLYGON((0 0, 1 0, 1 1, 0 1, 0 0))", false},
+		{"Point", "POINT(1 1)", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ring, err := helper.service.IsRing(helper.ParseWKT(tc.wkt))
+			if err != nil {
+				t.Fatalf("Failed to test ring: %v", err)
+			}
+			if ring != tc.expected {
+				t.Errorf("Expected IsRing %v, got %v", tc.expected, ring)
+			}
+		})
+	}
+
+	if _, err := helper.service.IsRing(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestPointOrdinates tests reading the coordinates of points
+func TestPointOrdinates(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	point := helper.ParseWKT("POINT Z (1.5 -2 30)")
+	for _, c := range []struct {
+		name     string
+		get      func(*Geometry) (float64, error)
+		expected float64
+	}{
+		{"X", helper.service.X, 1.5},
+		{"Y", helper.service.Y, -2},
+		{"Z", helper.service.Z, 30},
+	} {
+		value, err := c.get(point)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", c.name, err)
+		}
+		if value != c.expected {
+			t.Errorf("Expected %s %v, got %v", c.name, c.expected, value)
+		}
+	}
+
+	// Results of operations are points too
+	midpoint, err := helper.service.Interpolate(helper.ParseWKT("LINESTRING(0 0, 10 0)"), 0.5)
+	if err != nil {
+		t.Fatalf("Failed to interpolate: %v", err)
+	}
+	if x, err := helper.service.X(midpoint); err != nil || x != 5 {
+		t.Errorf("Expected midpoint X 5, got %v (%v)", x, err)
+	}
+
+	if z, err := helper.service.Z(helper.PointGeometry()); err != nil || !math.IsNaN(z) {
+		t.Errorf("Expected NaN Z for a 2D point, got %v (%v)", z, err)
+	}
+	if _, err := helper.service.X(helper.ParseWKT("POINT EMPTY")); err == nil {
+		t.Error("Expected error for empty point")
+	}
+	if _, err := helper.service.X(helper.LineGeometry()); err == nil {
+		t.Error("Expected error for non-point")
+	}
+}
+
+// TestBounds tests reading the bounding box of geometries
+func TestBounds(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		wkt      string
+		expected [4]float64
+	}{
+		{"POINT(3 4)", [4]float64{3, 4, 3, 4}},
+		{"LINESTRING(-1 5, 2 -3, 7 0)", [4]float64{-1, -3, 7, 5}},
+		{"POLYGON((0 0, 10 0, 10 20, 0 20, 0 0))", [4]float64{0, 0, 10, 20}},
+		{"GEOMETRYCOLLECTION(POINT(-5 -5), POINT(5 5))", [4]float64{-5, -5, 5, 5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			minX, minY, maxX, maxY, err := helper.ParseWKT(tc.wkt).Bounds()
+			if err != nil {
+				t.Fatalf("Failed to get bounds: %v", err)
+			}
+			if got := [4]float64{minX, minY, maxX, maxY}; got != tc.expected {
+				t.Errorf("Expected bounds %v, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	if _, _, _, _, err := helper.ParseWKT("POLYGON EMPTY").Bounds(); err == nil {
+		t.Error("Expected error for empty geometry")
+	}
+	var nilGeom *Geometry
+	if _, _, _, _, err := nilGeom.Bounds(); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestCoordinateDimension tests Z and M detection and their preservation from input to output
+func TestCoordinateDimension(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		wkt  string
+		hasZ bool
+		hasM bool
+		dims int
+	}{
+		{"POINT(1 2)", false, false, 2},
+		{"LINESTRING Z (0 0 1, 1 1 2)", true, false, 3},
+		{"POINT M (1 2 3)", false, true, 3},
+		{"POINT ZM (1 2 3 4)", true, true, 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.wkt)
+
+			hasZ, err := helper.service.HasZ(geom)
+			if err != nil {
+				t.Fatalf("Failed to test Z: %v", err)
+			}
+			hasM, err := helper.service.HasM(geom)
+			if err != nil {
+				t.Fatalf("Failed to test M: %v", err)
+			}
+			dims, err := helper.service.CoordinateDimension(geom)
+			if err != nil {
+				t.Fatalf("Failed to get coordinate dimension: %v", err)
+			}
+			if hasZ != tc.hasZ || hasM != tc.hasM || dims != tc.dims {
+				t.Errorf("Expected Z %v, M %v, dimension %d, got %v, %v, %d", tc.hasZ, tc.hasM, tc.dims, hasZ, hasM, dims)
+			}
+		})
+	}
+
+	// GeoJSON positions keep their third and fourth values
+	geoJSONCases := []struct {
+		geoJSON  map[string]interface{}
+		expected string
+	}{
+		{
+			map[string]interface{}{"type": "Point", "coordinates": []interface{}{1.5, 2.0, 30.25}},
+			"POINT Z (1.5 2 30.25)",
+		},
+		{
+			map[string]interface{}{"type": "LineString", "coordinates": []interface{}{
+				[]interface{}{0.0, 0.0, 120.0}, []interface{}{1.0, 1.0, 135.0},
+			}},
+			"LINESTRING Z (0 0 120, 1 1 135)",
+		},
+		{
+			map[string]interface{}{"type": "Point", "coordinates": []interface{}{1.0, 2.0, 3.0, 4.0}},
+			"POINT ZM (1 2 3 4)",
+		},
+		{
+			helper.PolygonGeoJSON(),
+			"POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))",
+		},
+	}
+	for _, tc := range geoJSONCases {
+		geom := helper.ParseGeoJSON(tc.geoJSON)
+		wkt, err := helper.service.ToWKTWithOptions(geom, WKTOptions{Trim: true})
+		if err != nil {
+			t.Fatalf("Failed to convert to WKT: %v", err)
+		}
+		if wkt != tc.expected {
+			t.Errorf("Expected %s, got %s", tc.expected, wkt)
+		}
+	}
+
+	// The default writer keeps Z too
+	wkt, err := helper.service.ToWKT(helper.ParseWKT("POINT Z (1 2 3)"))
+	if err != nil {
+		t.Fatalf("Failed to convert to WKT: %v", err)
+	}
+	if !strings.HasPrefix(wkt, "POINT Z") {
+		t.Errorf("Expected a POINT Z, got %s", wkt)
+	}
+
+	mixed := map[string]interface{}{"type": "LineString", "coordinates": []interface{}{
+		[]interface{}{0.0, 0.0, 1.0}, []interface{}{1.0, 1.0},
+	}}
+	if _, err := helper.service.ParseGeometry(GeometryInput{GeoJSON: mixed}); err == nil {
+		t.Error("Expected error for positions of different dimensions")
+	}
+}
@@ -0,0 +1,232 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// SweepSector creates a circular sector (a pie slice) around a point, such as
+// the area covered by a directional antenna or a radar beam. Angles are
+// azimuths in degrees, measured clockwise from north (the +Y axis), and the
+// sector runs clockwise from startAngle to endAngle; an endAngle below
+// startAngle wraps through north. A sweep of 360 degrees or more gives a full
+// disc.
+//
+// Parameters:
+//   - center: The apex Point of the sector
+//   - startAngle: The azimuth where the sector starts, in degrees
+//   - endAngle: The azimuth where the sector ends, in degrees
+//   - radius: The radius of the sector (must be positive)
+//   - steps: The number of segments approximating the arc (at least 1)
+//
+// Returns:
+//   - *Geometry: The sector Polygon
+//   - error: An error if an input is invalid or the operation fails
+//
+// Example:
+//
+//	antenna, _ := service.ParseGeometry(GeometryInput{WKT: "POINT(0 0)"})
+//
+//	// A 60 degree beam facing east
+//	beam, err := service.SweepSector(antenna, 60, 120, 1000, 16)
+func (s *Service) SweepSector(center *Geometry, startAngle, endAngle, radius float64, steps int) (*Geometry, error) {
+	if center == nil || center.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if err := validateSector(startAngle, endAngle, radius, steps); err != nil {
+		return nil, err
+	}
+
+	c, err := s.acquire(center)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	x, y, err := c.pointXY(center.geom)
+	if err != nil {
+		return nil, err
+	}
+
+	sector, err := c.buildShape(sectorShape(x, y, startAngle, endAngle, radius, steps))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newDerivedGeometry("SweepSector", map[string]any{"startAngle": startAngle, "endAngle": endAngle, "radius": radius, "steps": steps}, sector, center), nil
+}
+
+// SweepConfig describes a rotating beam for SweepFootprints. At time step t
+// the beam covers the sector from StartAngle+t*Rotation to
+// StartAngle+t*Rotation+BeamWidth, with angles as for SweepSector.
+type SweepConfig struct {
+	// Radius is the range of the beam
+	Radius float64 `json:"radius"`
+
+	// StartAngle is the azimuth of the leading edge at the first time step, in degrees
+	StartAngle float64 `json:"start_angle"`
+
+	// BeamWidth is the angular width of the beam, in degrees
+	BeamWidth float64 `json:"beam_width"`
+
+	// Rotation is how far the beam turns per time step, in degrees (negative turns counter-clockwise)
+	Rotation float64 `json:"rotation,omitempty"`
+
+	// TimeSteps is the number of time steps to sweep (at least 1)
+	TimeSteps int `json:"time_steps"`
+
+	// Steps is the number of segments approximating the arc of each sector (default 8)
+	Steps int `json:"steps,omitempty"`
+}
+
+// SweepFootprints computes the area covered by rotating beams around a point
+// over time, such as radar or antenna sweeps. For every configuration the
+// sectors of all time steps are unioned into one dissolved footprint, so
+// configurations can be compared or overlaid directly.
+//
+// Parameters:
+//   - center: The Point the beams rotate around
+//   - configs: The beam configurations
+//
+// Returns:
+//   - []*Geometry: One dissolved footprint per configuration, in the same order
+//   - error: An error if a configuration is invalid or the operation fails
+//
+// Example:
+//
+//	footprints, err := service.SweepFootprints(radar, []geos.SweepConfig{
+//		{Radius: 5000, StartAngle: 0, BeamWidth: 10, Rotation: 15, TimeSteps: 12},
+//		{Radius: 8000, StartAngle: 90, BeamWidth: 5, Rotation: 5, TimeSteps: 36},
+//	})
+//	// footprints[0] covers a 175 degree fan clockwise from north
+func (s *Service) SweepFootprints(center *Geometry, configs []SweepConfig) ([]*Geometry, error) {
+	if center == nil || center.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	for i, cfg := range configs {
+		if cfg.TimeSteps < 1 {
+			return nil, fmt.Errorf("config %d: at least 1 time step is required, got %d", i, cfg.TimeSteps)
+		}
+		if math.IsNaN(cfg.Rotation) || math.IsInf(cfg.Rotation, 0) {
+			return nil, fmt.Errorf("config %d: invalid rotation %g", i, cfg.Rotation)
+		}
+		if !(cfg.BeamWidth > 0) {
+			return nil, fmt.Errorf("config %d: beam width must be positive, got %g", i, cfg.BeamWidth)
+		}
+		if err := validateSector(cfg.StartAngle, cfg.StartAngle+cfg.BeamWidth, cfg.Radius, cfg.steps()); err != nil {
+			return nil, fmt.Errorf("config %d: %w", i, err)
+		}
+	}
+
+	c, err := s.acquire(center)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	x, y, err := c.pointXY(center.geom)
+	if err != nil {
+		return nil, err
+	}
+
+	footprints := make([]*Geometry, len(configs))
+	for i, cfg := range configs {
+		sectors := &shape{typeID: C.GEOS_GEOMETRYCOLLECTION, parts: make([]*shape, cfg.TimeSteps)}
+		for t := range sectors.parts {
+			start := cfg.StartAngle + float64(t)*cfg.Rotation
+			sectors.parts[t] = sectorShape(x, y, start, start+cfg.BeamWidth, cfg.Radius, cfg.steps())
+		}
+
+		collection, err := c.buildShape(sectors)
+		if err != nil {
+			c.discard(footprints[:i]...)
+			return nil, err
+		}
+		union := C.GEOSUnaryUnion_r(c.context, collection)
+		C.GEOSGeom_destroy_r(c.context, collection)
+		if union == nil {
+			c.discard(footprints[:i]...)
+			return nil, c.geosError(fmt.Sprintf("config %d: failed to union sectors", i))
+		}
+		footprints[i] = c.newGeometry(union)
+	}
+	c.inheritSRID([]*Geometry{center}, footprints...)
+	c.record("SweepFootprints", map[string]any{"configs": configs}, []*Geometry{center}, footprints...)
+
+	return footprints, nil
+}
+
+// steps returns the number of arc segments per sector
+func (cfg SweepConfig) steps() int {
+	if cfg.Steps == 0 {
+		return 8
+	}
+	return cfg.Steps
+}
+
+// validateSector checks the parameters of a sector
+func validateSector(startAngle, endAngle, radius float64, steps int) error {
+	if math.IsNaN(startAngle) || math.IsInf(startAngle, 0) || math.IsNaN(endAngle) || math.IsInf(endAngle, 0) {
+		return fmt.Errorf("invalid sector angles: %g to %g", startAngle, endAngle)
+	}
+	if startAngle == endAngle {
+		return errors.New("sector start and end angles must differ")
+	}
+	if !(radius > 0) || math.IsInf(radius, 0) {
+		return fmt.Errorf("radius must be positive, got %g", radius)
+	}
+	if steps < 1 {
+		return fmt.Errorf("at least 1 arc step is required, got %d", steps)
+	}
+	return nil
+}
+
+// sectorShape builds the polygon of a sector with azimuth angles in degrees
+func sectorShape(x, y, startAngle, endAngle, radius float64, steps int) *shape {
+	sweep := endAngle - startAngle
+	if sweep < 0 {
+		sweep = math.Mod(sweep, 360) + 360
+	}
+	full := sweep >= 360
+	if full {
+		sweep = 360
+	}
+
+	ring := make([]float64, 0, 2*(steps+3))
+	if !full {
+		ring = append(ring, x, y)
+	}
+	for i := 0; i <= steps; i++ {
+		if full && i == steps {
+			break
+		}
+		angle := (startAngle + sweep*float64(i)/float64(steps)) * math.Pi / 180
+		ring = append(ring, x+radius*math.Sin(angle), y+radius*math.Cos(angle))
+	}
+	ring = append(ring, ring[0], ring[1])
+
+	return &shape{
+		typeID: C.GEOS_POLYGON,
+		parts:  []*shape{{typeID: C.GEOS_LINEARRING, coords: ring}},
+	}
+}
+
+// pointXY reads the coordinates of a non-empty Point.
+func (c *session) pointXY(g *C.GEOSGeometry) (float64, float64, error) {
+	if C.GEOSGeomTypeId_r(c.context, g) != C.GEOS_POINT || C.GEOSisEmpty_r(c.context, g) != 0 {
+		return 0, 0, errors.New("geometry must be a non-empty Point")
+	}
+	var x, y C.double
+	if C.GEOSGeomGetX_r(c.context, g, &x) == 0 || C.GEOSGeomGetY_r(c.context, g, &y) == 0 {
+		return 0, 0, c.geosError("failed to read point coordinates")
+	}
+	return float64(x), float64(y), nil
+}
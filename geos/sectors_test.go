@@ -0,0 +1,118 @@
+package geos
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSweepSector tests sectors between two azimuths
+func TestSweepSector(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	center := helper.ParseWKT("POINT(0 0)")
+
+	testCases := []struct {
+		name       string
+		start, end float64
+		inside     []string
+		outside    []string
+	}{
+		{"Facing east", 60, 120, []string{"POINT(5 0)"}, []string{"POINT(0 5)", "POINT(-5 0)", "POINT(11 0)"}},
+		{"Wrapping through north", 330, 30, []string{"POINT(0 5)"}, []string{"POINT(5 0)", "POINT(0 -5)"}},
+		{"Full disc", 0, 360, []string{"POINT(0 5)", "POINT(-5 0)", "POINT(0 -5)"}, []string{"POINT(8 8)"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sector, err := helper.service.SweepSector(center, tc.start, tc.end, 10, 16)
+			if err != nil {
+				t.Fatalf("Failed to create sector: %v", err)
+			}
+			for _, wkt := range tc.inside {
+				if within, _ := helper.service.Within(helper.ParseWKT(wkt), sector); !within {
+					t.Errorf("Expected %s inside the sector", wkt)
+				}
+			}
+			for _, wkt := range tc.outside {
+				if within, _ := helper.service.Within(helper.ParseWKT(wkt), sector); within {
+					t.Errorf("Expected %s outside the sector", wkt)
+				}
+			}
+		})
+	}
+
+	if _, err := helper.service.SweepSector(center, 10, 10, 10, 16); err == nil {
+		t.Error("Expected error for equal angles")
+	}
+	if _, err := helper.service.SweepSector(center, 0, 90, -1, 16); err == nil {
+		t.Error("Expected error for negative radius")
+	}
+	if _, err := helper.service.SweepSector(center, 0, 90, 10, 0); err == nil {
+		t.Error("Expected error for zero steps")
+	}
+	if _, err := helper.service.SweepSector(helper.LineGeometry(), 0, 90, 10, 16); err == nil {
+		t.Error("Expected error for a non-point center")
+	}
+}
+
+// TestSweepFootprints tests dissolved footprints of rotating beams
+func TestSweepFootprints(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	center := helper.ParseWKT("POINT(0 0)")
+
+	footprints, err := helper.service.SweepFootprints(center, []SweepConfig{
+		{Radius: 10, StartAngle: 0, BeamWidth: 10, Rotation: 10, TimeSteps: 9},
+		{Radius: 5, StartAngle: 180, BeamWidth: 20, Rotation: -10, TimeSteps: 3},
+	})
+	if err != nil {
+		t.Fatalf("Failed to compute footprints: %v", err)
+	}
+	if len(footprints) != 2 {
+		t.Fatalf("Expected 2 footprints, got %d", len(footprints))
+	}
+
+	testCases := []struct {
+		name    string
+		index   int
+		wkt     string
+		covered bool
+	}{
+		{"First quadrant", 0, "POINT(3 3)", true},
+		{"Near the end of the sweep", 0, "POINT(5 0.5)", true},
+		{"Behind the sweep", 0, "POINT(-3 3)", false},
+		{"Initial beam", 1, "POINT(-0.5 -3)", true},
+		{"Counter-clockwise sweep", 1, "POINT(1 -3)", true},
+		{"Outside the sweep", 1, "POINT(-2 -2)", false},
+		{"Beyond the range", 1, "POINT(0 -6)", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			within, err := helper.service.Within(helper.ParseWKT(tc.wkt), footprints[tc.index])
+			if err != nil {
+				t.Fatalf("Failed to test within: %v", err)
+			}
+			if within != tc.covered {
+				t.Errorf("Expected covered %v, got %v", tc.covered, within)
+			}
+		})
+	}
+
+	wkt, err := helper.service.ToWKTWithOptions(footprints[0], WKTOptions{Trim: true})
+	if err != nil {
+		t.Fatalf("Failed to write WKT: %v", err)
+	}
+	if !strings.HasPrefix(wkt, "POLYGON") {
+		t.Errorf("Expected a dissolved polygon, got %s", wkt)
+	}
+
+	if _, err := helper.service.SweepFootprints(center, []SweepConfig{{Radius: 10, BeamWidth: 10}}); err == nil {
+		t.Error("Expected error for zero time steps")
+	}
+	if _, err := helper.service.SweepFootprints(center, []SweepConfig{{Radius: 10, TimeSteps: 1}}); err == nil {
+		t.Error("Expected error for zero beam width")
+	}
+}
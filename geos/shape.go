@@ -0,0 +1,375 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// shape is a Go-side copy of a geometry's structure and coordinates.
+// It lets coordinates be read and rewritten in bulk without a round trip
+// through WKT, and can be kept independently of any GEOS context.
+type shape struct {
+	typeID C.int
+	hasZ   bool
+	hasM   bool
+	coords []float64 // interleaved ordinates for points, linestrings and rings
+	parts  []*shape  // polygon rings (shell first) or collection members
+}
+
+// stride returns the number of ordinates per coordinate
+func (sh *shape) stride() int {
+	n := 2
+	if sh.hasZ {
+		n++
+	}
+	if sh.hasM {
+		n++
+	}
+	return n
+}
+
+// numCoordinates returns the number of coordinates in the shape and its parts
+func (sh *shape) numCoordinates() int {
+	n := len(sh.coords) / sh.stride()
+	for _, part := range sh.parts {
+		n += part.numCoordinates()
+	}
+	return n
+}
+
+// transformXY applies fn to the X and Y ordinate of every coordinate in place
+func (sh *shape) transformXY(fn func(x, y float64) (float64, float64)) {
+	stride := sh.stride()
+	for i := 0; i+1 < len(sh.coords); i += stride {
+		sh.coords[i], sh.coords[i+1] = fn(sh.coords[i], sh.coords[i+1])
+	}
+	for _, part := range sh.parts {
+		part.transformXY(fn)
+	}
+}
+
+// xy returns the X and Y ordinates of every coordinate, in the order visited
+// by transformXY
+func (sh *shape) xy() (x, y []float64) {
+	n := sh.numCoordinates()
+	x, y = make([]float64, 0, n), make([]float64, 0, n)
+	sh.transformXY(func(cx, cy float64) (float64, float64) {
+		x, y = append(x, cx), append(y, cy)
+		return cx, cy
+	})
+	return x, y
+}
+
+// setXY replaces the X and Y ordinates of every coordinate with values in the
+// order returned by xy
+func (sh *shape) setXY(x, y []float64) {
+	i := 0
+	sh.transformXY(func(float64, float64) (float64, float64) {
+		i++
+		return x[i-1], y[i-1]
+	})
+}
+
+// clone returns a deep copy of the shape
+func (sh *shape) clone() *shape {
+	c := &shape{
+		typeID: sh.typeID,
+		hasZ:   sh.hasZ,
+		hasM:   sh.hasM,
+		coords: append([]float64(nil), sh.coords...),
+	}
+	if sh.parts != nil {
+		c.parts = make([]*shape, len(sh.parts))
+		for i, part := range sh.parts {
+			c.parts[i] = part.clone()
+		}
+	}
+	return c
+}
+
+// signedRingArea returns the signed area enclosed by a ring using the shoelace
+// formula. It is positive for counter-clockwise rings when Y points up.
+func (sh *shape) signedRingArea() float64 {
+	stride := sh.stride()
+	n := len(sh.coords) / stride
+	if n < 3 {
+		return 0
+	}
+	sum := 0.0
+	for i := 0; i < n-1; i++ {
+		x0, y0 := sh.coords[i*stride], sh.coords[i*stride+1]
+		x1, y1 := sh.coords[(i+1)*stride], sh.coords[(i+1)*stride+1]
+		sum += x0*y1 - x1*y0
+	}
+	return sum / 2
+}
+
+// readShape copies a GEOS geometry into a shape.
+func (c *session) readShape(g *C.GEOSGeometry) (*shape, error) {
+	typeID := C.GEOSGeomTypeId_r(c.context, g)
+	if typeID < 0 {
+		return nil, c.geosError("failed to get geometry type")
+	}
+
+	sh := &shape{
+		typeID: typeID,
+		hasZ:   C.GEOSHasZ_r(c.context, g) == 1,
+		hasM:   C.GEOSHasM_r(c.context, g) == 1,
+	}
+
+	switch typeID {
+	case C.GEOS_POINT, C.GEOS_LINESTRING, C.GEOS_LINEARRING:
+		if C.GEOSisEmpty_r(c.context, g) == 1 {
+			return sh, nil
+		}
+		seq := C.GEOSGeom_getCoordSeq_r(c.context, g)
+		if seq == nil {
+			return nil, c.geosError("failed to get coordinate sequence")
+		}
+		var size C.uint
+		if C.GEOSCoordSeq_getSize_r(c.context, seq, &size) == 0 {
+			return nil, c.geosError("failed to get coordinate sequence size")
+		}
+		sh.coords = make([]float64, int(size)*sh.stride())
+		if size > 0 {
+			if C.GEOSCoordSeq_copyToBuffer_r(c.context, seq, (*C.double)(unsafe.Pointer(&sh.coords[0])), boolToCInt(sh.hasZ), boolToCInt(sh.hasM)) == 0 {
+				return nil, c.geosError("failed to copy coordinates")
+			}
+		}
+
+	case C.GEOS_POLYGON:
+		if C.GEOSisEmpty_r(c.context, g) == 1 {
+			return sh, nil
+		}
+		holes := int(C.GEOSGetNumInteriorRings_r(c.context, g))
+		if holes < 0 {
+			return nil, c.geosError("failed to get number of interior rings")
+		}
+		shell, err := c.readShape(C.GEOSGetExteriorRing_r(c.context, g))
+		if err != nil {
+			return nil, err
+		}
+		sh.parts = append(sh.parts, shell)
+		for i := 0; i < holes; i++ {
+			hole, err := c.readShape(C.GEOSGetInteriorRingN_r(c.context, g, C.int(i)))
+			if err != nil {
+				return nil, err
+			}
+			sh.parts = append(sh.parts, hole)
+		}
+
+	default:
+		n := int(C.GEOSGetNumGeometries_r(c.context, g))
+		if n < 0 {
+			return nil, c.geosError("failed to get number of geometries")
+		}
+		sh.parts = make([]*shape, 0, n)
+		for i := 0; i < n; i++ {
+			part, err := c.readShape(C.GEOSGetGeometryN_r(c.context, g, C.int(i)))
+			if err != nil {
+				return nil, err
+			}
+			sh.parts = append(sh.parts, part)
+		}
+	}
+
+	return sh, nil
+}
+
+// walk calls fn at the distances start, start+spacing, ... along the
+// coordinates of a line or ring, with the direction of the segment at that
+// point in degrees counter-clockwise from the +X axis. Points on a vertex take
+// the direction of the following segment.
+func (sh *shape) walk(start, spacing float64, fn func(x, y, angle float64) error) error {
+	stride := sh.stride()
+	offset := start // distance from the start of the segment to the next point
+	for i := 0; i+2*stride <= len(sh.coords); i += stride {
+		x0, y0 := sh.coords[i], sh.coords[i+1]
+		x1, y1 := sh.coords[i+stride], sh.coords[i+stride+1]
+		length := math.Hypot(x1-x0, y1-y0)
+		if length == 0 {
+			continue
+		}
+		angle := math.Atan2(y1-y0, x1-x0) * 180 / math.Pi
+
+		for ; offset < length; offset += spacing {
+			f := offset / length
+			if err := fn(x0+f*(x1-x0), y0+f*(y1-y0), angle); err != nil {
+				return err
+			}
+		}
+		offset -= length
+	}
+	return nil
+}
+
+// buildShape creates a new GEOS geometry from a shape. The caller owns the
+// returned geometry.
+func (c *session) buildShape(sh *shape) (*C.GEOSGeometry, error) {
+	switch sh.typeID {
+	case C.GEOS_POINT:
+		if len(sh.coords) == 0 {
+			return checkBuilt(C.GEOSGeom_createEmptyPoint_r(c.context), "point")
+		}
+		seq, err := c.buildSequence(sh)
+		if err != nil {
+			return nil, err
+		}
+		return checkBuilt(C.GEOSGeom_createPoint_r(c.context, seq), "point")
+
+	case C.GEOS_LINESTRING:
+		if len(sh.coords) == 0 {
+			return checkBuilt(C.GEOSGeom_createEmptyLineString_r(c.context), "linestring")
+		}
+		seq, err := c.buildSequence(sh)
+		if err != nil {
+			return nil, err
+		}
+		return checkBuilt(C.GEOSGeom_createLineString_r(c.context, seq), "linestring")
+
+	case C.GEOS_LINEARRING:
+		seq, err := c.buildSequence(sh)
+		if err != nil {
+			return nil, err
+		}
+		return checkBuilt(C.GEOSGeom_createLinearRing_r(c.context, seq), "linear ring")
+
+	case C.GEOS_POLYGON:
+		if len(sh.parts) == 0 {
+			return checkBuilt(C.GEOSGeom_createEmptyPolygon_r(c.context), "polygon")
+		}
+		rings, err := c.buildParts(sh.parts)
+		if err != nil {
+			return nil, err
+		}
+		var holes **C.GEOSGeometry
+		if len(rings) > 1 {
+			holes = &rings[1]
+		}
+		polygon := C.GEOSGeom_createPolygon_r(c.context, rings[0], holes, C.uint(len(rings)-1))
+		if polygon == nil {
+			c.destroyAll(rings)
+			return nil, c.geosError("failed to create polygon")
+		}
+		return polygon, nil
+
+	case C.GEOS_MULTIPOINT, C.GEOS_MULTILINESTRING, C.GEOS_MULTIPOLYGON, C.GEOS_GEOMETRYCOLLECTION:
+		if len(sh.parts) == 0 {
+			return checkBuilt(C.GEOSGeom_createEmptyCollection_r(c.context, sh.typeID), "collection")
+		}
+		parts, err := c.buildParts(sh.parts)
+		if err != nil {
+			return nil, err
+		}
+		collection := C.GEOSGeom_createCollection_r(c.context, sh.typeID, &parts[0], C.uint(len(parts)))
+		if collection == nil {
+			c.destroyAll(parts)
+			return nil, c.geosError("failed to create collection")
+		}
+		return collection, nil
+	}
+
+	return nil, fmt.Errorf("unsupported geometry type id: %d", int(sh.typeID))
+}
+
+// buildParts builds every part of a shape, cleaning up on failure
+func (c *session) buildParts(shapes []*shape) ([]*C.GEOSGeometry, error) {
+	parts := make([]*C.GEOSGeometry, 0, len(shapes))
+	for _, part := range shapes {
+		g, err := c.buildShape(part)
+		if err != nil {
+			c.destroyAll(parts)
+			return nil, err
+		}
+		parts = append(parts, g)
+	}
+	return parts, nil
+}
+
+// buildSequence creates a coordinate sequence from the coordinates of a shape
+func (c *session) buildSequence(sh *shape) (*C.GEOSCoordSequence, error) {
+	n := len(sh.coords) / sh.stride()
+	var buf *C.double
+	if n > 0 {
+		buf = (*C.double)(unsafe.Pointer(&sh.coords[0]))
+	}
+	seq := C.GEOSCoordSeq_copyFromBuffer_r(c.context, buf, C.uint(n), boolToCInt(sh.hasZ), boolToCInt(sh.hasM))
+	if seq == nil {
+		return nil, c.geosError("failed to create coordinate sequence")
+	}
+	return seq, nil
+}
+
+// destroyAll destroys GEOS geometries that were not handed over to a parent
+func (g *geosContext) destroyAll(geoms []*C.GEOSGeometry) {
+	for _, geom := range geoms {
+		C.GEOSGeom_destroy_r(g.context, geom)
+	}
+}
+
+// collectCopies builds a GeometryCollection from copies of geometries, for
+// operations that take their inputs as one collection. The geometries cannot
+// be borrowed as members, since GEOS gives the members of a collection its
+// SRID, which would change the SRID of the inputs. The collection is owned
+// by the caller.
+func (g *geosContext) collectCopies(geoms []*C.GEOSGeometry) (*C.GEOSGeometry, error) {
+	parts := make([]*C.GEOSGeometry, 0, len(geoms))
+	for _, geom := range geoms {
+		copied := C.GEOSGeom_clone_r(g.context, geom)
+		if copied == nil {
+			g.destroyAll(parts)
+			return nil, g.geosError("failed to copy geometry")
+		}
+		parts = append(parts, copied)
+	}
+
+	collection := C.GEOSGeom_createCollection_r(g.context, C.GEOS_GEOMETRYCOLLECTION, &parts[0], C.uint(len(parts)))
+	if collection == nil {
+		g.destroyAll(parts)
+		return nil, g.geosError("failed to collect geometries")
+	}
+	return collection, nil
+}
+
+// checkBuilt turns a nil constructor result into an error
+func checkBuilt(g *C.GEOSGeometry, what string) (*C.GEOSGeometry, error) {
+	if g == nil {
+		return nil, fmt.Errorf("failed to create %s", what)
+	}
+	return g, nil
+}
+
+// envelope is an axis-aligned bounding box
+type envelope struct {
+	minX, minY, maxX, maxY float64
+}
+
+// intersects reports whether two envelopes share at least one point
+func (e envelope) intersects(o envelope) bool {
+	return e.minX <= o.maxX && o.minX <= e.maxX && e.minY <= o.maxY && o.minY <= e.maxY
+}
+
+// expand grows the envelope by d in every direction
+func (e envelope) expand(d float64) envelope {
+	return envelope{e.minX - d, e.minY - d, e.maxX + d, e.maxY + d}
+}
+
+// envelopeOf returns the bounding box of a GEOS geometry; ok is false for
+// empty geometries.
+func (c *session) envelopeOf(g *C.GEOSGeometry) (env envelope, ok bool, err error) {
+	if C.GEOSisEmpty_r(c.context, g) == 1 {
+		return envelope{}, false, nil
+	}
+	var minX, minY, maxX, maxY C.double
+	if C.GEOSGeom_getExtent_r(c.context, g, &minX, &minY, &maxX, &maxY) == 0 {
+		return envelope{}, false, c.geosError("failed to get geometry extent")
+	}
+	return envelope{float64(minX), float64(minY), float64(maxX), float64(maxY)}, true, nil
+}
@@ -0,0 +1,265 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Format identifies a geometry serialization format.
+type Format int
+
+const (
+	// FormatWKT is Well-Known Text
+	FormatWKT Format = iota
+	// FormatWKB is Well-Known Binary
+	FormatWKB
+	// FormatGeoJSON is a GeoJSON geometry object
+	FormatGeoJSON
+)
+
+// String returns the name of the format
+func (f Format) String() string {
+	switch f {
+	case FormatWKT:
+		return "WKT"
+	case FormatWKB:
+		return "WKB"
+	case FormatGeoJSON:
+		return "GeoJSON"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// estimatedOrdinateChars is the average number of characters a full precision
+// ordinate takes in text formats (sign, integer part, '.', ~15 decimals)
+const estimatedOrdinateChars = 18
+
+// EstimateSize returns the approximate number of bytes a geometry occupies when
+// encoded in the given format, without actually serializing it. Only the geometry
+// structure (components, rings and vertex counts) is inspected, which makes this
+// cheap enough to run before deciding whether to simplify or reject a geometry.
+//
+// The WKB estimate is exact. WKT and GeoJSON estimates assume full precision
+// coordinates and may be higher than the real size for short coordinate values.
+//
+// Parameters:
+//   - geom: The geometry to measure
+//   - format: The target serialization format
+//
+// Returns:
+//   - int: The estimated encoded size in bytes
+//   - error: An error if the geometry or format is invalid
+//
+// Example:
+//
+//	size, err := service.EstimateSize(geom, geos.FormatGeoJSON)
+//	if err == nil && size > maxResponseBytes {
+//		geom, err = service.Simplify(geom, tolerance)
+//	}
+func (s *Service) EstimateSize(geom *Geometry, format Format) (int, error) {
+	if geom == nil || geom.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+	if format < FormatWKT || format > FormatGeoJSON {
+		return 0, fmt.Errorf("unsupported format: %v", format)
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+
+	dims := int(C.GEOSGeom_getCoordinateDimension_r(c.context, geom.geom))
+	if dims == 0 {
+		return 0, c.geosError("failed to get coordinate dimension")
+	}
+	if format == FormatGeoJSON && dims > 3 {
+		// GeoJSON has no measure ordinate
+		dims = 3
+	}
+
+	return c.estimateSize(geom.geom, format, dims, true)
+}
+
+// estimateSize recursively estimates the encoded size of a GEOS geometry
+func (c *session) estimateSize(g *C.GEOSGeometry, format Format, dims int, top bool) (int, error) {
+	typeID := C.GEOSGeomTypeId_r(c.context, g)
+	if typeID < 0 {
+		return 0, c.geosError("failed to get geometry type")
+	}
+
+	switch typeID {
+	case C.GEOS_POINT, C.GEOS_LINESTRING, C.GEOS_LINEARRING:
+		n := int(C.GEOSGetNumCoordinates_r(c.context, g))
+		if n < 0 {
+			return 0, c.geosError("failed to get number of coordinates")
+		}
+		if typeID == C.GEOS_POINT {
+			return pointSize(format, dims, n == 0, top), nil
+		}
+		return sequenceSize(format, dims, n, top), nil
+
+	case C.GEOS_POLYGON:
+		if C.GEOSisEmpty_r(c.context, g) == 1 {
+			return containerSize(format, "POLYGON", nil, top), nil
+		}
+		holes := int(C.GEOSGetNumInteriorRings_r(c.context, g))
+		if holes < 0 {
+			return 0, c.geosError("failed to get number of interior rings")
+		}
+		rings := make([]int, 0, holes+1)
+		size, err := c.estimateSize(C.GEOSGetExteriorRing_r(c.context, g), format, dims, false)
+		if err != nil {
+			return 0, err
+		}
+		rings = append(rings, size)
+		for i := 0; i < holes; i++ {
+			size, err := c.estimateSize(C.GEOSGetInteriorRingN_r(c.context, g, C.int(i)), format, dims, false)
+			if err != nil {
+				return 0, err
+			}
+			rings = append(rings, size)
+		}
+		return containerSize(format, "POLYGON", rings, top), nil
+
+	default:
+		n := int(C.GEOSGetNumGeometries_r(c.context, g))
+		if n < 0 {
+			return 0, c.geosError("failed to get number of geometries")
+		}
+		parts := make([]int, 0, n)
+		// WKB writes every component as a complete geometry, while WKT and
+		// GeoJSON only do so inside a GeometryCollection
+		childTop := format == FormatWKB || typeID == C.GEOS_GEOMETRYCOLLECTION
+		for i := 0; i < n; i++ {
+			child := C.GEOSGetGeometryN_r(c.context, g, C.int(i))
+			size, err := c.estimateSize(child, format, dims, childTop)
+			if err != nil {
+				return 0, err
+			}
+			parts = append(parts, size)
+		}
+		return containerSize(format, collectionKeyword(typeID), parts, top), nil
+	}
+}
+
+// pointSize returns the encoded size of a single point
+func pointSize(format Format, dims int, empty, top bool) int {
+	switch format {
+	case FormatWKB:
+		// byte order + type + ordinates (empty points are written as NaN)
+		return 1 + 4 + 8*dims
+	case FormatGeoJSON:
+		size := coordinateTextSize(dims) + 2 // [x,y]
+		if top {
+			size += len(`{"type":"Point","coordinates":}`)
+		}
+		return size
+	default:
+		if empty {
+			if top {
+				return len("POINT EMPTY")
+			}
+			return len("EMPTY")
+		}
+		size := coordinateTextSize(dims) + 2 // (x y)
+		if top {
+			size += len("POINT ") + dimensionTagSize(dims)
+		}
+		return size
+	}
+}
+
+// sequenceSize returns the encoded size of a linestring or ring with n coordinates
+func sequenceSize(format Format, dims, n int, top bool) int {
+	switch format {
+	case FormatWKB:
+		size := 4 + n*8*dims // count + ordinates
+		if top {
+			size += 1 + 4 // byte order + type
+		}
+		return size
+	case FormatGeoJSON:
+		size := 2 + n*(coordinateTextSize(dims)+3) // [[x,y],...]
+		if top {
+			size += len(`{"type":"LineString","coordinates":}`)
+		}
+		return size
+	default:
+		if n == 0 {
+			return len("EMPTY")
+		}
+		size := 2 + n*(coordinateTextSize(dims)+2) // (x y, ...)
+		if top {
+			size += len("LINESTRING ") + dimensionTagSize(dims)
+		}
+		return size
+	}
+}
+
+// containerSize returns the encoded size of a polygon or collection given the
+// sizes of its parts
+func containerSize(format Format, keyword string, parts []int, top bool) int {
+	size := 0
+	for _, part := range parts {
+		size += part
+	}
+
+	switch format {
+	case FormatWKB:
+		size += 4 // part count
+		if top {
+			size += 1 + 4
+		}
+		return size
+	case FormatGeoJSON:
+		size += 2 + len(parts) // brackets and commas
+		if top {
+			size += len(`{"type":"","coordinates":}`) + len(keyword)
+		}
+		return size
+	default:
+		if len(parts) == 0 {
+			size += len("EMPTY")
+		} else {
+			size += 2 + 2*len(parts) // parentheses and ", " separators
+		}
+		if top {
+			size += len(keyword) + 1
+		}
+		return size
+	}
+}
+
+// coordinateTextSize returns the estimated text size of one coordinate
+func coordinateTextSize(dims int) int {
+	return dims*estimatedOrdinateChars + dims - 1
+}
+
+// dimensionTagSize returns the size of the WKT "Z "/"ZM " style dimension tag
+func dimensionTagSize(dims int) int {
+	if dims <= 2 {
+		return 0
+	}
+	return dims - 1
+}
+
+// collectionKeyword returns the WKT keyword of a collection type
+func collectionKeyword(typeID C.int) string {
+	switch typeID {
+	case C.GEOS_MULTIPOINT:
+		return "MULTIPOINT"
+	case C.GEOS_MULTILINESTRING:
+		return "MULTILINESTRING"
+	case C.GEOS_MULTIPOLYGON:
+		return "MULTIPOLYGON"
+	}
+	return "GEOMETRYCOLLECTION"
+}
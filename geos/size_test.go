@@ -0,0 +1,85 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestEstimateSize_WKB tests that WKB size estimates are exact
+func TestEstimateSize_WKB(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected int
+	}{
+		{"Point", "POINT(1 2)", 21},
+		{"Point Z", "POINT Z (1 2 3)", 29},
+		{"LineString", "LINESTRING(0 0, 1 1, 2 2)", 57},
+		{"Polygon", "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))", 93},
+		{"MultiPolygon", "MULTIPOLYGON(((0 0, 1 0, 1 1, 0 1, 0 0)), ((2 2, 3 2, 3 3, 2 3, 2 2)))", 195},
+		{"MultiPoint", "MULTIPOINT((0 0), (1 1))", 51},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.wkt)
+
+			size, err := helper.service.EstimateSize(geom, FormatWKB)
+			if err != nil {
+				t.Fatalf("Failed to estimate size: %v", err)
+			}
+			if size != tc.expected {
+				t.Errorf("Expected WKB size %d, got %d", tc.expected, size)
+			}
+		})
+	}
+}
+
+// TestEstimateSize_Text tests that text size estimates are in the right range
+func TestEstimateSize_Text(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	geom := helper.ParseWKT("POLYGON((0.123456789012345 0.123456789012345, 2.123456789012345 0.123456789012345, 2.123456789012345 2.123456789012345, 0.123456789012345 0.123456789012345))")
+
+	wkt := helper.AssertToWKT(geom)
+	size, err := helper.service.EstimateSize(geom, FormatWKT)
+	if err != nil {
+		t.Fatalf("Failed to estimate size: %v", err)
+	}
+	if size < len(wkt)/2 || size > len(wkt)*2 {
+		t.Errorf("WKT estimate %d too far from actual size %d", size, len(wkt))
+	}
+
+	jsonSize, err := helper.service.EstimateSize(geom, FormatGeoJSON)
+	if err != nil {
+		t.Fatalf("Failed to estimate size: %v", err)
+	}
+	if jsonSize <= 0 {
+		t.Errorf("Expected positive GeoJSON estimate, got %d", jsonSize)
+	}
+
+	large := helper.AssertBuffer(geom, 10)
+	largeSize, err := helper.service.EstimateSize(large, FormatGeoJSON)
+	if err != nil {
+		t.Fatalf("Failed to estimate size: %v", err)
+	}
+	if largeSize <= jsonSize {
+		t.Errorf("Expected buffered geometry estimate %d to exceed %d", largeSize, jsonSize)
+	}
+}
+
+// TestEstimateSize_Invalid tests invalid inputs
+func TestEstimateSize_Invalid(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	if _, err := helper.service.EstimateSize(nil, FormatWKT); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+	if _, err := helper.service.EstimateSize(helper.PointGeometry(), Format(42)); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
@@ -0,0 +1,193 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// SRID returns the spatial reference system identifier of the geometry, or 0
+// if none is set. Geometries parsed with GeometryInput.SRID or from EWKT carry
+// it, and results of operations inherit the SRID of their inputs.
+//
+// Returns:
+//   - int: The SRID, 0 if unset or if the geometry can no longer be used
+//
+// Example:
+//
+//	geom, _ := service.ParseGeometry(GeometryInput{WKT: "POINT(28.97 41.01)", SRID: 4326})
+//	buffered, _ := service.Buffer(geom, 0.01)
+//	srid := buffered.SRID()
+//	// srid will be 4326
+func (g *Geometry) SRID() int {
+	if g == nil || g.geom == nil || g.service == nil {
+		return 0
+	}
+
+	c, err := g.service.acquire(g)
+	if err != nil {
+		return 0
+	}
+	defer c.release()
+
+	return int(C.GEOSGetSRID_r(c.context, g.geom))
+}
+
+// SetSRID sets the spatial reference system identifier of the geometry in
+// place; 0 clears it. The coordinates are not transformed. Like other
+// changes to a geometry, this must not run while the geometry is used by
+// another goroutine.
+//
+// Parameters:
+//   - srid: The SRID to set
+//
+// Returns:
+//   - error: An error if the geometry can no longer be used
+func (g *Geometry) SetSRID(srid int) error {
+	if g == nil || g.geom == nil || g.service == nil {
+		return errors.New("invalid geometry")
+	}
+	if srid < 0 {
+		return fmt.Errorf("invalid SRID: %d", srid)
+	}
+
+	c, err := g.service.acquire(g)
+	if err != nil {
+		return err
+	}
+	defer c.release()
+
+	C.GEOSSetSRID_r(c.context, g.geom, C.int(srid))
+	return nil
+}
+
+// ToEWKT converts a geometry to Extended Well-Known Text, the PostGIS format
+// that prefixes WKT with the SRID, e.g. "SRID=4326;POINT (1 2)". Geometries
+// without an SRID are written as plain WKT. ParseGeometry reads the format back.
+//
+// Parameters:
+//   - geom: The geometry object to convert
+//   - opts: Output options, as for ToWKTWithOptions
+//
+// Returns:
+//   - string: The EWKT representation of the geometry
+//   - error: An error if the options are invalid or conversion fails
+//
+// Example:
+//
+//	geom, _ := service.ParseGeometry(GeometryInput{WKT: "POINT(1 2)", SRID: 4326})
+//
+//	ewkt, err := service.ToEWKT(geom, WKTOptions{Trim: true})
+//	// ewkt will be "SRID=4326;POINT (1 2)"
+func (s *Service) ToEWKT(geom *Geometry, opts WKTOptions) (string, error) {
+	wkt, err := s.ToWKTWithOptions(geom, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if srid := geom.SRID(); srid != 0 {
+		return fmt.Sprintf("SRID=%d;%s", srid, wkt), nil
+	}
+	return wkt, nil
+}
+
+// ToEWKB converts a geometry to little-endian Extended Well-Known Binary, the
+// PostGIS format that embeds the SRID and the Z and M flags in the type code.
+// The SRID is only written when the geometry has one.
+//
+// Parameters:
+//   - geom: The geometry object to convert
+//
+// Returns:
+//   - []byte: The EWKB encoding of the geometry
+//   - error: An error if conversion fails
+//
+// Example:
+//
+//	ewkb, err := service.ToEWKB(geom)
+//	_, err = db.Exec("INSERT INTO parcels (geom) VALUES ($1)", ewkb)
+func (s *Service) ToEWKB(geom *Geometry) ([]byte, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	writer, err := c.wkbWriter(C.GEOS_WKB_EXTENDED, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var size C.size_t
+	wkb := C.GEOSWKBWriter_write_r(c.context, writer, geom.geom, &size)
+	if wkb == nil {
+		return nil, c.geosError("failed to write EWKB geometry")
+	}
+	defer C.GEOSFree_r(c.context, unsafe.Pointer(wkb))
+
+	return C.GoBytes(unsafe.Pointer(wkb), C.int(size)), nil
+}
+
+// newDerivedGeometry wraps and records a result like newRecordedGeometry and
+// gives it the SRID of its inputs. Operations that rewrite coordinates, such
+// as Transform, use newRecordedGeometry instead so the result is not tagged
+// with a reference system it is no longer in.
+func (c *session) newDerivedGeometry(op string, params map[string]any, result *C.GEOSGeometry, inputs ...*Geometry) *Geometry {
+	g := c.newDerivedGeometry(op, params, result, inputs...)
+	c.inheritSRID(inputs, g)
+	return g
+}
+
+// inheritSRID gives outputs without an SRID the SRID of the first input that
+// has one.
+func (c *session) inheritSRID(inputs []*Geometry, outputs ...*Geometry) {
+	srid := C.int(0)
+	for _, input := range inputs {
+		if input != nil && input.geom != nil {
+			if srid = C.GEOSGetSRID_r(c.context, input.geom); srid != 0 {
+				break
+			}
+		}
+	}
+	if srid == 0 {
+		return
+	}
+
+	for _, output := range outputs {
+		if output != nil && output.geom != nil && C.GEOSGetSRID_r(c.context, output.geom) == 0 {
+			C.GEOSSetSRID_r(c.context, output.geom, srid)
+		}
+	}
+}
+
+// splitEWKT separates the "SRID=n;" prefix of EWKT from the WKT. Text
+// without the prefix is returned unchanged with an SRID of 0.
+func splitEWKT(text string) (int, string, error) {
+	trimmed := strings.TrimSpace(text)
+	if len(trimmed) < 5 || !strings.EqualFold(trimmed[:5], "SRID=") {
+		return 0, text, nil
+	}
+
+	value, wkt, ok := strings.Cut(trimmed[5:], ";")
+	if !ok {
+		return 0, "", errors.New("invalid EWKT: missing ';' after SRID")
+	}
+	srid, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || srid < 0 {
+		return 0, "", fmt.Errorf("invalid EWKT SRID: %q", value)
+	}
+
+	return srid, wkt, nil
+}
@@ -0,0 +1,240 @@
+package geos
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/mehmetymw/gogeos/mercator"
+)
+
+// TestSRID tests setting SRIDs on parsed geometries and carrying them through operations
+func TestSRID(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	parse := func(input GeometryInput) *Geometry {
+		t.Helper()
+		geom, err := helper.service.ParseGeometry(input)
+		if err != nil {
+			t.Fatalf("Failed to parse %v: %v", input, err)
+		}
+		return geom
+	}
+
+	square := parse(GeometryInput{WKT: "POLYGON((0 0, 4 0, 4 4, 0 4, 0 0))", SRID: 3857})
+	other := parse(GeometryInput{WKT: "SRID=3857;POLYGON((2 2, 6 2, 6 6, 2 6, 2 2))"})
+	plain := helper.PolygonGeometry()
+
+	if srid := square.SRID(); srid != 3857 {
+		t.Errorf("Expected SRID 3857 from input, got %d", srid)
+	}
+	if srid := other.SRID(); srid != 3857 {
+		t.Errorf("Expected SRID 3857 from EWKT, got %d", srid)
+	}
+	if srid := plain.SRID(); srid != 0 {
+		t.Errorf("Expected no SRID, got %d", srid)
+	}
+
+	buffered, err := helper.service.Buffer(square, 1)
+	if err != nil {
+		t.Fatalf("Failed to buffer: %v", err)
+	}
+	union, err := helper.service.Union([]*Geometry{plain, square, other})
+	if err != nil {
+		t.Fatalf("Failed to union: %v", err)
+	}
+	difference, err := helper.service.Difference(square, other)
+	if err != nil {
+		t.Fatalf("Failed to compute difference: %v", err)
+	}
+	for name, geom := range map[string]*Geometry{"Buffer": buffered, "Union": union, "Difference": difference} {
+		if srid := geom.SRID(); srid != 3857 {
+			t.Errorf("%s: expected SRID 3857, got %d", name, srid)
+		}
+	}
+
+	if err := plain.SetSRID(4326); err != nil {
+		t.Fatalf("Failed to set SRID: %v", err)
+	}
+	if srid := plain.SRID(); srid != 4326 {
+		t.Errorf("Expected SRID 4326 after SetSRID, got %d", srid)
+	}
+	if err := plain.SetSRID(-1); err == nil {
+		t.Error("Expected error for negative SRID")
+	}
+
+	errorCases := []GeometryInput{
+		{WKT: "SRID=4326;POINT(1 1)", SRID: 3857},
+		{WKT: "SRID=abc;POINT(1 1)"},
+		{WKT: "SRID=4326 POINT(1 1)"},
+		{WKT: "POINT(1 1)", SRID: -5},
+	}
+	for _, input := range errorCases {
+		if _, err := helper.service.ParseGeometry(input); err == nil {
+			t.Errorf("Expected error for %+v", input)
+		}
+	}
+}
+
+// TestSRIDCollectedInputs tests that operations taking their inputs as one
+// collection keep the SRIDs of the inputs and pass them on
+func TestSRIDCollectedInputs(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	parse := func(wkt string) *Geometry {
+		t.Helper()
+		geom, err := helper.service.ParseGeometry(GeometryInput{WKT: wkt, SRID: 3857})
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", wkt, err)
+		}
+		return geom
+	}
+
+	left := parse("POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))")
+	right := parse("POLYGON((2 0, 4 0, 4 2, 2 2, 2 0))")
+	edit := parse("POLYGON((0.01 2.01, 3.99 2.01, 3.99 4, 0.01 4, 0.01 2.01))")
+	inputs := []*Geometry{left, right, edit}
+
+	check := func(name string, results ...*Geometry) {
+		t.Helper()
+		for i, geom := range inputs {
+			if srid := geom.SRID(); srid != 3857 {
+				t.Errorf("%s: expected input %d to keep SRID 3857, got %d", name, i, srid)
+			}
+		}
+		for i, geom := range results {
+			if srid := geom.SRID(); srid != 3857 {
+				t.Errorf("%s: expected SRID 3857 on result %d, got %d", name, i, srid)
+			}
+		}
+	}
+
+	union, err := helper.service.UnionPrec([]*Geometry{left, right}, 0.001)
+	if err != nil {
+		t.Fatalf("UnionPrec failed: %v", err)
+	}
+	check("UnionPrec", union)
+
+	snapped, err := helper.service.SnapLayer([]*Geometry{edit}, []*Geometry{left, right}, 0.1)
+	if err != nil {
+		t.Fatalf("SnapLayer failed: %v", err)
+	}
+	check("SnapLayer", snapped...)
+
+	report, err := helper.service.CoverageIsValid([]*Geometry{left, right}, 0)
+	if err != nil {
+		t.Fatalf("CoverageIsValid failed: %v", err)
+	}
+	check("CoverageIsValid", report.InvalidEdges...)
+
+	simplified, err := helper.service.CoverageSimplify([]*Geometry{left, right}, 0.5, false)
+	if err != nil {
+		t.Fatalf("CoverageSimplify failed: %v", err)
+	}
+	check("CoverageSimplify", simplified...)
+}
+
+// TestSRIDRewrittenCoordinates tests that operations rewriting coordinates do
+// not tag their results with the SRID of the input
+func TestSRIDRewrittenCoordinates(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	lonLat, err := helper.service.ParseGeometry(GeometryInput{WKT: "POINT(180 0)", SRID: 4326})
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	projected, err := helper.service.Transform(lonLat, mercator.ToMeters)
+	if err != nil {
+		t.Fatalf("Failed to transform: %v", err)
+	}
+	batch, err := helper.service.TransformAll([]*Geometry{lonLat}, mercator.ToMeters)
+	if err != nil {
+		t.Fatalf("Failed to transform batch: %v", err)
+	}
+	moved, err := helper.service.Affine(lonLat, TranslateTransform(1, 1))
+	if err != nil {
+		t.Fatalf("Failed to apply affine transformation: %v", err)
+	}
+	for name, geom := range map[string]*Geometry{"Transform": projected, "TransformAll": batch[0], "Affine": moved} {
+		if srid := geom.SRID(); srid != 0 {
+			t.Errorf("%s: expected no SRID, got %d", name, srid)
+		}
+	}
+	if srid := lonLat.SRID(); srid != 4326 {
+		t.Errorf("Expected the input to keep SRID 4326, got %d", srid)
+	}
+}
+
+// TestEWKT tests writing EWKT and reading it back
+func TestEWKT(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		input    GeometryInput
+		expected string
+	}{
+		{GeometryInput{WKT: "POINT(1 2)", SRID: 4326}, "SRID=4326;POINT (1 2)"},
+		{GeometryInput{WKT: "srid=2154; LINESTRING Z (0 0 1, 1 1 2)"}, "SRID=2154;LINESTRING Z (0 0 1, 1 1 2)"},
+		{GeometryInput{WKT: "POINT(1 2)"}, "POINT (1 2)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			geom, err := helper.service.ParseGeometry(tc.input)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			ewkt, err := helper.service.ToEWKT(geom, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to write EWKT: %v", err)
+			}
+			if ewkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, ewkt)
+			}
+
+			roundTrip, err := helper.service.ParseGeometry(GeometryInput{WKT: ewkt})
+			if err != nil {
+				t.Fatalf("Failed to parse EWKT: %v", err)
+			}
+			if roundTrip.SRID() != geom.SRID() {
+				t.Errorf("Expected SRID %d after round trip, got %d", geom.SRID(), roundTrip.SRID())
+			}
+		})
+	}
+}
+
+// TestEWKB tests that EWKB output embeds the SRID
+func TestEWKB(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	geom, err := helper.service.ParseGeometry(GeometryInput{WKT: "POINT(1 2)", SRID: 4326})
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	ewkb, err := helper.service.ToEWKB(geom)
+	if err != nil {
+		t.Fatalf("Failed to write EWKB: %v", err)
+	}
+
+	// byte order, point type with the SRID flag, SRID, X, Y
+	expected := []byte{1, 1, 0, 0, 0x20, 0xE6, 0x10, 0, 0}
+	expected = binary.LittleEndian.AppendUint64(expected, 0x3FF0000000000000)
+	expected = binary.LittleEndian.AppendUint64(expected, 0x4000000000000000)
+	if !bytes.Equal(ewkb, expected) {
+		t.Errorf("Expected EWKB %x, got %x", expected, ewkb)
+	}
+
+	plain, err := helper.service.ToEWKB(helper.PointGeometry())
+	if err != nil {
+		t.Fatalf("Failed to write EWKB: %v", err)
+	}
+	if len(plain) != 21 {
+		t.Errorf("Expected 21 bytes without an SRID, got %d", len(plain))
+	}
+}
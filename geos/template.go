@@ -0,0 +1,149 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// TemplatePool holds named template geometries that can be instantiated many
+// times under different affine transformations. Instantiating a template only
+// rewrites a copy of its coordinates, which is far cheaper than recomputing the
+// shape (e.g. buffering a point for every marker symbol).
+//
+// A TemplatePool is safe for concurrent use.
+//
+// Example:
+//
+//	pool := service.NewTemplatePool()
+//	if err := pool.RegisterCircle("marker", 32); err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	for _, site := range sites {
+//		marker, err := pool.Instantiate("marker",
+//			geos.ScaleTransform(5, 5).Then(geos.TranslateTransform(site.X, site.Y)))
+//		// ...
+//	}
+type TemplatePool struct {
+	service   *Service
+	mutex     sync.RWMutex
+	templates map[string]*shape
+}
+
+// NewTemplatePool creates an empty template pool bound to the service.
+func (s *Service) NewTemplatePool() *TemplatePool {
+	return &TemplatePool{
+		service:   s,
+		templates: make(map[string]*shape),
+	}
+}
+
+// Register stores a copy of a geometry as a named template, replacing any
+// template with the same name. The geometry can be discarded afterwards.
+func (p *TemplatePool) Register(name string, geom *Geometry) error {
+	if name == "" {
+		return errors.New("template name is required")
+	}
+	if geom == nil || geom.geom == nil {
+		return errors.New("invalid geometry")
+	}
+
+	c, err := p.service.acquire(geom)
+	if err != nil {
+		return err
+	}
+	sh, err := c.readShape(geom.geom)
+	c.release()
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	p.templates[name] = sh
+	p.mutex.Unlock()
+
+	return nil
+}
+
+// RegisterCircle stores a unit circle polygon centered on the origin with the
+// given number of segments as a named template.
+func (p *TemplatePool) RegisterCircle(name string, segments int) error {
+	if name == "" {
+		return errors.New("template name is required")
+	}
+	if segments < 3 {
+		return fmt.Errorf("a circle needs at least 3 segments, got %d", segments)
+	}
+
+	ring := make([]float64, 0, 2*(segments+1))
+	for i := 0; i < segments; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(segments)
+		ring = append(ring, math.Cos(angle), math.Sin(angle))
+	}
+	ring = append(ring, ring[0], ring[1])
+
+	p.mutex.Lock()
+	p.templates[name] = &shape{
+		typeID: C.GEOS_POLYGON,
+		parts:  []*shape{{typeID: C.GEOS_LINEARRING, coords: ring}},
+	}
+	p.mutex.Unlock()
+
+	return nil
+}
+
+// Unregister removes a named template. Removing an unknown name is a no-op.
+func (p *TemplatePool) Unregister(name string) {
+	p.mutex.Lock()
+	delete(p.templates, name)
+	p.mutex.Unlock()
+}
+
+// Names returns the names of all registered templates in sorted order.
+func (p *TemplatePool) Names() []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	names := make([]string, 0, len(p.templates))
+	for name := range p.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Instantiate creates a new geometry from a named template with the given
+// transformation applied to its coordinates.
+func (p *TemplatePool) Instantiate(name string, t AffineTransform) (*Geometry, error) {
+	p.mutex.RLock()
+	template, ok := p.templates[name]
+	p.mutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown template: %s", name)
+	}
+
+	sh := template.clone()
+	sh.transformXY(t.Apply)
+
+	c, err := p.service.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	geom, err := c.buildShape(sh)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newRecordedGeometry("Instantiate", map[string]any{"name": name, "t": t}, geom), nil
+}
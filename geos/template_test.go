@@ -0,0 +1,69 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestTemplatePool tests registering and instantiating templates
+func TestTemplatePool(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	pool := helper.service.NewTemplatePool()
+
+	if err := pool.RegisterCircle("circle", 32); err != nil {
+		t.Fatalf("Failed to register circle: %v", err)
+	}
+	if err := pool.Register("square", helper.ParseWKT("POLYGON((-1 -1, 1 -1, 1 1, -1 1, -1 -1))")); err != nil {
+		t.Fatalf("Failed to register square: %v", err)
+	}
+
+	names := pool.Names()
+	if len(names) != 2 || names[0] != "circle" || names[1] != "square" {
+		t.Errorf("Unexpected template names: %v", names)
+	}
+
+	marker, err := pool.Instantiate("circle", ScaleTransform(5, 5).Then(TranslateTransform(100, 100)))
+	if err != nil {
+		t.Fatalf("Failed to instantiate circle: %v", err)
+	}
+
+	helper.AssertWithin(helper.ParseWKT("POINT(103 100)"), marker, true)
+	helper.AssertWithin(helper.ParseWKT("POINT(106 100)"), marker, false)
+
+	square, err := pool.Instantiate("square", TranslateTransform(10, 0))
+	if err != nil {
+		t.Fatalf("Failed to instantiate square: %v", err)
+	}
+	helper.AssertWithin(helper.ParseWKT("POINT(10.5 0.5)"), square, true)
+
+	// Instances are independent copies of the template
+	again, err := pool.Instantiate("square", IdentityTransform())
+	if err != nil {
+		t.Fatalf("Failed to instantiate square: %v", err)
+	}
+	helper.AssertWithin(helper.ParseWKT("POINT(0.5 0.5)"), again, true)
+
+	pool.Unregister("square")
+	if _, err := pool.Instantiate("square", IdentityTransform()); err == nil {
+		t.Error("Expected error for unregistered template")
+	}
+}
+
+// TestTemplatePool_Invalid tests invalid registrations
+func TestTemplatePool_Invalid(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	pool := helper.service.NewTemplatePool()
+
+	if err := pool.RegisterCircle("circle", 2); err == nil {
+		t.Error("Expected error for too few segments")
+	}
+	if err := pool.Register("", helper.PointGeometry()); err == nil {
+		t.Error("Expected error for empty name")
+	}
+	if err := pool.Register("nil", nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,216 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mehmetymw/gogeos/mercator"
+)
+
+// TilePolygon returns the extent of an XYZ tile as a WGS84 rectangle, so a
+// tile can be used as a spatial filter, e.g. with Intersects or Within.
+//
+// Parameters:
+//   - z, x, y: The tile address
+//
+// Returns:
+//   - *Geometry: A rectangular Polygon in longitude/latitude
+//   - error: An error if the tile address is invalid
+//
+// Example:
+//
+//	tile, err := service.TilePolygon(1, 1, 0)
+//	// tile will be POLYGON ((0 0, 180 0, 180 85.0511287798066, 0 85.0511287798066, 0 0))
+func (s *Service) TilePolygon(z, x, y int) (*Geometry, error) {
+	if !mercator.ValidTile(z, x, y) {
+		return nil, fmt.Errorf("invalid tile %d/%d/%d", z, x, y)
+	}
+	minLon, minLat, maxLon, maxLat := mercator.TileBounds(z, x, y)
+	return s.rectangle("TilePolygon", map[string]any{"z": z, "x": x, "y": y}, minLon, minLat, maxLon, maxLat)
+}
+
+// TilePolygonMeters returns the extent of an XYZ tile as a Web Mercator
+// (EPSG:3857) rectangle in meters.
+//
+// Parameters:
+//   - z, x, y: The tile address
+//
+// Returns:
+//   - *Geometry: A rectangular Polygon in Web Mercator meters
+//   - error: An error if the tile address is invalid
+//
+// Example:
+//
+//	tile, err := service.TilePolygonMeters(0, 0, 0)
+//	// tile will cover the whole projected world, ±20037508.34 m in X and Y
+func (s *Service) TilePolygonMeters(z, x, y int) (*Geometry, error) {
+	if !mercator.ValidTile(z, x, y) {
+		return nil, fmt.Errorf("invalid tile %d/%d/%d", z, x, y)
+	}
+	minX, minY, maxX, maxY := mercator.TileBoundsMeters(z, x, y)
+	return s.rectangle("TilePolygonMeters", map[string]any{"z": z, "x": x, "y": y}, minX, minY, maxX, maxY)
+}
+
+// QuadkeyPolygon returns the extent of the tile addressed by a Bing Maps
+// quadkey as a WGS84 rectangle.
+//
+// Parameters:
+//   - quadkey: The quadkey, one digit from 0 to 3 per zoom level
+//
+// Returns:
+//   - *Geometry: A rectangular Polygon in longitude/latitude
+//   - error: An error if the quadkey is malformed
+//
+// Example:
+//
+//	tile, err := service.QuadkeyPolygon("120")
+func (s *Service) QuadkeyPolygon(quadkey string) (*Geometry, error) {
+	z, x, y, err := mercator.QuadkeyToTile(quadkey)
+	if err != nil {
+		return nil, err
+	}
+	return s.TilePolygon(z, x, y)
+}
+
+// TilesCovering returns the XYZ tiles at a zoom level that intersect a WGS84
+// geometry, row by row from the north-west corner. Unlike the tiles of its
+// bounding box, tiles that only overlap the bounding box but not the geometry
+// itself are left out, which matters for diagonal lines and sparse
+// collections when seeding a tile cache or invalidating tiles after an edit.
+// Parts beyond the Web Mercator latitude limit of about ±85.05° lie in no tile.
+//
+// Parameters:
+//   - geom: The geometry to cover, in longitude/latitude
+//   - zoom: The zoom level, from 0 to 30
+//
+// Returns:
+//   - []mercator.Tile: The intersecting tiles, empty for an empty geometry
+//   - error: An error if the zoom level is invalid, the bounding box of the
+//     geometry spans more than mercator.MaxTiles tiles or the operation fails
+//
+// Example:
+//
+//	route := GeometryInput{WKT: "LINESTRING(28.97 41.00, 29.05 41.08)"}
+//	geom, _ := service.ParseGeometry(route)
+//
+//	tiles, err := service.TilesCovering(geom, 14)
+//	for _, tile := range tiles {
+//		cache.Invalidate(tile.Z, tile.X, tile.Y)
+//	}
+func (s *Service) TilesCovering(geom *Geometry, zoom int) ([]mercator.Tile, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	if zoom < 0 || zoom > 30 {
+		return nil, fmt.Errorf("invalid zoom level %d", zoom)
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	env, ok, err := c.envelopeOf(geom.geom)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return []mercator.Tile{}, nil
+	}
+
+	prepared := C.GEOSPrepare_r(c.context, geom.geom)
+	if prepared == nil {
+		return nil, c.geosError("failed to prepare geometry")
+	}
+	defer C.GEOSPreparedGeom_destroy_r(c.context, prepared)
+
+	candidates, err := mercator.TilesInBounds(env.minX, env.minY, env.maxX, env.maxY, zoom)
+	if err != nil {
+		return nil, err
+	}
+	tiles := make([]mercator.Tile, 0, len(candidates))
+	for _, tile := range candidates {
+		minLon, minLat, maxLon, maxLat := mercator.TileBounds(tile.Z, tile.X, tile.Y)
+		rect := C.GEOSGeom_createRectangle_r(c.context, C.double(minLon), C.double(minLat), C.double(maxLon), C.double(maxLat))
+		if rect == nil {
+			return nil, c.geosError("failed to create tile rectangle")
+		}
+		result := C.GEOSPreparedIntersects_r(c.context, prepared, rect)
+		C.GEOSGeom_destroy_r(c.context, rect)
+		if result == 2 { // Error case
+			return nil, c.geosError("GEOS prepared intersects operation failed")
+		}
+		if result == 1 {
+			tiles = append(tiles, tile)
+		}
+	}
+
+	return tiles, nil
+}
+
+// ClipByRect clips a geometry to an axis-aligned rectangle. It is much faster
+// than intersecting with a rectangle polygon because it works on the
+// coordinates directly, which makes it the primitive for cutting features
+// into tiles. Unlike an intersection, the result is not guaranteed to be
+// valid: polygons that touch the rectangle edge from outside may leave
+// degenerate pieces on the boundary. Run MakeValid if the result is fed
+// into further overlay operations.
+//
+// Parameters:
+//   - geom: The geometry to clip
+//   - minX, minY, maxX, maxY: The clipping rectangle
+//
+// Returns:
+//   - *Geometry: The part of the geometry inside the rectangle, possibly empty
+//   - error: An error if the rectangle is malformed or the operation fails
+//
+// Example:
+//
+//	minX, minY, maxX, maxY := mercator.TileBoundsMeters(z, x, y)
+//	clipped, err := service.ClipByRect(roads, minX, minY, maxX, maxY)
+func (s *Service) ClipByRect(geom *Geometry, minX, minY, maxX, maxY float64) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if !(minX <= maxX && minY <= maxY) {
+		return nil, fmt.Errorf("invalid clipping rectangle: (%g %g, %g %g)", minX, minY, maxX, maxY)
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	clipped := C.GEOSClipByRect_r(c.context, geom.geom, C.double(minX), C.double(minY), C.double(maxX), C.double(maxY))
+	if clipped == nil {
+		return nil, c.geosError("failed to clip geometry")
+	}
+
+	return c.newDerivedGeometry("ClipByRect", map[string]any{"minX": minX, "minY": minY, "maxX": maxX, "maxY": maxY}, clipped, geom), nil
+}
+
+// rectangle creates an axis-aligned rectangular polygon, recorded under the
+// name and parameters of the calling method
+func (s *Service) rectangle(method string, params map[string]any, minX, minY, maxX, maxY float64) (*Geometry, error) {
+	c, err := s.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	rect := C.GEOSGeom_createRectangle_r(c.context, C.double(minX), C.double(minY), C.double(maxX), C.double(maxY))
+	if rect == nil {
+		return nil, c.geosError("failed to create rectangle")
+	}
+
+	return c.newRecordedGeometry(method, params, rect), nil
+}
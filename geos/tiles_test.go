@@ -0,0 +1,135 @@
+package geos
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mehmetymw/gogeos/mercator"
+)
+
+// TestTilePolygon tests tile extents as geometries
+func TestTilePolygon(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	tile, err := helper.service.TilePolygon(1, 1, 0)
+	if err != nil {
+		t.Fatalf("Failed to create tile polygon: %v", err)
+	}
+	helper.AssertIntersects(tile, helper.ParseWKT("POINT(28.9784 41.0082)"), true)
+	helper.AssertIntersects(tile, helper.ParseWKT("POINT(-74.006 40.7128)"), false)
+
+	world, err := helper.service.TilePolygonMeters(0, 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to create tile polygon: %v", err)
+	}
+	helper.AssertWithin(helper.ParseWKT("POINT(20037508 -20037508)"), world, true)
+
+	quad, err := helper.service.QuadkeyPolygon("1")
+	if err != nil {
+		t.Fatalf("Failed to create quadkey polygon: %v", err)
+	}
+	if a, b := helper.AssertToWKT(quad), helper.AssertToWKT(tile); a != b {
+		t.Errorf("Expected quadkey 1 to match tile 1/1/0, got %s and %s", a, b)
+	}
+
+	if _, err := helper.service.TilePolygon(2, 4, 0); err == nil {
+		t.Error("Expected error for invalid tile")
+	}
+	if _, err := helper.service.QuadkeyPolygon("9"); err == nil {
+		t.Error("Expected error for invalid quadkey")
+	}
+}
+
+// TestTilesCovering tests enumerating the tiles that intersect a geometry
+func TestTilesCovering(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		zoom     int
+		expected []mercator.Tile
+	}{
+		{"Point", "POINT(28.9784 41.0082)", 14, []mercator.Tile{{Z: 14, X: 9510, Y: 6142}}},
+		{"Diagonal line", "LINESTRING(10 10, 170 80)", 2, []mercator.Tile{{Z: 2, X: 3, Y: 0}, {Z: 2, X: 2, Y: 1}, {Z: 2, X: 3, Y: 1}}},
+		{"Sparse points", "MULTIPOINT((-170 80), (170 -80))", 1, []mercator.Tile{{Z: 1, X: 0, Y: 0}, {Z: 1, X: 1, Y: 1}}},
+		{"Empty", "POLYGON EMPTY", 5, []mercator.Tile{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tiles, err := helper.service.TilesCovering(helper.ParseWKT(tc.wkt), tc.zoom)
+			if err != nil {
+				t.Fatalf("Failed to cover geometry: %v", err)
+			}
+			if !reflect.DeepEqual(tiles, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, tiles)
+			}
+		})
+	}
+
+	if _, err := helper.service.TilesCovering(helper.PointGeometry(), 31); err == nil {
+		t.Error("Expected error for invalid zoom level")
+	}
+}
+
+// TestClipByRect tests fast rectangular clipping
+func TestClipByRect(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected string
+	}{
+		{"Polygon", "POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))", "POLYGON((5 5, 10 5, 10 10, 5 10, 5 5))"},
+		{"Line", "LINESTRING(0 0, 20 20)", "LINESTRING(5 5, 15 15)"},
+		{"Inside", "POINT(8 8)", "POINT(8 8)"},
+		{"Outside", "POINT(20 20)", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clipped, err := helper.service.ClipByRect(helper.ParseWKT(tc.wkt), 5, 5, 15, 15)
+			if err != nil {
+				t.Fatalf("Failed to clip: %v", err)
+			}
+
+			if tc.expected == "" {
+				empty, err := helper.service.IsEmpty(clipped)
+				if err != nil {
+					t.Fatalf("Failed to test emptiness: %v", err)
+				}
+				if !empty {
+					t.Error("Expected an empty result")
+				}
+				return
+			}
+
+			// Compare normalized forms, since clipping may change the ring start
+			got, err := helper.service.Normalize(clipped)
+			if err != nil {
+				t.Fatalf("Failed to normalize: %v", err)
+			}
+			want, err := helper.service.Normalize(helper.ParseWKT(tc.expected))
+			if err != nil {
+				t.Fatalf("Failed to normalize: %v", err)
+			}
+			gotWKT, _ := helper.service.ToWKTWithOptions(got, WKTOptions{Trim: true})
+			wantWKT, _ := helper.service.ToWKTWithOptions(want, WKTOptions{Trim: true})
+			if gotWKT != wantWKT {
+				t.Errorf("Expected %s, got %s", wantWKT, gotWKT)
+			}
+		})
+	}
+
+	if _, err := helper.service.ClipByRect(helper.PolygonGeometry(), 5, 5, 0, 0); err == nil {
+		t.Error("Expected error for inverted rectangle")
+	}
+	if _, err := helper.service.ClipByRect(nil, 0, 0, 1, 1); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,123 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+)
+
+// PolygonizeResult holds the polygons formed by Polygonize along with the
+// linework that could not be used. Every field is a GeometryCollection,
+// which is empty when there is nothing to report.
+type PolygonizeResult struct {
+	// Polygons are the polygons formed from the linework
+	Polygons *Geometry
+
+	// Dangles are edges with an end point not connected to any other edge
+	Dangles *Geometry
+
+	// CutEdges are edges connected at both ends but not part of any polygon,
+	// such as a line bridging two separate rings
+	CutEdges *Geometry
+
+	// InvalidRings are closed rings that would form invalid polygons
+	InvalidRings *Geometry
+}
+
+// Polygonize forms polygons from linework, e.g. building parcels or areas
+// from their boundary lines. The lines must be correctly noded, meaning they
+// only touch at their end points; use Union or Node beforehand otherwise.
+// Lines that do not end up as polygon edges are reported separately, which
+// pinpoints gaps and overshoots in the source data.
+//
+// Parameters:
+//   - geom: The linework, typically a MultiLineString
+//
+// Returns:
+//   - *PolygonizeResult: The polygons, dangles, cut edges and invalid rings
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	boundaries := GeometryInput{WKT: "MULTILINESTRING((0 0, 10 0, 10 10), (10 10, 0 10, 0 0), (10 10, 12 12))"}
+//	geom, _ := service.ParseGeometry(boundaries)
+//
+//	result, err := service.Polygonize(geom)
+//	// result.Polygons will contain POLYGON ((0 0, 0 10, 10 10, 10 0, 0 0))
+//	// result.Dangles will contain the overshoot LINESTRING (10 10, 12 12)
+func (s *Service) Polygonize(geom *Geometry) (*PolygonizeResult, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	var cuts, dangles, invalidRings *C.GEOSGeometry
+	polygons := C.GEOSPolygonize_full_r(c.context, geom.geom, &cuts, &dangles, &invalidRings)
+	if polygons == nil {
+		for _, g := range []*C.GEOSGeometry{cuts, dangles, invalidRings} {
+			if g != nil {
+				C.GEOSGeom_destroy_r(c.context, g)
+			}
+		}
+		return nil, c.geosError("failed to polygonize linework")
+	}
+
+	result := &PolygonizeResult{
+		Polygons:     c.newGeometry(polygons),
+		Dangles:      c.newGeometry(dangles),
+		CutEdges:     c.newGeometry(cuts),
+		InvalidRings: c.newGeometry(invalidRings),
+	}
+	outputs := []*Geometry{result.Polygons, result.Dangles, result.CutEdges, result.InvalidRings}
+	c.inheritSRID([]*Geometry{geom}, outputs...)
+	c.record("Polygonize", nil, []*Geometry{geom}, outputs...)
+
+	return result, nil
+}
+
+// Node fully nodes a set of lines: a vertex is inserted wherever two lines
+// cross or touch, and the lines are split there so that they only meet at
+// their end points. This is a prerequisite for Polygonize and for building
+// clean networks from digitized linework.
+//
+// Parameters:
+//   - geom: The linework to node, typically a MultiLineString
+//
+// Returns:
+//   - *Geometry: A MultiLineString of the noded lines
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	cross := GeometryInput{WKT: "MULTILINESTRING((0 0, 2 2), (0 2, 2 0))"}
+//	geom, _ := service.ParseGeometry(cross)
+//
+//	noded, err := service.Node(geom)
+//	// noded will be split into four lines meeting at (1 1)
+func (s *Service) Node(geom *Geometry) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	noded := C.GEOSNode_r(c.context, geom.geom)
+	if noded == nil {
+		return nil, c.geosError("failed to node linework")
+	}
+
+	return c.newDerivedGeometry("Node", nil, noded, geom), nil
+}
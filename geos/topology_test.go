@@ -0,0 +1,91 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestPolygonize tests forming polygons from linework and reporting leftovers
+func TestPolygonize(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	// Two squares joined by a bridge, with an overshoot dangling off the first
+	lines := helper.ParseWKT("MULTILINESTRING((0 0, 10 0), (10 0, 10 10), (10 10, 0 10, 0 0), (10 10, 12 12), (10 0, 20 0), (20 0, 30 0, 30 10, 20 10, 20 0))")
+
+	result, err := helper.service.Polygonize(lines)
+	if err != nil {
+		t.Fatalf("Failed to polygonize: %v", err)
+	}
+
+	helper.AssertIntersects(result.Polygons, helper.ParseWKT("POINT(5 5)"), true)
+	helper.AssertIntersects(result.Polygons, helper.ParseWKT("POINT(25 5)"), true)
+	helper.AssertIntersects(result.Polygons, helper.ParseWKT("POINT(15 5)"), false)
+
+	expected := []struct {
+		name     string
+		geom     *Geometry
+		expected string
+	}{
+		{"dangles", result.Dangles, "GEOMETRYCOLLECTION (LINESTRING (10 10, 12 12))"},
+		{"cut edges", result.CutEdges, "GEOMETRYCOLLECTION (LINESTRING (10 0, 20 0))"},
+		{"invalid rings", result.InvalidRings, "GEOMETRYCOLLECTION EMPTY"},
+	}
+
+	for _, e := range expected {
+		if e.geom == nil {
+			t.Fatalf("Expected %s to be reported", e.name)
+		}
+		wkt, err := helper.service.ToWKTWithOptions(e.geom, WKTOptions{Trim: true})
+		if err != nil {
+			t.Fatalf("Failed to convert %s to WKT: %v", e.name, err)
+		}
+		if wkt != e.expected {
+			t.Errorf("Expected %s %s, got %s", e.name, e.expected, wkt)
+		}
+	}
+
+	if _, err := helper.service.Polygonize(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestNode tests inserting nodes at line crossings
+func TestNode(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	cross := helper.ParseWKT("MULTILINESTRING((0 0, 2 2), (0 2, 2 0))")
+
+	noded, err := helper.service.Node(cross)
+	if err != nil {
+		t.Fatalf("Failed to node linework: %v", err)
+	}
+
+	// Four segments of two coordinates each, in a MultiLineString
+	size, err := helper.service.EstimateSize(noded, FormatWKB)
+	if err != nil {
+		t.Fatalf("Failed to estimate size: %v", err)
+	}
+	if expected := 9 + 4*(9+2*16); size != expected {
+		t.Errorf("Expected WKB size %d for four segments, got %d", expected, size)
+	}
+
+	// The lines now share a node at the crossing
+	helper.AssertIntersects(noded, helper.ParseWKT("POINT(1 1)"), true)
+
+	// Noding a closed square with a tail lets it polygonize
+	lines := helper.ParseWKT("MULTILINESTRING((0 0, 10 0, 10 10, 0 10, 0 0), (5 -5, 5 5))")
+	noded, err = helper.service.Node(lines)
+	if err != nil {
+		t.Fatalf("Failed to node linework: %v", err)
+	}
+	result, err := helper.service.Polygonize(noded)
+	if err != nil {
+		t.Fatalf("Failed to polygonize: %v", err)
+	}
+	helper.AssertIntersects(result.Polygons, helper.ParseWKT("POINT(2 2)"), true)
+
+	if _, err := helper.service.Node(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,329 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mehmetymw/gogeos/transform"
+)
+
+// Transformer converts coordinates between coordinate systems in batches. It
+// is the extension point for reprojection: implementations can wrap PROJ
+// bindings, grid-shift lookups or plain formulas, and the library does not
+// depend on any particular projection stack. AffineTransform and
+// TransformerFunc implement it, as do the converters of the mercator package.
+type Transformer interface {
+	// TransformXY transforms the coordinates in place. x and y have the same
+	// length and hold the X and Y ordinates of every coordinate of a geometry.
+	TransformXY(x, y []float64) error
+}
+
+// TransformerFunc adapts a function converting a single coordinate to the
+// Transformer interface.
+//
+// Example:
+//
+//	swap := geos.TransformerFunc(func(x, y float64) (float64, float64) {
+//		return y, x
+//	})
+type TransformerFunc func(x, y float64) (float64, float64)
+
+// TransformXY applies f to every coordinate.
+func (f TransformerFunc) TransformXY(x, y []float64) error {
+	for i := range x {
+		x[i], y[i] = f(x[i], y[i])
+	}
+	return nil
+}
+
+// TransformXY applies the affine transformation to every coordinate.
+func (t AffineTransform) TransformXY(x, y []float64) error {
+	return TransformerFunc(t.Apply).TransformXY(x, y)
+}
+
+// Transform converts every coordinate of a geometry with a Transformer, e.g.
+// to reproject it. All coordinates are passed to the transformer in one batch,
+// which suits libraries with a per-call overhead such as PROJ. Z and M
+// ordinates are left untouched and the result has the same structure as the
+// input. The transformer runs without holding the service lock, so it may use
+// the service itself. The result has no SRID, since the transformer may have
+// moved it to another reference system; set one with SetSRID or use
+// TransformEPSG.
+//
+// Parameters:
+//   - geom: The geometry to transform
+//   - t: The coordinate transformer
+//
+// Returns:
+//   - *Geometry: A new transformed geometry
+//   - error: An error if the transformer fails or the operation fails
+//
+// Example:
+//
+//	// Project longitude/latitude to Web Mercator meters
+//	projected, err := service.Transform(geom, mercator.ToMeters)
+func (s *Service) Transform(geom *Geometry, t Transformer) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	if t == nil {
+		return nil, errors.New("transformer is required")
+	}
+
+	sh, err := s.shapeOf(geom)
+	if err != nil {
+		return nil, err
+	}
+
+	x, y := sh.xy()
+	if err := t.TransformXY(x, y); err != nil {
+		return nil, fmt.Errorf("transform failed: %w", err)
+	}
+	sh.setXY(x, y)
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	transformed, err := c.buildShape(sh)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newRecordedGeometry("Transform", map[string]any{"t": fmt.Sprintf("%T", t)}, transformed, geom), nil
+}
+
+// TransformEPSG reprojects a geometry from one EPSG reference system to
+// another with the pure Go transformations of the transform package, which
+// cover WGS84 longitude/latitude (4326), Web Mercator (3857) and the WGS84
+// UTM zones (32601-32660, 32701-32760). Reprojecting longitude/latitude to a
+// metric system first makes Buffer, Area and Distance work in meters. The
+// result carries toEPSG as its SRID.
+//
+// Parameters:
+//   - geom: The geometry to reproject
+//   - fromEPSG: The reference system of geom, or 0 to use its SRID
+//   - toEPSG: The reference system to reproject to
+//
+// Returns:
+//   - *Geometry: A new reprojected geometry
+//   - error: An error if a reference system is unsupported or the operation fails
+//
+// Example:
+//
+//	parcel, _ := service.ParseGeometry(GeometryInput{WKT: wkt, SRID: 4326})
+//	utm, err := service.TransformEPSG(parcel, 0, 32635)
+//	buffered, _ := service.Buffer(utm, 500) // 500 meters
+//	back, _ := service.TransformEPSG(buffered, 0, 4326)
+func (s *Service) TransformEPSG(geom *Geometry, fromEPSG, toEPSG int) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	if fromEPSG == 0 {
+		if fromEPSG = geom.SRID(); fromEPSG == 0 {
+			return nil, errors.New("geometry has no SRID; the source EPSG code is required")
+		}
+	}
+
+	t, err := transform.New(fromEPSG, toEPSG)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.Transform(geom, t)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.SetSRID(toEPSG); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// TransformToLocalUTM reprojects a WGS84 longitude/latitude geometry to the
+// UTM zone containing its centroid, where coordinates are in meters and
+// distortion is small. This makes metric operations such as Buffer by 500
+// meters or Area in square meters meaningful for lon/lat data. The result
+// carries the EPSG code of the zone as its SRID, so TransformEPSG with a
+// source of 0 transforms it back. Geometries spanning many zones are still
+// projected to a single zone and become increasingly distorted.
+//
+// Parameters:
+//   - geom: The geometry to project, in longitude/latitude
+//
+// Returns:
+//   - *Geometry: A new geometry in UTM meters, with the zone as its SRID
+//   - error: An error if the geometry is empty, has a projected SRID or the operation fails
+//
+// Example:
+//
+//	parcel, _ := service.ParseGeometry(GeometryInput{WKT: "POLYGON((28.97 41.00, 28.98 41.00, 28.98 41.01, 28.97 41.00))"})
+//
+//	local, err := service.TransformToLocalUTM(parcel) // EPSG:32635
+//	buffered, _ := service.Buffer(local, 500)          // 500 meters
+//	back, _ := service.TransformEPSG(buffered, 0, 4326)
+func (s *Service) TransformToLocalUTM(geom *Geometry) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	if err := checkGeographic(geom); err != nil {
+		return nil, err
+	}
+
+	lon, lat, err := s.centroidXY(geom)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLatitudes([]float64{lat}); err != nil {
+		return nil, err
+	}
+
+	return s.TransformEPSG(geom, transform.WGS84, transform.UTMZone(lon, lat))
+}
+
+// centroidXY returns the coordinates of the centroid of a non-empty geometry
+func (s *Service) centroidXY(geom *Geometry) (x, y float64, err error) {
+	c, err := s.acquire(geom)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer c.release()
+	if C.GEOSisEmpty_r(c.context, geom.geom) != 0 {
+		return 0, 0, errors.New("geometry is empty")
+	}
+
+	centroid := C.GEOSGetCentroid_r(c.context, geom.geom)
+	if centroid == nil {
+		return 0, 0, c.geosError("failed to calculate centroid")
+	}
+	defer C.GEOSGeom_destroy_r(c.context, centroid)
+
+	var cx, cy C.double
+	if C.GEOSGeomGetX_r(c.context, centroid, &cx) == 0 || C.GEOSGeomGetY_r(c.context, centroid, &cy) == 0 {
+		return 0, 0, c.geosError("failed to get centroid coordinates")
+	}
+
+	return float64(cx), float64(cy), nil
+}
+
+// shapeOf copies the structure and coordinates of a geometry, taking the
+// service lock for the duration of the copy
+func (s *Service) shapeOf(geom *Geometry) (*shape, error) {
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	return c.readShape(geom.geom)
+}
+
+// TransformAll converts the coordinates of a batch of geometries with a single
+// Transformer call. Creating a transformation, e.g. a PROJ pipeline between
+// two coordinate reference systems, and crossing into it are often more
+// expensive than the conversion itself, so the transformer should be created
+// once and all coordinates of the batch are converted in bulk. Nil geometries
+// yield nil results. Like Transform, the results have no SRID.
+//
+// Parameters:
+//   - geoms: The geometries to transform
+//   - t: The coordinate transformer, reused for the whole batch
+//
+// Returns:
+//   - []*Geometry: The transformed geometries, in input order
+//   - error: An error if the transformer fails or the operation fails
+//
+// Example:
+//
+//	projected, err := service.TransformAll(features, mercator.ToMeters)
+func (s *Service) TransformAll(geoms []*Geometry, t Transformer) ([]*Geometry, error) {
+	for i, geom := range geoms {
+		if geom != nil && geom.geom == nil {
+			return nil, fmt.Errorf("invalid geometry at index %d", i)
+		}
+	}
+	if t == nil {
+		return nil, errors.New("transformer is required")
+	}
+
+	shapes, err := s.shapesOf(geoms)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lay the coordinates of all geometries end to end
+	var x, y []float64
+	offsets := make([]int, len(shapes)+1)
+	for i, sh := range shapes {
+		if sh != nil {
+			sx, sy := sh.xy()
+			x, y = append(x, sx...), append(y, sy...)
+		}
+		offsets[i+1] = len(x)
+	}
+
+	if err := t.TransformXY(x, y); err != nil {
+		return nil, fmt.Errorf("transform failed: %w", err)
+	}
+
+	c, err := s.acquire(geoms...)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	built := make([]*C.GEOSGeometry, len(shapes))
+	for i, sh := range shapes {
+		if sh == nil {
+			continue
+		}
+		sh.setXY(x[offsets[i]:offsets[i+1]], y[offsets[i]:offsets[i+1]])
+		if built[i], err = c.buildShape(sh); err != nil {
+			c.destroyAll(built[:i])
+			return nil, fmt.Errorf("geometry %d: %w", i, err)
+		}
+	}
+
+	results := make([]*Geometry, len(built))
+	for i, g := range built {
+		if g != nil {
+			results[i] = c.newGeometry(g)
+		}
+	}
+	c.record("TransformAll", map[string]any{"t": fmt.Sprintf("%T", t)}, geoms, results...)
+
+	return results, nil
+}
+
+// shapesOf copies the structure and coordinates of geometries, taking the
+// service lock for the duration of the copy. Nil geometries yield nil shapes.
+func (s *Service) shapesOf(geoms []*Geometry) ([]*shape, error) {
+	c, err := s.acquire(geoms...)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	shapes := make([]*shape, len(geoms))
+	for i, geom := range geoms {
+		if geom == nil {
+			continue
+		}
+		sh, err := c.readShape(geom.geom)
+		if err != nil {
+			return nil, fmt.Errorf("geometry %d: %w", i, err)
+		}
+		shapes[i] = sh
+	}
+
+	return shapes, nil
+}
@@ -0,0 +1,228 @@
+package geos
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mehmetymw/gogeos/mercator"
+)
+
+// failingTransformer is a Transformer that always fails
+type failingTransformer struct{}
+
+func (failingTransformer) TransformXY(x, y []float64) error {
+	return errors.New("no grid for this area")
+}
+
+// TestTransform tests converting coordinates with pluggable transformers
+func TestTransform(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	swap := TransformerFunc(func(x, y float64) (float64, float64) {
+		return y, x
+	})
+
+	testCases := []struct {
+		name        string
+		wkt         string
+		transformer Transformer
+		expected    string
+	}{
+		{"Function", "LINESTRING(1 2, 3 4)", swap, "LINESTRING (2 1, 4 3)"},
+		{"Affine", "POLYGON((0 0, 1 0, 1 1, 0 0))", TranslateTransform(10, 20), "POLYGON ((10 20, 11 20, 11 21, 10 20))"},
+		{"Z is kept", "POINT Z(1 2 3)", swap, "POINT Z (2 1 3)"},
+		{"Collection", "GEOMETRYCOLLECTION(POINT(1 2), LINESTRING(3 4, 5 6))", swap, "GEOMETRYCOLLECTION (POINT (2 1), LINESTRING (4 3, 6 5))"},
+		{"Mercator", "POINT(180 0)", mercator.ToMeters, "POINT (20037508.342789 0)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transformed, err := helper.service.Transform(helper.ParseWKT(tc.wkt), tc.transformer)
+			if err != nil {
+				t.Fatalf("Failed to transform: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(transformed, WKTOptions{Trim: true, Precision: 6})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.Transform(helper.PointGeometry(), failingTransformer{}); err == nil {
+		t.Error("Expected the transformer error")
+	}
+	if _, err := helper.service.Transform(helper.PointGeometry(), nil); err == nil {
+		t.Error("Expected error for a nil transformer")
+	}
+}
+
+// countingTransformer records how often it is called
+type countingTransformer struct {
+	calls int
+}
+
+func (c *countingTransformer) TransformXY(x, y []float64) error {
+	c.calls++
+	for i := range x {
+		x[i], y[i] = x[i]*2, y[i]*2
+	}
+	return nil
+}
+
+// TestTransformAll tests transforming a batch with a single transformer call
+func TestTransformAll(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	geoms := []*Geometry{
+		helper.ParseWKT("POINT(1 2)"),
+		nil,
+		helper.ParseWKT("LINESTRING(0 0, 1 1)"),
+		helper.ParseWKT("POLYGON((0 0, 1 0, 1 1, 0 0))"),
+	}
+	expected := []string{"POINT (2 4)", "", "LINESTRING (0 0, 2 2)", "POLYGON ((0 0, 2 0, 2 2, 0 0))"}
+
+	counter := &countingTransformer{}
+	results, err := helper.service.TransformAll(geoms, counter)
+	if err != nil {
+		t.Fatalf("Failed to transform: %v", err)
+	}
+	if counter.calls != 1 {
+		t.Errorf("Expected 1 transformer call, got %d", counter.calls)
+	}
+	if len(results) != len(geoms) {
+		t.Fatalf("Expected %d results, got %d", len(geoms), len(results))
+	}
+
+	for i, result := range results {
+		if expected[i] == "" {
+			if result != nil {
+				t.Errorf("Result %d: expected nil for a nil geometry", i)
+			}
+			continue
+		}
+		wkt, err := helper.service.ToWKTWithOptions(result, WKTOptions{Trim: true})
+		if err != nil {
+			t.Fatalf("Failed to convert to WKT: %v", err)
+		}
+		if wkt != expected[i] {
+			t.Errorf("Result %d: expected %s, got %s", i, expected[i], wkt)
+		}
+	}
+
+	if _, err := helper.service.TransformAll(geoms, failingTransformer{}); err == nil {
+		t.Error("Expected the transformer error")
+	}
+}
+
+// TestTransformEPSG tests reprojecting between EPSG reference systems
+func TestTransformEPSG(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	tower, err := helper.service.ParseGeometry(GeometryInput{WKT: "POINT(-79.387139 43.642567)", SRID: 4326})
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	projected, err := helper.service.TransformEPSG(tower, 0, 32617)
+	if err != nil {
+		t.Fatalf("Failed to reproject: %v", err)
+	}
+	if projected.SRID() != 32617 {
+		t.Errorf("Expected SRID 32617, got %d", projected.SRID())
+	}
+	expected := helper.ParseWKT("POINT(630084.30 4833438.59)")
+	if equal, err := helper.service.EqualsWithTolerance(projected, expected, 0.01); err != nil || !equal {
+		t.Errorf("Expected the UTM position of the tower, got %s (%v)", helper.AssertToWKT(projected), err)
+	}
+
+	// A 500 m buffer in UTM goes back to lon/lat around the tower
+	buffered, err := helper.service.Buffer(projected, 500)
+	if err != nil {
+		t.Fatalf("Failed to buffer: %v", err)
+	}
+	back, err := helper.service.TransformEPSG(buffered, 0, 4326)
+	if err != nil {
+		t.Fatalf("Failed to reproject back: %v", err)
+	}
+	if back.SRID() != 4326 {
+		t.Errorf("Expected SRID 4326, got %d", back.SRID())
+	}
+	if inside, err := helper.service.Within(tower, back); err != nil || !inside {
+		t.Errorf("Expected the tower within its buffer, got %v (%v)", inside, err)
+	}
+
+	if _, err := helper.service.TransformEPSG(helper.PointGeometry(), 0, 3857); err == nil {
+		t.Error("Expected error for a geometry without SRID")
+	}
+	if _, err := helper.service.TransformEPSG(tower, 4326, 2154); err == nil {
+		t.Error("Expected error for an unsupported reference system")
+	}
+}
+
+// TestTransformToLocalUTM tests projecting to the UTM zone of the centroid
+func TestTransformToLocalUTM(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected int
+	}{
+		{"Toronto", "POINT(-79.387139 43.642567)", 32617},
+		{"Sydney", "POLYGON((151.20 -33.87, 151.22 -33.87, 151.22 -33.86, 151.20 -33.87))", 32756},
+		// The centroid decides, not the first vertex
+		{"Across a zone boundary", "LINESTRING(29.9 41, 36.5 41)", 32636},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			local, err := helper.service.TransformToLocalUTM(helper.ParseWKT(tc.wkt))
+			if err != nil {
+				t.Fatalf("Failed to project: %v", err)
+			}
+			if local.SRID() != tc.expected {
+				t.Errorf("Expected SRID %d, got %d", tc.expected, local.SRID())
+			}
+		})
+	}
+
+	// A 500 m buffer, a 32-gon of about 780400 m², survives the round trip
+	tower := helper.ParseWKT("POINT(-79.387139 43.642567)")
+	local, err := helper.service.TransformToLocalUTM(tower)
+	if err != nil {
+		t.Fatalf("Failed to project: %v", err)
+	}
+	buffered, err := helper.service.Buffer(local, 500)
+	if err != nil {
+		t.Fatalf("Failed to buffer: %v", err)
+	}
+	back, err := helper.service.TransformEPSG(buffered, 0, 4326)
+	if err != nil {
+		t.Fatalf("Failed to reproject back: %v", err)
+	}
+	if inside, err := helper.service.Within(tower, back); err != nil || !inside {
+		t.Errorf("Expected the tower within its buffer, got %v (%v)", inside, err)
+	}
+	if area, err := helper.service.GeodesicArea(back); err != nil || area < 775000 || area > 785000 {
+		t.Errorf("Expected a buffer of about 780400 m², got %f (%v)", area, err)
+	}
+
+	if _, err := helper.service.TransformToLocalUTM(helper.ParseWKT("POINT EMPTY")); err == nil {
+		t.Error("Expected error for an empty geometry")
+	}
+	projected, err := helper.service.ParseGeometry(GeometryInput{WKT: "POINT(630084 4833438)", SRID: 32617})
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if _, err := helper.service.TransformToLocalUTM(projected); err == nil {
+		t.Error("Expected error for a projected geometry")
+	}
+}
@@ -0,0 +1,152 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import "fmt"
+
+// GeometryType is the kind of a geometry, as named in WKT.
+type GeometryType int
+
+const (
+	// TypeUnknown is the type of a nil geometry
+	TypeUnknown GeometryType = iota
+	// TypePoint is a single position
+	TypePoint
+	// TypeLineString is a sequence of connected segments
+	TypeLineString
+	// TypeLinearRing is a closed LineString, as used for polygon rings
+	TypeLinearRing
+	// TypePolygon is an area bounded by a shell and optional holes
+	TypePolygon
+	// TypeMultiPoint is a collection of points
+	TypeMultiPoint
+	// TypeMultiLineString is a collection of LineStrings
+	TypeMultiLineString
+	// TypeMultiPolygon is a collection of polygons
+	TypeMultiPolygon
+	// TypeGeometryCollection is a collection of geometries of any type
+	TypeGeometryCollection
+)
+
+// String returns the WKT name of the type, e.g. "MultiPolygon"
+func (t GeometryType) String() string {
+	switch t {
+	case TypeUnknown:
+		return "Unknown"
+	case TypePoint:
+		return "Point"
+	case TypeLineString:
+		return "LineString"
+	case TypeLinearRing:
+		return "LinearRing"
+	case TypePolygon:
+		return "Polygon"
+	case TypeMultiPoint:
+		return "MultiPoint"
+	case TypeMultiLineString:
+		return "MultiLineString"
+	case TypeMultiPolygon:
+		return "MultiPolygon"
+	case TypeGeometryCollection:
+		return "GeometryCollection"
+	}
+	return fmt.Sprintf("GeometryType(%d)", int(t))
+}
+
+// IsCollection reports whether the type is a multi-geometry or a
+// GeometryCollection.
+func (t GeometryType) IsCollection() bool {
+	return t >= TypeMultiPoint && t <= TypeGeometryCollection
+}
+
+// geometryType converts a GEOS type id
+func geometryType(typeID C.int) GeometryType {
+	switch typeID {
+	case C.GEOS_POINT:
+		return TypePoint
+	case C.GEOS_LINESTRING:
+		return TypeLineString
+	case C.GEOS_LINEARRING:
+		return TypeLinearRing
+	case C.GEOS_POLYGON:
+		return TypePolygon
+	case C.GEOS_MULTIPOINT:
+		return TypeMultiPoint
+	case C.GEOS_MULTILINESTRING:
+		return TypeMultiLineString
+	case C.GEOS_MULTIPOLYGON:
+		return TypeMultiPolygon
+	case C.GEOS_GEOMETRYCOLLECTION:
+		return TypeGeometryCollection
+	}
+	return TypeUnknown
+}
+
+// Type returns the kind of the geometry. The type is fixed when a geometry
+// is created, so this needs neither the service nor error handling.
+//
+// Returns:
+//   - GeometryType: The type, or TypeUnknown for a nil geometry
+//
+// Example:
+//
+//	union, _ := service.Union(parcels)
+//	if union.Type() == geos.TypeMultiPolygon {
+//		fmt.Println("the parcels are not contiguous")
+//	}
+func (g *Geometry) Type() GeometryType {
+	if g == nil {
+		return TypeUnknown
+	}
+	return g.geomType
+}
+
+// IsPoint reports whether the geometry is a Point.
+func (g *Geometry) IsPoint() bool {
+	return g.Type() == TypePoint
+}
+
+// IsLineString reports whether the geometry is a LineString or LinearRing.
+func (g *Geometry) IsLineString() bool {
+	t := g.Type()
+	return t == TypeLineString || t == TypeLinearRing
+}
+
+// IsPolygon reports whether the geometry is a Polygon.
+func (g *Geometry) IsPolygon() bool {
+	return g.Type() == TypePolygon
+}
+
+// IsCollection reports whether the geometry is a multi-geometry or a
+// GeometryCollection.
+func (g *Geometry) IsCollection() bool {
+	return g.Type().IsCollection()
+}
+
+// typeID converts the type to a GEOS type id; ok is false for TypeUnknown
+// and unknown values
+func (t GeometryType) typeID() (id C.int, ok bool) {
+	switch t {
+	case TypePoint:
+		return C.GEOS_POINT, true
+	case TypeLineString:
+		return C.GEOS_LINESTRING, true
+	case TypeLinearRing:
+		return C.GEOS_LINEARRING, true
+	case TypePolygon:
+		return C.GEOS_POLYGON, true
+	case TypeMultiPoint:
+		return C.GEOS_MULTIPOINT, true
+	case TypeMultiLineString:
+		return C.GEOS_MULTILINESTRING, true
+	case TypeMultiPolygon:
+		return C.GEOS_MULTIPOLYGON, true
+	case TypeGeometryCollection:
+		return C.GEOS_GEOMETRYCOLLECTION, true
+	}
+	return 0, false
+}
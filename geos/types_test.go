@@ -0,0 +1,65 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestGeometryType tests the cached geometry type and type predicates
+func TestGeometryType(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		wkt        string
+		expected   GeometryType
+		name       string
+		point      bool
+		line       bool
+		polygon    bool
+		collection bool
+	}{
+		{"POINT(1 2)", TypePoint, "Point", true, false, false, false},
+		{"LINESTRING(0 0, 1 1)", TypeLineString, "LineString", false, true, false, false},
+		{"LINEARRING(0 0, 1 0, 1 1, 0 0)", TypeLinearRing, "LinearRing", false, true, false, false},
+		{"POLYGON((0 0, 1 0, 1 1, 0 0))", TypePolygon, "Polygon", false, false, true, false},
+		{"MULTIPOINT((0 0), (1 1))", TypeMultiPoint, "MultiPoint", false, false, false, true},
+		{"MULTILINESTRING((0 0, 1 1), (2 2, 3 3))", TypeMultiLineString, "MultiLineString", false, false, false, true},
+		{"MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)))", TypeMultiPolygon, "MultiPolygon", false, false, false, true},
+		{"GEOMETRYCOLLECTION(POINT(0 0))", TypeGeometryCollection, "GeometryCollection", false, false, false, true},
+		{"POLYGON EMPTY", TypePolygon, "Polygon", false, false, true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.wkt)
+
+			if got := geom.Type(); got != tc.expected {
+				t.Errorf("Expected type %v, got %v", tc.expected, got)
+			}
+			if got := geom.Type().String(); got != tc.name {
+				t.Errorf("Expected name %s, got %s", tc.name, got)
+			}
+			if geom.IsPoint() != tc.point || geom.IsLineString() != tc.line || geom.IsPolygon() != tc.polygon || geom.IsCollection() != tc.collection {
+				t.Errorf("Unexpected predicates: point %v, line %v, polygon %v, collection %v",
+					geom.IsPoint(), geom.IsLineString(), geom.IsPolygon(), geom.IsCollection())
+			}
+		})
+	}
+
+	// Results of operations carry their type too
+	buffered, err := helper.service.Buffer(helper.PointGeometry(), 1)
+	if err != nil {
+		t.Fatalf("Failed to buffer: %v", err)
+	}
+	if !buffered.IsPolygon() {
+		t.Errorf("Expected a Polygon buffer, got %v", buffered.Type())
+	}
+
+	var nilGeom *Geometry
+	if nilGeom.Type() != TypeUnknown {
+		t.Errorf("Expected TypeUnknown for nil geometry, got %v", nilGeom.Type())
+	}
+	if got := GeometryType(42).String(); got != "GeometryType(42)" {
+		t.Errorf("Expected fallback name, got %s", got)
+	}
+}
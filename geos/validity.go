@@ -0,0 +1,566 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SelfIntersections returns the locations where the linework of a geometry
+// intersects itself, as a MultiPoint. For lines these are the points where the
+// line crosses or touches itself; for polygons they are the points where rings
+// cross or touch each other or themselves. Crossings make a polygon invalid,
+// but touching does not always: a hole may touch the shell or another hole at
+// a single point, so IsValid tells the two apart. Unlike the single location
+// reported by validity checks, every intersection is returned, so digitizing
+// errors can be highlighted for editors.
+//
+// The linework is fully noded and every node shared by more than two edges is
+// reported. Where the linework runs back over itself, such as a line that
+// doubles back or two rings sharing an edge, noding merges the overlapping
+// segments, so the ends of each overlapping stretch are reported instead. The
+// closing point of a ring is not considered an intersection.
+//
+// Parameters:
+//   - geom: The line or polygon geometry to check
+//
+// Returns:
+//   - *Geometry: A MultiPoint of self-intersection locations (empty if there are none)
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	bowtie := GeometryInput{WKT: "LINESTRING(0 0, 2 2, 2 0, 0 2)"}
+//	geom, _ := service.ParseGeometry(bowtie)
+//
+//	points, err := service.SelfIntersections(geom)
+//	// points will be MULTIPOINT ((1 1))
+func (s *Service) SelfIntersections(geom *Geometry) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	result := &shape{typeID: C.GEOS_MULTIPOINT}
+
+	dimension := C.GEOSGeom_getDimensions_r(c.context, geom.geom)
+	if dimension > 0 && C.GEOSisEmpty_r(c.context, geom.geom) == 0 {
+		lines := geom.geom
+		if dimension == 2 {
+			lines = C.GEOSBoundary_r(c.context, geom.geom)
+			if lines == nil {
+				return nil, c.geosError("failed to get polygon boundary")
+			}
+			defer C.GEOSGeom_destroy_r(c.context, lines)
+		}
+
+		noded := C.GEOSNode_r(c.context, lines)
+		if noded == nil {
+			return nil, c.geosError("failed to node linework")
+		}
+		defer C.GEOSGeom_destroy_r(c.context, noded)
+
+		input, err := c.readShape(lines)
+		if err != nil {
+			return nil, err
+		}
+		edges, err := c.readShape(noded)
+		if err != nil {
+			return nil, err
+		}
+
+		degrees := make(map[[2]float64]int)
+		countEndpoints(edges, degrees)
+
+		found := make(map[[2]float64]bool)
+		for node, degree := range degrees {
+			if degree > 2 {
+				found[node] = true
+			}
+		}
+		for _, end := range overlapEnds(appendSegments(nil, input), appendSegments(nil, edges)) {
+			found[end] = true
+		}
+
+		nodes := make([][2]float64, 0, len(found))
+		for node := range found {
+			nodes = append(nodes, node)
+		}
+		sort.Slice(nodes, func(i, j int) bool {
+			if nodes[i][0] != nodes[j][0] {
+				return nodes[i][0] < nodes[j][0]
+			}
+			return nodes[i][1] < nodes[j][1]
+		})
+
+		for _, node := range nodes {
+			result.parts = append(result.parts, &shape{
+				typeID: C.GEOS_POINT,
+				coords: []float64{node[0], node[1]},
+			})
+		}
+	}
+
+	points, err := c.buildShape(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newDerivedGeometry("SelfIntersections", nil, points, geom), nil
+}
+
+// countEndpoints counts how many noded edges start or end at each XY location
+func countEndpoints(sh *shape, degrees map[[2]float64]int) {
+	if sh.typeID == C.GEOS_LINESTRING || sh.typeID == C.GEOS_LINEARRING {
+		stride := sh.stride()
+		n := len(sh.coords) / stride
+		if n < 2 {
+			return
+		}
+		degrees[[2]float64{sh.coords[0], sh.coords[1]}]++
+		last := (n - 1) * stride
+		degrees[[2]float64{sh.coords[last], sh.coords[last+1]}]++
+		return
+	}
+	for _, part := range sh.parts {
+		countEndpoints(part, degrees)
+	}
+}
+
+// segment is a line segment between two XY locations
+type segment struct {
+	a, b [2]float64
+}
+
+// minX returns the smallest X of the segment
+func (s segment) minX() float64 {
+	return math.Min(s.a[0], s.b[0])
+}
+
+// width returns the extent of the segment along X
+func (s segment) width() float64 {
+	return math.Abs(s.b[0] - s.a[0])
+}
+
+// contains reports whether (x, y) lies strictly inside the segment, allowing
+// for the rounding of noded coordinates
+func (s segment) contains(x, y float64) bool {
+	dx, dy := s.b[0]-s.a[0], s.b[1]-s.a[1]
+	length2 := dx*dx + dy*dy
+	if length2 == 0 {
+		return false
+	}
+	t := ((x-s.a[0])*dx + (y-s.a[1])*dy) / length2
+	if t <= 0 || t >= 1 {
+		return false
+	}
+	// The distance to the line is cross/length, allowed up to 1e-9 * length
+	cross := (x-s.a[0])*dy - (y-s.a[1])*dx
+	return cross*cross <= 1e-18*length2*length2
+}
+
+// appendSegments appends the segments of the lines and rings of a shape,
+// skipping repeated points
+func appendSegments(segments []segment, sh *shape) []segment {
+	if sh.typeID == C.GEOS_LINESTRING || sh.typeID == C.GEOS_LINEARRING {
+		stride := sh.stride()
+		for i := stride; i+1 < len(sh.coords); i += stride {
+			a := [2]float64{sh.coords[i-stride], sh.coords[i-stride+1]}
+			b := [2]float64{sh.coords[i], sh.coords[i+1]}
+			if a != b {
+				segments = append(segments, segment{a, b})
+			}
+		}
+		return segments
+	}
+	for _, part := range sh.parts {
+		segments = appendSegments(segments, part)
+	}
+	return segments
+}
+
+// overlapEnds returns the ends of the stretches where linework runs over
+// itself. Noding merges overlapping segments into one, so a noded segment
+// whose midpoint lies on more than one input segment is part of an overlap.
+func overlapEnds(input, noded []segment) [][2]float64 {
+	// Sorted by their left end and with the widest extent known, the input
+	// segments that may hold a midpoint form a short run
+	sort.Slice(input, func(i, j int) bool { return input[i].minX() < input[j].minX() })
+	width := 0.0
+	for _, s := range input {
+		width = math.Max(width, s.width())
+	}
+
+	incident := make(map[[2]float64]int)
+	overlapping := make(map[[2]float64]int)
+	for _, s := range noded {
+		incident[s.a]++
+		incident[s.b]++
+
+		x, y := (s.a[0]+s.b[0])/2, (s.a[1]+s.b[1])/2
+		covering := 0
+		first := sort.Search(len(input), func(i int) bool { return input[i].minX() >= x-width })
+		for _, in := range input[first:] {
+			if in.minX() > x {
+				break
+			}
+			if in.contains(x, y) {
+				covering++
+			}
+		}
+		if covering > 1 {
+			overlapping[s.a]++
+			overlapping[s.b]++
+		}
+	}
+
+	// Inside a stretch a vertex joins exactly two overlapping segments
+	ends := make([][2]float64, 0)
+	for p, n := range overlapping {
+		if n != 2 || incident[p] != 2 {
+			ends = append(ends, p)
+		}
+	}
+	return ends
+}
+
+// MakeValidMethod selects the algorithm used by MakeValidWithParams.
+type MakeValidMethod int
+
+const (
+	// MakeValidLinework builds valid geometries from the noded linework of the
+	// input, keeping every edge. Self-overlapping areas may become holes.
+	MakeValidLinework MakeValidMethod = iota
+	// MakeValidStructure rebuilds polygons from their shells and holes, so
+	// areas are unioned rather than toggled. This usually matches intent better
+	// for polygons drawn by hand.
+	MakeValidStructure
+)
+
+// MakeValidOptions controls MakeValidWithParams.
+type MakeValidOptions struct {
+	// Method is the repair algorithm
+	Method MakeValidMethod `json:"method,omitempty"`
+
+	// KeepCollapsed keeps components that collapse to a lower dimension, e.g.
+	// a zero-area polygon becoming a line. It only applies to MakeValidStructure.
+	KeepCollapsed bool `json:"keep_collapsed,omitempty"`
+}
+
+// MakeValid repairs an invalid geometry without losing any of its vertices.
+// Valid geometries are returned unchanged. The result may be a collection of
+// mixed types when parts of the input collapse, e.g. a bow-tie polygon becomes
+// a MultiPolygon of its two triangles.
+//
+// Parameters:
+//   - geom: The geometry to repair, e.g. parsed with GeometryInput.AllowInvalid
+//
+// Returns:
+//   - *Geometry: A new valid geometry
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	bowtie := GeometryInput{WKT: "POLYGON((0 0, 2 2, 2 0, 0 2, 0 0))", AllowInvalid: true}
+//	geom, _ := service.ParseGeometry(bowtie)
+//
+//	fixed, err := service.MakeValid(geom)
+//	// fixed will be a MultiPolygon of two triangles meeting at (1 1)
+func (s *Service) MakeValid(geom *Geometry) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	valid := C.GEOSMakeValid_r(c.context, geom.geom)
+	if valid == nil {
+		return nil, c.geosError("failed to make geometry valid")
+	}
+
+	return c.newDerivedGeometry("MakeValid", nil, valid, geom), nil
+}
+
+// MakeValidWithParams repairs an invalid geometry using the given method. See
+// MakeValidMethod for how the methods differ.
+//
+// Parameters:
+//   - geom: The geometry to repair
+//   - opts: The repair method and whether to keep collapsed components
+//
+// Returns:
+//   - *Geometry: A new valid geometry
+//   - error: An error if the method is unknown or the operation fails
+//
+// Example:
+//
+//	fixed, err := service.MakeValidWithParams(geom, geos.MakeValidOptions{
+//		Method: geos.MakeValidStructure,
+//	})
+func (s *Service) MakeValidWithParams(geom *Geometry, opts MakeValidOptions) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	var method C.enum_GEOSMakeValidMethods
+	switch opts.Method {
+	case MakeValidLinework:
+		method = C.GEOS_MAKE_VALID_LINEWORK
+	case MakeValidStructure:
+		method = C.GEOS_MAKE_VALID_STRUCTURE
+	default:
+		return nil, fmt.Errorf("unknown make valid method: %d", int(opts.Method))
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	params := C.GEOSMakeValidParams_create_r(c.context)
+	if params == nil {
+		return nil, c.geosError("failed to create make valid parameters")
+	}
+	defer C.GEOSMakeValidParams_destroy_r(c.context, params)
+
+	if C.GEOSMakeValidParams_setMethod_r(c.context, params, method) == 0 {
+		return nil, c.geosError("failed to set make valid method")
+	}
+	if C.GEOSMakeValidParams_setKeepCollapsed_r(c.context, params, boolToCInt(opts.KeepCollapsed)) == 0 {
+		return nil, c.geosError("failed to set make valid keep collapsed flag")
+	}
+
+	valid := C.GEOSMakeValidWithParams_r(c.context, geom.geom, params)
+	if valid == nil {
+		return nil, c.geosError("failed to make geometry valid")
+	}
+
+	return c.newDerivedGeometry("MakeValidWithParams", map[string]any{"opts": opts}, valid, geom), nil
+}
+
+// FixStrategy selects how FixSelfIntersections repairs a geometry.
+type FixStrategy int
+
+const (
+	// FixAuto uses MakeValid and falls back to buffer(0) when MakeValid turns
+	// a polygonal input into a non-polygonal result, e.g. by keeping
+	// collapsed parts as lines
+	FixAuto FixStrategy = iota
+	// FixMakeValid repairs with MakeValid, which keeps every vertex and all
+	// of the area enclosed by self-intersecting rings
+	FixMakeValid
+	// FixBuffer0 repairs polygons with the classic buffer(0) trick, which
+	// drops parts of self-intersecting rings with the opposite orientation,
+	// such as one loop of a bow-tie
+	FixBuffer0
+)
+
+// String returns the lowercase name of the strategy
+func (f FixStrategy) String() string {
+	switch f {
+	case FixAuto:
+		return "auto"
+	case FixMakeValid:
+		return "makevalid"
+	case FixBuffer0:
+		return "buffer0"
+	}
+	return fmt.Sprintf("FixStrategy(%d)", int(f))
+}
+
+// FixResult is the outcome of FixSelfIntersections
+type FixResult struct {
+	// Geometry is the repaired geometry
+	Geometry *Geometry
+
+	// Strategy is the strategy that produced Geometry; never FixAuto
+	Strategy FixStrategy
+
+	// AreaBefore is the area of the input as computed by GEOS; for
+	// self-intersecting rings, loops of opposite orientation cancel out
+	AreaBefore float64
+
+	// AreaAfter is the area of the repaired geometry
+	AreaAfter float64
+
+	// AreaDelta is AreaAfter minus AreaBefore
+	AreaDelta float64
+}
+
+// FixSelfIntersections repairs a geometry with a chosen strategy and reports
+// the evidence for quality assurance: which strategy was applied and how the
+// area changed. MakeValid and buffer(0) disagree on self-intersecting
+// polygons: a bow-tie becomes both triangles with MakeValid but only one with
+// buffer(0), so recording the choice makes repairs reviewable.
+//
+// Parameters:
+//   - geom: The geometry to repair, e.g. parsed with GeometryInput.AllowInvalid
+//   - strategy: The repair strategy; FixBuffer0 requires a polygonal geometry
+//
+// Returns:
+//   - FixResult: The repaired geometry, the strategy used and the area change
+//   - error: An error if the strategy does not apply or the operation fails
+//
+// Example:
+//
+//	bowtie := GeometryInput{WKT: "POLYGON((0 0, 2 2, 2 0, 0 2, 0 0))", AllowInvalid: true}
+//	geom, _ := service.ParseGeometry(bowtie)
+//
+//	result, err := service.FixSelfIntersections(geom, geos.FixBuffer0)
+//	// result.AreaAfter will be 1: buffer(0) kept a single triangle
+//	log.Printf("fixed with %s, area changed by %g", result.Strategy, result.AreaDelta)
+func (s *Service) FixSelfIntersections(geom *Geometry, strategy FixStrategy) (FixResult, error) {
+	if geom == nil || geom.geom == nil {
+		return FixResult{}, errors.New("invalid geometry")
+	}
+
+	switch strategy {
+	case FixAuto, FixMakeValid, FixBuffer0:
+	default:
+		return FixResult{}, fmt.Errorf("unknown fix strategy %d", int(strategy))
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return FixResult{}, err
+	}
+	defer c.release()
+
+	polygonal := isPolygonalType(C.GEOSGeomTypeId_r(c.context, geom.geom))
+	if strategy == FixBuffer0 && !polygonal {
+		return FixResult{}, errors.New("buffer(0) only repairs polygonal geometries")
+	}
+
+	var fixed *C.GEOSGeometry
+	used := strategy
+	if strategy != FixBuffer0 {
+		used = FixMakeValid
+		if fixed = C.GEOSMakeValid_r(c.context, geom.geom); fixed == nil {
+			return FixResult{}, c.geosError("failed to make geometry valid")
+		}
+		if strategy == FixAuto && polygonal && !isPolygonalType(C.GEOSGeomTypeId_r(c.context, fixed)) {
+			C.GEOSGeom_destroy_r(c.context, fixed)
+			fixed = nil
+			used = FixBuffer0
+		}
+	}
+	if fixed == nil {
+		if fixed = C.GEOSBuffer_r(c.context, geom.geom, 0, defaultQuadrantSegments); fixed == nil {
+			return FixResult{}, c.geosError("failed to buffer geometry by 0")
+		}
+	}
+
+	var before, after C.double
+	if C.GEOSArea_r(c.context, geom.geom, &before) == 0 || C.GEOSArea_r(c.context, fixed, &after) == 0 {
+		C.GEOSGeom_destroy_r(c.context, fixed)
+		return FixResult{}, c.geosError("failed to calculate area")
+	}
+
+	return FixResult{
+		Geometry:   c.newDerivedGeometry("FixSelfIntersections", map[string]any{"strategy": strategy.String(), "used": used.String()}, fixed, geom),
+		Strategy:   used,
+		AreaBefore: float64(before),
+		AreaAfter:  float64(after),
+		AreaDelta:  float64(after - before),
+	}, nil
+}
+
+// isPolygonalType reports whether a GEOS type id is Polygon or MultiPolygon
+func isPolygonalType(typeID C.int) bool {
+	return typeID == C.GEOS_POLYGON || typeID == C.GEOS_MULTIPOLYGON
+}
+
+// IsValid tests whether a geometry is valid according to the OGC rules, e.g.
+// polygon rings must not cross and holes must lie inside their shell.
+// ParseGeometry already rejects invalid input, so this is mainly useful for
+// geometries read by other means, such as FromWKBArray, or parsed with
+// GeometryInput.AllowInvalid.
+//
+// Parameters:
+//   - geom: The geometry to test
+//
+// Returns:
+//   - bool: True if the geometry is valid, false otherwise
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	geoms, _ := service.FromWKBArray(column)
+//	valid, err := service.IsValid(geoms[0])
+//	if err == nil && !valid {
+//		geoms[0], err = service.MakeValid(geoms[0])
+//	}
+func (s *Service) IsValid(geom *Geometry) (bool, error) {
+	if geom == nil || geom.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+
+	result := C.GEOSisValid_r(c.context, geom.geom)
+	if result == 2 { // Error case
+		return false, c.geosError("GEOS is valid operation failed")
+	}
+
+	return result == 1, nil
+}
+
+// IsSimple tests whether a geometry has no anomalous points such as
+// self-intersections or self-tangency. Lines that cross or touch themselves,
+// as raw GPS traces often do, are not simple; closed lines are simple as long
+// as they only meet at their endpoints. Use SelfIntersections to find where a
+// geometry fails the test.
+//
+// Parameters:
+//   - geom: The geometry to test
+//
+// Returns:
+//   - bool: True if the geometry is simple, false otherwise
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	trace := GeometryInput{WKT: "LINESTRING(0 0, 2 2, 2 0, 0 2)"}
+//	geom, _ := service.ParseGeometry(trace)
+//
+//	simple, err := service.IsSimple(geom)
+//	// simple will be false since the trace crosses itself at (1 1)
+func (s *Service) IsSimple(geom *Geometry) (bool, error) {
+	if geom == nil || geom.geom == nil {
+		return false, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return false, err
+	}
+	defer c.release()
+
+	result := C.GEOSisSimple_r(c.context, geom.geom)
+	if result == 2 { // Error case
+		return false, c.geosError("GEOS is simple operation failed")
+	}
+
+	return result == 1, nil
+}
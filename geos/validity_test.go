@@ -0,0 +1,249 @@
+package geos
+
+import (
+	"math"
+	"testing"
+)
+
+// TestSelfIntersections tests reporting of self-intersection locations
+func TestSelfIntersections(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected string
+	}{
+		{"Simple line", "LINESTRING(0 0, 1 1, 2 0)", "MULTIPOINT EMPTY"},
+		{"Crossing line", "LINESTRING(0 0, 2 2, 2 0, 0 2)", "MULTIPOINT ((1 1))"},
+		{"Line crossing and touching", "LINESTRING(0 0, 4 4, 4 0, 0 4, 0 2, 4 2)", "MULTIPOINT ((2 2), (4 2))"},
+		{"Closed line", "LINESTRING(0 0, 1 0, 1 1, 0 0)", "MULTIPOINT EMPTY"},
+		{"Line doubling back", "LINESTRING(0 0, 2 0, 1 0)", "MULTIPOINT ((1 0), (2 0))"},
+		{"Line retracing a stretch", "LINESTRING(0 0, 4 0, 4 1, 3 0, 1 0, 1 1)", "MULTIPOINT ((1 0), (3 0))"},
+		{"Valid polygon", "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))", "MULTIPOINT EMPTY"},
+		{"Hole touching shell", "POLYGON((0 0, 4 0, 4 4, 0 4, 0 0), (0 2, 2 1, 2 3, 0 2))", "MULTIPOINT ((0 2))"},
+		{"Point", "POINT(1 1)", "MULTIPOINT EMPTY"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.wkt)
+
+			points, err := helper.service.SelfIntersections(geom)
+			if err != nil {
+				t.Fatalf("Failed to find self-intersections: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(points, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.SelfIntersections(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestMakeValid tests repairing invalid geometries
+func TestMakeValid(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	bowtie := GeometryInput{WKT: "POLYGON((0 0, 2 2, 2 0, 0 2, 0 0))"}
+	if _, err := helper.service.ParseGeometry(bowtie); err == nil {
+		t.Fatal("Expected ParseGeometry to reject an invalid polygon")
+	}
+
+	bowtie.AllowInvalid = true
+	invalid, err := helper.service.ParseGeometry(bowtie)
+	if err != nil {
+		t.Fatalf("Failed to parse invalid polygon with AllowInvalid: %v", err)
+	}
+
+	testCases := []struct {
+		name string
+		fix  func(*Geometry) (*Geometry, error)
+	}{
+		{"Default", helper.service.MakeValid},
+		{"Linework", func(g *Geometry) (*Geometry, error) {
+			return helper.service.MakeValidWithParams(g, MakeValidOptions{Method: MakeValidLinework})
+		}},
+		{"Structure", func(g *Geometry) (*Geometry, error) {
+			return helper.service.MakeValidWithParams(g, MakeValidOptions{Method: MakeValidStructure, KeepCollapsed: true})
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fixed, err := tc.fix(invalid)
+			if err != nil {
+				t.Fatalf("Failed to make geometry valid: %v", err)
+			}
+
+			// Both triangles of the bow-tie must survive the repair
+			for _, wkt := range []string{"POINT(0.5 1)", "POINT(1.5 1)"} {
+				within, err := helper.service.Within(helper.ParseWKT(wkt), fixed)
+				if err != nil {
+					t.Fatalf("Failed to test within: %v", err)
+				}
+				if !within {
+					t.Errorf("Expected %s to lie within the repaired polygon", wkt)
+				}
+			}
+
+			wkt, err := helper.service.ToWKT(fixed)
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if _, err := helper.service.ParseGeometry(GeometryInput{WKT: wkt}); err != nil {
+				t.Errorf("Expected repaired geometry to be valid, got %v", err)
+			}
+		})
+	}
+
+	if _, err := helper.service.MakeValidWithParams(invalid, MakeValidOptions{Method: MakeValidMethod(7)}); err == nil {
+		t.Error("Expected error for unknown method")
+	}
+	if _, err := helper.service.MakeValid(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestIsValid tests OGC validity checks
+func TestIsValid(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected bool
+	}{
+		{"Square", "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0))", true},
+		{"Bow-tie", "POLYGON((0 0, 2 2, 2 0, 0 2, 0 0))", false},
+		{"Hole outside shell", "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0), (5 5, 6 5, 6 6, 5 5))", false},
+		{"Self-crossing line", "LINESTRING(0 0, 2 2, 2 0, 0 2)", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom, err := helper.service.ParseGeometry(GeometryInput{WKT: tc.wkt, AllowInvalid: true})
+			if err != nil {
+				t.Fatalf("Failed to parse geometry: %v", err)
+			}
+			valid, err := helper.service.IsValid(geom)
+			if err != nil {
+				t.Fatalf("Failed to test validity: %v", err)
+			}
+			if valid != tc.expected {
+				t.Errorf("Expected IsValid %v, got %v", tc.expected, valid)
+			}
+		})
+	}
+
+	if _, err := helper.service.IsValid(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestIsSimple tests detection of self-intersecting geometries
+func TestIsSimple(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		expected bool
+	}{
+		{"Straight line", "LINESTRING(0 0, 1 1, 2 0)", true},
+		{"Crossing line", "LINESTRING(0 0, 2 2, 2 0, 0 2)", false},
+		{"Self-touching line", "LINESTRING(0 0, 2 0, 2 2, 1 0)", false},
+		{"Closed line", "LINESTRING(0 0, 1 0, 1 1, 0 0)", true},
+		{"Crossing multilinestring", "MULTILINESTRING((0 0, 2 2), (0 2, 2 0))", false},
+		{"Distinct multipoint", "MULTIPOINT((0 0), (1 1))", true},
+		{"Repeated multipoint", "MULTIPOINT((0 0), (0 0))", false},
+		{"Point", "POINT(1 1)", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			simple, err := helper.service.IsSimple(helper.ParseWKT(tc.wkt))
+			if err != nil {
+				t.Fatalf("Failed to test simplicity: %v", err)
+			}
+			if simple != tc.expected {
+				t.Errorf("Expected IsSimple %v, got %v", tc.expected, simple)
+			}
+		})
+	}
+
+	if _, err := helper.service.IsSimple(nil); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestFixSelfIntersections tests repair strategies and their area evidence
+func TestFixSelfIntersections(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	parse := func(wkt string) *Geometry {
+		geom, err := helper.service.ParseGeometry(GeometryInput{WKT: wkt, AllowInvalid: true})
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", wkt, err)
+		}
+		return geom
+	}
+
+	bowtie := "POLYGON((0 0, 2 2, 2 0, 0 2, 0 0))"
+	spike := "POLYGON((0 0, 2 0, 2 2, 0 2, 0 0, -1 -1, 0 0))"
+
+	testCases := []struct {
+		name      string
+		wkt       string
+		strategy  FixStrategy
+		used      FixStrategy
+		areaAfter float64
+	}{
+		{"MakeValid keeps both loops", bowtie, FixMakeValid, FixMakeValid, 2},
+		{"Buffer0 keeps one loop", bowtie, FixBuffer0, FixBuffer0, 1},
+		{"Auto prefers MakeValid", bowtie, FixAuto, FixMakeValid, 2},
+		{"Auto falls back for collapsed parts", spike, FixAuto, FixBuffer0, 4},
+		{"Auto repairs lines with MakeValid", "LINESTRING(0 0, 1 1)", FixAuto, FixMakeValid, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := helper.service.FixSelfIntersections(parse(tc.wkt), tc.strategy)
+			if err != nil {
+				t.Fatalf("Failed to fix: %v", err)
+			}
+			if result.Strategy != tc.used {
+				t.Errorf("Expected strategy %s, got %s", tc.used, result.Strategy)
+			}
+			if math.Abs(result.AreaAfter-tc.areaAfter) > 1e-9 {
+				t.Errorf("Expected area %g, got %g", tc.areaAfter, result.AreaAfter)
+			}
+			if math.Abs(result.AreaDelta-(result.AreaAfter-result.AreaBefore)) > 1e-9 {
+				t.Errorf("Inconsistent area delta %g", result.AreaDelta)
+			}
+			if valid, err := helper.service.IsValid(result.Geometry); err != nil || !valid {
+				t.Errorf("Expected a valid result, got %v (%v)", valid, err)
+			}
+		})
+	}
+
+	if _, err := helper.service.FixSelfIntersections(helper.LineGeometry(), FixBuffer0); err == nil {
+		t.Error("Expected error for buffer(0) on a line")
+	}
+	if _, err := helper.service.FixSelfIntersections(helper.PolygonGeometry(), FixStrategy(42)); err == nil {
+		t.Error("Expected error for an unknown strategy")
+	}
+}
@@ -0,0 +1,306 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Densify inserts vertices into the segments of a geometry so that no segment
+// is longer than maxSegmentLength. Each long segment is split into equal
+// pieces, so the shape is unchanged. Densifying is needed before reprojecting,
+// where straight segments should follow curves in the target projection, and
+// it makes vertex-based measures like HausdorffDistance more accurate.
+//
+// Parameters:
+//   - geom: The geometry to densify
+//   - maxSegmentLength: The longest allowed segment (must be positive)
+//
+// Returns:
+//   - *Geometry: A new geometry with the same shape and more vertices
+//   - error: An error if the length is not positive or the operation fails
+//
+// Example:
+//
+//	line := GeometryInput{WKT: "LINESTRING(0 0, 10 0)"}
+//	geom, _ := service.ParseGeometry(line)
+//
+//	dense, err := service.Densify(geom, 3.0)
+//	// dense will be LINESTRING (0 0, 2.5 0, 5 0, 7.5 0, 10 0)
+func (s *Service) Densify(geom *Geometry, maxSegmentLength float64) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if !(maxSegmentLength > 0) || math.IsInf(maxSegmentLength, 0) {
+		return nil, fmt.Errorf("max segment length must be positive, got %g", maxSegmentLength)
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	dense := C.GEOSDensify_r(c.context, geom.geom, C.double(maxSegmentLength))
+	if dense == nil {
+		return nil, c.geosError("failed to densify geometry")
+	}
+
+	return c.newDerivedGeometry("Densify", map[string]any{"maxSegmentLength": maxSegmentLength}, dense, geom), nil
+}
+
+// RemoveRepeatedPoints removes consecutive vertices that lie within tolerance
+// of the previous kept vertex. Digitizing tools and GPS receivers often emit
+// duplicate or nearly identical vertices, which can make overlay operations
+// fail with topology errors; cleaning them first avoids that. The first and
+// last vertex of a line are always kept, so rings stay closed.
+//
+// Parameters:
+//   - geom: The geometry to clean
+//   - tolerance: The distance below which vertices count as repeated; 0 removes exact duplicates only
+//
+// Returns:
+//   - *Geometry: A new geometry without repeated vertices
+//   - error: An error if the tolerance is negative or the operation fails
+//
+// Example:
+//
+//	trace := GeometryInput{WKT: "LINESTRING(0 0, 0 0, 1 0, 1.001 0, 2 0)"}
+//	geom, _ := service.ParseGeometry(trace)
+//
+//	cleaned, err := service.RemoveRepeatedPoints(geom, 0.01)
+//	// cleaned will be LINESTRING (0 0, 1 0, 2 0)
+func (s *Service) RemoveRepeatedPoints(geom *Geometry, tolerance float64) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if tolerance < 0 || math.IsNaN(tolerance) {
+		return nil, fmt.Errorf("tolerance must not be negative, got %g", tolerance)
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	cleaned := C.GEOSRemoveRepeatedPoints_r(c.context, geom.geom, C.double(tolerance))
+	if cleaned == nil {
+		return nil, c.geosError("failed to remove repeated points")
+	}
+
+	return c.newDerivedGeometry("RemoveRepeatedPoints", map[string]any{"tolerance": tolerance}, cleaned, geom), nil
+}
+
+// SnapToGrid rounds every coordinate of a geometry to the nearest point of a
+// square grid, then repairs the result. Snapping merges vertices that fall
+// into the same cell, which deduplicates near-identical vertices, and caps the
+// precision of the output deliberately, e.g. to centimeters before storage or
+// to shrink the size of WKT and GeoJSON. Components that collapse, such as
+// polygons narrower than a cell, are removed, and the result is made valid if
+// snapping produced invalid topology.
+//
+// Parameters:
+//   - geom: The geometry to snap
+//   - cellSize: The spacing of the grid (must be positive)
+//   - originX, originY: A point on the grid, so grids need not be aligned to 0
+//
+// Returns:
+//   - *Geometry: A new valid geometry with snapped coordinates
+//   - error: An error if the grid is malformed or the operation fails
+//
+// Example:
+//
+//	line := GeometryInput{WKT: "LINESTRING(0.12 0.04, 0.98 0.11, 1.02 0.09, 2.1 0.2)"}
+//	geom, _ := service.ParseGeometry(line)
+//
+//	snapped, err := service.SnapToGrid(geom, 0.5, 0, 0)
+//	// snapped will be LINESTRING (0 0, 1 0, 2 0)
+func (s *Service) SnapToGrid(geom *Geometry, cellSize, originX, originY float64) (*Geometry, error) {
+	if geom == nil || geom.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+
+	if !(cellSize > 0) || math.IsInf(cellSize, 0) {
+		return nil, fmt.Errorf("cell size must be positive, got %g", cellSize)
+	}
+	if math.IsNaN(originX) || math.IsInf(originX, 0) || math.IsNaN(originY) || math.IsInf(originY, 0) {
+		return nil, fmt.Errorf("grid origin must be finite, got (%g %g)", originX, originY)
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	// GEOS grids are anchored at 0, so move the origin there and back
+	sh, err := c.readShape(geom.geom)
+	if err != nil {
+		return nil, err
+	}
+	sh.transformXY(func(x, y float64) (float64, float64) { return x - originX, y - originY })
+	shifted, err := c.buildShape(sh)
+	if err != nil {
+		return nil, err
+	}
+	snapped := C.GEOSGeom_setPrecision_r(c.context, shifted, C.double(cellSize), 0)
+	C.GEOSGeom_destroy_r(c.context, shifted)
+	if snapped == nil {
+		return nil, c.geosError("failed to snap geometry to grid")
+	}
+	sh, err = c.readShape(snapped)
+	C.GEOSGeom_destroy_r(c.context, snapped)
+	if err != nil {
+		return nil, err
+	}
+	sh.transformXY(func(x, y float64) (float64, float64) { return x + originX, y + originY })
+	result, err := c.buildShape(sh)
+	if err != nil {
+		return nil, err
+	}
+
+	switch C.GEOSisValid_r(c.context, result) {
+	case 0:
+		valid := C.GEOSMakeValid_r(c.context, result)
+		C.GEOSGeom_destroy_r(c.context, result)
+		if valid == nil {
+			return nil, c.geosError("failed to make snapped geometry valid")
+		}
+		result = valid
+	case 2: // Error case
+		C.GEOSGeom_destroy_r(c.context, result)
+		return nil, c.geosError("failed to check validity of snapped geometry")
+	}
+
+	return c.newDerivedGeometry("SnapToGrid", map[string]any{"cellSize": cellSize, "originX": originX, "originY": originY}, result, geom), nil
+}
+
+// NumPoints returns the number of vertices of a LineString or LinearRing.
+//
+// Parameters:
+//   - line: The LineString or LinearRing to count
+//
+// Returns:
+//   - int: The number of vertices
+//   - error: An error if the geometry is not a LineString or the operation fails
+//
+// Example:
+//
+//	simplified, _ := service.Simplify(track, 0.5)
+//	n, err := service.NumPoints(simplified)
+//	// n is the number of vertices left after simplification
+func (s *Service) NumPoints(line *Geometry) (int, error) {
+	if line == nil || line.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+	if !line.IsLineString() {
+		return 0, fmt.Errorf("geometry must be a LineString or LinearRing, got %v", line.Type())
+	}
+
+	c, err := s.acquire(line)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+
+	n := C.GEOSGeomGetNumPoints_r(c.context, line.geom)
+	if n < 0 {
+		return 0, c.geosError("failed to count points")
+	}
+
+	return int(n), nil
+}
+
+// PointN returns the vertex at index n of a LineString or LinearRing as a
+// Point, keeping its Z and M values.
+//
+// Parameters:
+//   - line: The LineString or LinearRing to take the vertex from
+//   - n: The zero-based index of the vertex
+//
+// Returns:
+//   - *Geometry: A new Point at the vertex
+//   - error: An error if the geometry is not a LineString, the index is out
+//     of range or the operation fails
+//
+// Example:
+//
+//	line := GeometryInput{WKT: "LINESTRING(0 0, 5 5, 10 0)"}
+//	geom, _ := service.ParseGeometry(line)
+//
+//	apex, err := service.PointN(geom, 1)
+//	// apex will be POINT (5 5)
+func (s *Service) PointN(line *Geometry, n int) (*Geometry, error) {
+	if line == nil || line.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	if !line.IsLineString() {
+		return nil, fmt.Errorf("geometry must be a LineString or LinearRing, got %v", line.Type())
+	}
+
+	c, err := s.acquire(line)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	count := C.GEOSGeomGetNumPoints_r(c.context, line.geom)
+	if count < 0 {
+		return nil, c.geosError("failed to count points")
+	}
+	if n < 0 || n >= int(count) {
+		return nil, fmt.Errorf("point index %d out of range [0, %d)", n, int(count))
+	}
+
+	point := C.GEOSGeomGetPointN_r(c.context, line.geom, C.int(n))
+	if point == nil {
+		return nil, c.geosError("failed to get point")
+	}
+
+	return c.newDerivedGeometry("PointN", map[string]any{"n": n}, point, line), nil
+}
+
+// NumCoordinates returns the total number of coordinates of any geometry,
+// counting every ring of a polygon and every component of a collection. The
+// closing coordinate of a ring counts separately.
+//
+// Parameters:
+//   - geom: The geometry to count
+//
+// Returns:
+//   - int: The number of coordinates, 0 for an empty geometry
+//   - error: An error if the operation fails
+//
+// Example:
+//
+//	before, _ := service.NumCoordinates(parcels)
+//	simplified, _ := service.SimplifyPreserveTopology(parcels, 1)
+//	after, _ := service.NumCoordinates(simplified)
+//	fmt.Printf("removed %d of %d vertices\n", before-after, before)
+func (s *Service) NumCoordinates(geom *Geometry) (int, error) {
+	if geom == nil || geom.geom == nil {
+		return 0, errors.New("invalid geometry")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return 0, err
+	}
+	defer c.release()
+
+	n := C.GEOSGetNumCoordinates_r(c.context, geom.geom)
+	if n < 0 {
+		return 0, c.geosError("failed to count coordinates")
+	}
+
+	return int(n), nil
+}
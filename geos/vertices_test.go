@@ -0,0 +1,210 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestDensify tests inserting vertices into long segments
+func TestDensify(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name      string
+		wkt       string
+		maxLength float64
+		expected  string
+	}{
+		{"Split line", "LINESTRING(0 0, 10 0)", 3, "LINESTRING (0 0, 2.5 0, 5 0, 7.5 0, 10 0)"},
+		{"Short segments unchanged", "LINESTRING(0 0, 1 0, 2 0)", 3, "LINESTRING (0 0, 1 0, 2 0)"},
+		{"Polygon", "POLYGON((0 0, 4 0, 4 4, 0 4, 0 0))", 2, "POLYGON ((0 0, 2 0, 4 0, 4 2, 4 4, 2 4, 0 4, 0 2, 0 0))"},
+		{"Point", "POINT(1 1)", 1, "POINT (1 1)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dense, err := helper.service.Densify(helper.ParseWKT(tc.wkt), tc.maxLength)
+			if err != nil {
+				t.Fatalf("Failed to densify: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(dense, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	for _, length := range []float64{0, -1} {
+		if _, err := helper.service.Densify(helper.LineGeometry(), length); err == nil {
+			t.Errorf("Expected error for max segment length %g", length)
+		}
+	}
+	if _, err := helper.service.Densify(nil, 1); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestRemoveRepeatedPoints tests cleaning duplicate vertices
+func TestRemoveRepeatedPoints(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name      string
+		wkt       string
+		tolerance float64
+		expected  string
+	}{
+		{"Exact duplicates", "LINESTRING(0 0, 0 0, 1 0, 1 0, 2 0)", 0, "LINESTRING (0 0, 1 0, 2 0)"},
+		{"Near duplicates kept without tolerance", "LINESTRING(0 0, 1 0, 1.001 0, 2 0)", 0, "LINESTRING (0 0, 1 0, 1.001 0, 2 0)"},
+		{"Near duplicates", "LINESTRING(0 0, 0 0, 1 0, 1.001 0, 2 0)", 0.01, "LINESTRING (0 0, 1 0, 2 0)"},
+		{"Polygon stays closed", "POLYGON((0 0, 2 0, 2 0, 2 2, 0 2, 0 0))", 0, "POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cleaned, err := helper.service.RemoveRepeatedPoints(helper.ParseWKT(tc.wkt), tc.tolerance)
+			if err != nil {
+				t.Fatalf("Failed to remove repeated points: %v", err)
+			}
+
+			wkt, err := helper.service.ToWKTWithOptions(cleaned, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	if _, err := helper.service.RemoveRepeatedPoints(helper.LineGeometry(), -1); err == nil {
+		t.Error("Expected error for negative tolerance")
+	}
+	if _, err := helper.service.RemoveRepeatedPoints(nil, 0); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestSnapToGrid tests snapping coordinates to a grid with an origin
+func TestSnapToGrid(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		wkt      string
+		cellSize float64
+		originX  float64
+		originY  float64
+		expected string
+	}{
+		{"Merge near vertices", "LINESTRING(0.12 0.04, 0.98 0.11, 1.02 0.09, 2.1 0.2)", 0.5, 0, 0, "LINESTRING (0 0, 1 0, 2 0)"},
+		{"Shifted origin", "POINT(1.3 2.6)", 1, 0.5, 0.5, "POINT (1.5 2.5)"},
+		{"Polygon", "POLYGON((0.1 0.1, 3.9 0.2, 4.1 3.8, -0.2 4.1, 0.1 0.1))", 1, 0, 0, "POLYGON ((0 0, 0 4, 4 4, 4 0, 0 0))"},
+		{"Collapsed polygon", "POLYGON((0 0, 0.2 0, 0.2 0.2, 0 0.2, 0 0))", 1, 0, 0, "POLYGON EMPTY"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			snapped, err := helper.service.SnapToGrid(helper.ParseWKT(tc.wkt), tc.cellSize, tc.originX, tc.originY)
+			if err != nil {
+				t.Fatalf("Failed to snap to grid: %v", err)
+			}
+
+			normalized, err := helper.service.Normalize(snapped)
+			if err != nil {
+				t.Fatalf("Failed to normalize: %v", err)
+			}
+			wkt, err := helper.service.ToWKTWithOptions(normalized, WKTOptions{Trim: true})
+			if err != nil {
+				t.Fatalf("Failed to convert to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, wkt)
+			}
+		})
+	}
+
+	for _, size := range []float64{0, -1} {
+		if _, err := helper.service.SnapToGrid(helper.LineGeometry(), size, 0, 0); err == nil {
+			t.Errorf("Expected error for cell size %g", size)
+		}
+	}
+	if _, err := helper.service.SnapToGrid(nil, 1, 0, 0); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
+
+// TestPointN tests counting and reading the vertices of lines
+func TestPointN(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	line := helper.ParseWKT("LINESTRING Z (0 0 1, 5 5 2, 10 0 3)")
+
+	n, err := helper.service.NumPoints(line)
+	if err != nil {
+		t.Fatalf("Failed to count points: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("Expected 3 points, got %d", n)
+	}
+
+	expected := []string{"POINT Z (0 0 1)", "POINT Z (5 5 2)", "POINT Z (10 0 3)"}
+	for i, want := range expected {
+		point, err := helper.service.PointN(line, i)
+		if err != nil {
+			t.Fatalf("Failed to get point %d: %v", i, err)
+		}
+		wkt, err := helper.service.ToWKTWithOptions(point, WKTOptions{Trim: true})
+		if err != nil {
+			t.Fatalf("Failed to convert to WKT: %v", err)
+		}
+		if wkt != want {
+			t.Errorf("Point %d: expected %s, got %s", i, want, wkt)
+		}
+	}
+
+	for _, i := range []int{-1, 3} {
+		if _, err := helper.service.PointN(line, i); err == nil {
+			t.Errorf("Expected error for index %d", i)
+		}
+	}
+	if _, err := helper.service.NumPoints(helper.PolygonGeometry()); err == nil {
+		t.Error("Expected error for polygon")
+	}
+}
+
+// TestNumCoordinates tests counting the coordinates of any geometry
+func TestNumCoordinates(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		wkt      string
+		expected int
+	}{
+		{"POINT(1 1)", 1},
+		{"LINESTRING(0 0, 1 1, 2 2)", 3},
+		{"POLYGON((0 0, 4 0, 4 4, 0 4, 0 0), (1 1, 2 1, 2 2, 1 1))", 9},
+		{"GEOMETRYCOLLECTION(POINT(0 0), LINESTRING(0 0, 1 1))", 3},
+		{"POLYGON EMPTY", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.wkt, func(t *testing.T) {
+			n, err := helper.service.NumCoordinates(helper.ParseWKT(tc.wkt))
+			if err != nil {
+				t.Fatalf("Failed to count coordinates: %v", err)
+			}
+			if n != tc.expected {
+				t.Errorf("Expected %d coordinates, got %d", tc.expected, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// RadialPolygon approximates the region visible from a point within a radius
+// when the given obstacles block the line of sight, as used for simple
+// coverage and visibility maps. Rays are cast at evenly spaced angles and each
+// one stops at the first obstacle it hits; the ray ends form the returned
+// polygon. More rays follow obstacle edges more closely but cost more.
+//
+// Parameters:
+//   - center: The observer Point, which must not lie in or on an obstacle
+//   - radius: The maximum visible distance (must be positive)
+//   - obstacles: The blocking geometries, usually polygons
+//   - rays: The number of rays to cast (at least 3)
+//
+// Returns:
+//   - *Geometry: The visible region as a Polygon
+//   - error: An error if an input is invalid or the operation fails
+//
+// Example:
+//
+//	observer, _ := service.ParseGeometry(GeometryInput{WKT: "POINT(0 0)"})
+//	wall, _ := service.ParseGeometry(GeometryInput{WKT: "POLYGON((2 -1, 3 -1, 3 1, 2 1, 2 -1))"})
+//
+//	visible, err := service.RadialPolygon(observer, 10, []*Geometry{wall}, 360)
+//	// visible is a disc of radius 10 with a shadow behind the wall
+func (s *Service) RadialPolygon(center *Geometry, radius float64, obstacles []*Geometry, rays int) (*Geometry, error) {
+	if center == nil || center.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	for i, obstacle := range obstacles {
+		if obstacle == nil || obstacle.geom == nil {
+			return nil, fmt.Errorf("invalid obstacle at index %d", i)
+		}
+	}
+
+	if !(radius > 0) || math.IsInf(radius, 0) {
+		return nil, fmt.Errorf("radius must be positive, got %g", radius)
+	}
+	if rays < 3 {
+		return nil, fmt.Errorf("at least 3 rays are required, got %d", rays)
+	}
+
+	c, err := s.acquire(center)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+	if err := c.checkState(obstacles...); err != nil {
+		return nil, err
+	}
+
+	if C.GEOSGeomTypeId_r(c.context, center.geom) != C.GEOS_POINT || C.GEOSisEmpty_r(c.context, center.geom) != 0 {
+		return nil, errors.New("center must be a non-empty Point")
+	}
+	var cx, cy C.double
+	if C.GEOSGeomGetX_r(c.context, center.geom, &cx) == 0 || C.GEOSGeomGetY_r(c.context, center.geom, &cy) == 0 {
+		return nil, c.geosError("failed to read center coordinates")
+	}
+	x0, y0 := float64(cx), float64(cy)
+
+	// Only obstacles within reach can block a ray
+	reach := envelope{x0, y0, x0, y0}.expand(radius)
+	var blockers []*C.GEOSGeometry
+	var envs []envelope
+	for _, obstacle := range obstacles {
+		env, ok, err := c.envelopeOf(obstacle.geom)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || !env.intersects(reach) {
+			continue
+		}
+		inside := C.GEOSIntersects_r(c.context, obstacle.geom, center.geom)
+		if inside == 2 {
+			return nil, c.geosError("GEOS intersects operation failed")
+		}
+		if inside == 1 {
+			return nil, errors.New("center lies in or on an obstacle")
+		}
+		blockers = append(blockers, obstacle.geom)
+		envs = append(envs, env)
+	}
+
+	ring := make([]float64, 0, 2*(rays+1))
+	for i := 0; i < rays; i++ {
+		angle := 2 * math.Pi * float64(i) / float64(rays)
+		dx, dy := math.Cos(angle), math.Sin(angle)
+
+		length, err := c.castRay(center.geom, x0, y0, dx, dy, radius, blockers, envs)
+		if err != nil {
+			return nil, err
+		}
+		ring = append(ring, x0+dx*length, y0+dy*length)
+	}
+	ring = append(ring, ring[0], ring[1])
+
+	polygon, err := c.buildShape(&shape{
+		typeID: C.GEOS_POLYGON,
+		parts:  []*shape{{typeID: C.GEOS_LINEARRING, coords: ring}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newDerivedGeometry("RadialPolygon", map[string]any{"radius": radius, "rays": rays}, polygon, append([]*Geometry{center}, obstacles...)...), nil
+}
+
+// castRay returns the distance along a ray from (x0, y0) in direction
+// (dx, dy) to the first obstacle it hits, or radius if it hits none.
+func (c *session) castRay(center *C.GEOSGeometry, x0, y0, dx, dy, radius float64, blockers []*C.GEOSGeometry, envs []envelope) (float64, error) {
+	x1, y1 := x0+dx*radius, y0+dy*radius
+	bounds := envelope{math.Min(x0, x1), math.Min(y0, y1), math.Max(x0, x1), math.Max(y0, y1)}
+
+	var ray *C.GEOSGeometry
+	nearest := radius
+	for j, blocker := range blockers {
+		if !envs[j].intersects(bounds) {
+			continue
+		}
+		if ray == nil {
+			coords := []float64{x0, y0, x1, y1}
+			var err error
+			if ray, err = c.buildShape(&shape{typeID: C.GEOS_LINESTRING, coords: coords}); err != nil {
+				return 0, err
+			}
+			defer C.GEOSGeom_destroy_r(c.context, ray)
+		}
+
+		hit := C.GEOSIntersection_r(c.context, ray, blocker)
+		if hit == nil {
+			return 0, c.geosError("failed to intersect ray with obstacle")
+		}
+		if C.GEOSisEmpty_r(c.context, hit) == 0 {
+			var distance C.double
+			if C.GEOSDistance_r(c.context, center, hit, &distance) == 0 {
+				C.GEOSGeom_destroy_r(c.context, hit)
+				return 0, c.geosError("failed to measure distance to obstacle")
+			}
+			nearest = math.Min(nearest, float64(distance))
+		}
+		C.GEOSGeom_destroy_r(c.context, hit)
+	}
+
+	return nearest, nil
+}
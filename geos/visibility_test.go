@@ -0,0 +1,57 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestRadialPolygon tests line-of-sight regions around a point
+func TestRadialPolygon(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	center := helper.ParseWKT("POINT(0 0)")
+	wall := helper.ParseWKT("POLYGON((2 -1, 3 -1, 3 1, 2 1, 2 -1))")
+	farAway := helper.ParseWKT("POLYGON((100 100, 101 100, 101 101, 100 100))")
+
+	visible, err := helper.service.RadialPolygon(center, 10, []*Geometry{wall, farAway}, 360)
+	if err != nil {
+		t.Fatalf("Failed to compute visible region: %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		wkt     string
+		visible bool
+	}{
+		{"In front of the wall", "POINT(1 0)", true},
+		{"Behind the wall", "POINT(5 0)", false},
+		{"Beside the wall", "POINT(5 4)", true},
+		{"Opposite side", "POINT(-9 0)", true},
+		{"Beyond the radius", "POINT(0 11)", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			within, err := helper.service.Within(helper.ParseWKT(tc.wkt), visible)
+			if err != nil {
+				t.Fatalf("Failed to test within: %v", err)
+			}
+			if within != tc.visible {
+				t.Errorf("Expected visible %v, got %v", tc.visible, within)
+			}
+		})
+	}
+
+	if _, err := helper.service.RadialPolygon(helper.ParseWKT("POINT(2.5 0)"), 10, []*Geometry{wall}, 36); err == nil {
+		t.Error("Expected error for a center inside an obstacle")
+	}
+	if _, err := helper.service.RadialPolygon(center, 10, nil, 2); err == nil {
+		t.Error("Expected error for too few rays")
+	}
+	if _, err := helper.service.RadialPolygon(center, 0, nil, 36); err == nil {
+		t.Error("Expected error for zero radius")
+	}
+	if _, err := helper.service.RadialPolygon(wall, 10, nil, 36); err == nil {
+		t.Error("Expected error for a non-point center")
+	}
+}
@@ -0,0 +1,75 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// VoronoiDiagram computes the Voronoi diagram of the vertices of a geometry.
+// Each cell covers the area closer to its input point than to any other, which
+// partitions space into service areas or nearest-facility zones.
+//
+// Parameters:
+//   - points: The geometry whose vertices are the diagram sites
+//   - envelope: An optional geometry whose extent the diagram is expanded to
+//     cover (nil uses a default buffer around the sites)
+//   - tolerance: Snapping tolerance for robustness (0 for none)
+//   - edgesOnly: If true, return the cell edges as a MultiLineString instead
+//     of a GeometryCollection of polygons
+//
+// Returns:
+//   - *Geometry: A new geometry with the cells or their edges
+//   - error: An error if the tolerance is negative or the operation fails
+//
+// Example:
+//
+//	facilities := GeometryInput{WKT: "MULTIPOINT((0 0), (10 0), (5 10))"}
+//	region := GeometryInput{WKT: "POLYGON((-20 -20, 30 -20, 30 30, -20 30, -20 -20))"}
+//
+//	sites, _ := service.ParseGeometry(facilities)
+//	bounds, _ := service.ParseGeometry(region)
+//
+//	zones, err := service.VoronoiDiagram(sites, bounds, 0, false)
+//	// zones will be a GEOMETRYCOLLECTION of 3 polygons, one per facility
+func (s *Service) VoronoiDiagram(points, envelope *Geometry, tolerance float64, edgesOnly bool) (*Geometry, error) {
+	if points == nil || points.geom == nil {
+		return nil, errors.New("invalid geometry")
+	}
+	if envelope != nil && envelope.geom == nil {
+		return nil, errors.New("invalid envelope geometry")
+	}
+
+	if tolerance < 0 || math.IsNaN(tolerance) {
+		return nil, fmt.Errorf("tolerance must not be negative, got %g", tolerance)
+	}
+
+	c, err := s.acquire(points, envelope)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	var env *C.GEOSGeometry
+	if envelope != nil {
+		env = envelope.geom
+	}
+
+	var flags C.int
+	if edgesOnly {
+		flags = C.GEOS_VORONOI_ONLY_EDGES
+	}
+
+	diagram := C.GEOSVoronoiDiagram_r(c.context, points.geom, env, C.double(tolerance), flags)
+	if diagram == nil {
+		return nil, c.geosError("failed to compute Voronoi diagram")
+	}
+
+	return c.newDerivedGeometry("VoronoiDiagram", map[string]any{"tolerance": tolerance, "edgesOnly": edgesOnly}, diagram, points, envelope), nil
+}
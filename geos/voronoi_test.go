@@ -0,0 +1,36 @@
+package geos
+
+import (
+	"testing"
+)
+
+// TestVoronoiDiagram tests partitioning space around sites
+func TestVoronoiDiagram(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	sites := helper.ParseWKT("MULTIPOINT((0 0), (10 0), (5 10))")
+	bounds := helper.ParseWKT("POLYGON((-20 -20, 30 -20, 30 30, -20 30, -20 -20))")
+
+	cells, err := helper.service.VoronoiDiagram(sites, bounds, 0, false)
+	if err != nil {
+		t.Fatalf("Failed to compute Voronoi diagram: %v", err)
+	}
+
+	// The cells cover the whole envelope
+	helper.AssertWithin(bounds, helper.AssertUnion([]*Geometry{cells}), true)
+
+	edges, err := helper.service.VoronoiDiagram(sites, nil, 0, true)
+	if err != nil {
+		t.Fatalf("Failed to compute Voronoi edges: %v", err)
+	}
+	// The bisector between (0 0) and (10 0) runs along x = 5
+	helper.AssertIntersects(edges, helper.ParseWKT("POINT(5 -1)"), true)
+
+	if _, err := helper.service.VoronoiDiagram(sites, nil, -1, false); err == nil {
+		t.Error("Expected error for negative tolerance")
+	}
+	if _, err := helper.service.VoronoiDiagram(nil, nil, 0, false); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+}
@@ -0,0 +1,182 @@
+package geos
+
+/*
+#cgo pkg-config: geos
+#include <geos_c.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// WKTOptions controls how a geometry is written as Well-Known Text.
+// The zero value writes every ordinate the geometry carries using the
+// ISO dimension tags (POINT Z, POINT M, POINT ZM) at full precision.
+//
+// Example:
+//
+//	// Oracle Spatial and SQL Server expect 3D coordinates without the Z tag
+//	wkt, err := service.ToWKTWithOptions(geom, geos.WKTOptions{Old3D: true, Trim: true})
+//	// wkt will be "POINT (1 2 3)" instead of "POINT Z (1 2 3)"
+type WKTOptions struct {
+	// Dimension is the maximum number of ordinates written per coordinate
+	// (2, 3 or 4). Zero writes all available ordinates.
+	Dimension int `json:"dimension,omitempty"`
+
+	// Old3D writes 3D coordinates without the Z tag, the pre-ISO style
+	// understood by older consumers.
+	Old3D bool `json:"old3d,omitempty"`
+
+	// Trim removes insignificant trailing zeros from coordinate values.
+	Trim bool `json:"trim,omitempty"`
+
+	// Precision is the number of decimals written when greater than zero.
+	// Zero keeps full precision.
+	Precision int `json:"precision,omitempty"`
+
+	// FixedWidth writes every value with exactly Precision decimals, padding
+	// with zeros even when Trim is set. It requires a positive Precision.
+	FixedWidth bool `json:"fixed_width,omitempty"`
+}
+
+// ToWKTWithOptions converts a geometry to Well-Known Text using the given options.
+// It is the configurable counterpart of ToWKT, mainly used to control how Z and M
+// ordinates are tagged for consumers with different WKT dialects. Like ToWKT, the
+// output never uses scientific notation or locale-specific decimal separators.
+//
+// Parameters:
+//   - geom: The geometry object to convert
+//   - opts: Output options (dimension, old-style 3D, trimming, precision)
+//
+// Returns:
+//   - string: The WKT representation of the geometry
+//   - error: An error if the options are invalid or conversion fails
+//
+// Example:
+//
+//	geom, _ := service.ParseGeometry(GeometryInput{WKT: "POINT ZM (1 2 3 4)"})
+//
+//	wkt, err := service.ToWKTWithOptions(geom, WKTOptions{Trim: true})
+//	// wkt will be "POINT ZM (1 2 3 4)"
+//
+//	wkt, err = service.ToWKTWithOptions(geom, WKTOptions{Dimension: 3, Trim: true})
+//	// wkt will be "POINT Z (1 2 3)"
+func (s *Service) ToWKTWithOptions(geom *Geometry, opts WKTOptions) (string, error) {
+	if geom == nil || geom.geom == nil {
+		return "", errors.New("invalid geometry")
+	}
+
+	dimension := opts.Dimension
+	if dimension == 0 {
+		dimension = 4
+	}
+	if dimension < 2 || dimension > 4 {
+		return "", fmt.Errorf("invalid WKT output dimension: %d", opts.Dimension)
+	}
+	if opts.Precision < 0 {
+		return "", fmt.Errorf("invalid WKT precision: %d", opts.Precision)
+	}
+	if opts.FixedWidth && opts.Precision == 0 {
+		return "", errors.New("fixed-width WKT output requires a precision")
+	}
+
+	c, err := s.acquire(geom)
+	if err != nil {
+		return "", err
+	}
+	defer c.release()
+
+	writer, err := c.wktOptionsWriter(dimension, opts)
+	if err != nil {
+		return "", err
+	}
+
+	cWKT := C.GEOSWKTWriter_write_r(c.context, writer, geom.geom)
+	if cWKT == nil {
+		return "", c.geosError("failed to convert geometry to WKT")
+	}
+	defer C.GEOSFree_r(c.context, unsafe.Pointer(cWKT))
+
+	decimals := -1
+	if opts.FixedWidth {
+		decimals = opts.Precision
+	}
+
+	return formatCoordinates(C.GoString(cWKT), decimals), nil
+}
+
+// boolToCInt converts a Go bool into the 0/1 flag GEOS expects
+func boolToCInt(v bool) C.int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// formatCoordinates rewrites the numeric tokens of writer output so that values
+// are always plain decimals using '.' as separator. With decimals < 0 only
+// tokens in scientific notation are rewritten (shortest exact form); otherwise
+// every number is written with exactly that many decimals.
+func formatCoordinates(text string, decimals int) string {
+	var b strings.Builder
+	b.Grow(len(text))
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case isLetter(c):
+			// Keywords such as POINT, EMPTY or ZM are copied verbatim
+			j := i
+			for j < len(text) && isLetter(text[j]) {
+				j++
+			}
+			b.WriteString(text[i:j])
+			i = j
+		case isDigit(c) || c == '-' || c == '+' || c == '.':
+			j := i + 1
+			for j < len(text) && isNumberByte(text[j]) {
+				j++
+			}
+			b.WriteString(formatNumber(text[i:j], decimals))
+			i = j
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+// formatNumber reformats a single numeric token, leaving it untouched if it
+// does not need rewriting or cannot be parsed
+func formatNumber(token string, decimals int) string {
+	if decimals < 0 && !strings.ContainsAny(token, "eE") {
+		return token
+	}
+
+	v, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return token
+	}
+
+	return strconv.FormatFloat(v, 'f', decimals, 64)
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isNumberByte(c byte) bool {
+	return isDigit(c) || c == '.' || c == 'e' || c == 'E' || c == '-' || c == '+'
+}
@@ -0,0 +1,142 @@
+package geos
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestToWKTWithOptions tests WKT output flavors for Z and M coordinates
+func TestToWKTWithOptions(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := []struct {
+		name     string
+		inputWKT string
+		opts     WKTOptions
+		expected string
+	}{
+		{"2D point", "POINT(1 2)", WKTOptions{Trim: true}, "POINT (1 2)"},
+		{"Z point", "POINT Z (1 2 3)", WKTOptions{Trim: true}, "POINT Z (1 2 3)"},
+		{"M point", "POINT M (1 2 4)", WKTOptions{Trim: true}, "POINT M (1 2 4)"},
+		{"ZM point", "POINT ZM (1 2 3 4)", WKTOptions{Trim: true}, "POINT ZM (1 2 3 4)"},
+		{"ZM point limited to 3D", "POINT ZM (1 2 3 4)", WKTOptions{Dimension: 3, Trim: true}, "POINT Z (1 2 3)"},
+		{"Z point forced to 2D", "POINT Z (1 2 3)", WKTOptions{Dimension: 2, Trim: true}, "POINT (1 2)"},
+		{"Old-style 3D point", "POINT Z (1 2 3)", WKTOptions{Old3D: true, Trim: true}, "POINT (1 2 3)"},
+		{"Z linestring", "LINESTRING Z (0 0 1, 1 1 2)", WKTOptions{Trim: true}, "LINESTRING Z (0 0 1, 1 1 2)"},
+		{"Rounded point", "POINT(1.23456 2.5)", WKTOptions{Precision: 2}, "POINT (1.23 2.50)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			geom := helper.ParseWKT(tc.inputWKT)
+
+			wkt, err := helper.service.ToWKTWithOptions(geom, tc.opts)
+			if err != nil {
+				t.Fatalf("Unexpected error converting to WKT: %v", err)
+			}
+			if wkt != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, wkt)
+			}
+		})
+	}
+}
+
+// TestToWKTWithOptions_Invalid tests invalid inputs and options
+func TestToWKTWithOptions_Invalid(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	if _, err := helper.service.ToWKTWithOptions(nil, WKTOptions{}); err == nil {
+		t.Error("Expected error for nil geometry")
+	}
+
+	geom := helper.PointGeometry()
+	if _, err := helper.service.ToWKTWithOptions(geom, WKTOptions{Dimension: 5}); err == nil {
+		t.Error("Expected error for invalid dimension")
+	}
+	if _, err := helper.service.ToWKTWithOptions(geom, WKTOptions{Precision: -1}); err == nil {
+		t.Error("Expected error for negative precision")
+	}
+}
+
+// TestToWKT_Default tests the default output, as documented on ToWKT
+func TestToWKT_Default(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	testCases := map[string]string{
+		"POINT(1.0 2.0)":             "POINT (1 2)",
+		"POINT Z (1.5 2 3)":          "POINT Z (1.5 2 3)",
+		"LINESTRING(0 0, 0.25 10.0)": "LINESTRING (0 0, 0.25 10)",
+	}
+	for input, expected := range testCases {
+		if wkt := helper.AssertToWKT(helper.ParseWKT(input)); wkt != expected {
+			t.Errorf("Expected %q for %s, got %q", expected, input, wkt)
+		}
+	}
+}
+
+// TestToWKT_NoScientificNotation tests that small and large values are written as plain decimals
+func TestToWKT_NoScientificNotation(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	geom := helper.ParseWKT("POINT(0.0000001 100000000000000000000)")
+
+	wkt := helper.AssertToWKT(geom)
+	if strings.Contains(wkt, "e-") || strings.Contains(wkt, "e+") {
+		t.Errorf("Unexpected scientific notation in %q", wkt)
+	}
+
+	wkt, err := helper.service.ToWKTWithOptions(geom, WKTOptions{Trim: true})
+	if err != nil {
+		t.Fatalf("Unexpected error converting to WKT: %v", err)
+	}
+	expected := "POINT (0.0000001 100000000000000000000)"
+	if wkt != expected {
+		t.Errorf("Expected %q, got %q", expected, wkt)
+	}
+}
+
+// TestToWKTWithOptions_FixedWidth tests fixed-width number formatting
+func TestToWKTWithOptions_FixedWidth(t *testing.T) {
+	helper := NewTestHelper(t)
+	defer helper.Close()
+
+	geom := helper.ParseWKT("LINESTRING(1 2.5, 0.0000001 -3)")
+
+	wkt, err := helper.service.ToWKTWithOptions(geom, WKTOptions{Trim: true, Precision: 3, FixedWidth: true})
+	if err != nil {
+		t.Fatalf("Unexpected error converting to WKT: %v", err)
+	}
+	expected := "LINESTRING (1.000 2.500, 0.000 -3.000)"
+	if wkt != expected {
+		t.Errorf("Expected %q, got %q", expected, wkt)
+	}
+
+	if _, err := helper.service.ToWKTWithOptions(geom, WKTOptions{FixedWidth: true}); err == nil {
+		t.Error("Expected error for fixed width without precision")
+	}
+}
+
+// TestFormatCoordinates tests the locale-independent number rewriting
+func TestFormatCoordinates(t *testing.T) {
+	testCases := []struct {
+		input    string
+		decimals int
+		expected string
+	}{
+		{"POINT (1e-07 2)", -1, "POINT (0.0000001 2)"},
+		{"POINT (1.5E+21 -2.5e-3)", -1, "POINT (1500000000000000000000 -0.0025)"},
+		{"POINT (1.0000000000000000 2.0000000000000000)", -1, "POINT (1.0000000000000000 2.0000000000000000)"},
+		{"POINT EMPTY", -1, "POINT EMPTY"},
+		{"POINT ZM (1 2 3 4)", 2, "POINT ZM (1.00 2.00 3.00 4.00)"},
+	}
+
+	for _, tc := range testCases {
+		if got := formatCoordinates(tc.input, tc.decimals); got != tc.expected {
+			t.Errorf("formatCoordinates(%q, %d) = %q, expected %q", tc.input, tc.decimals, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Package geostest provides helpers for writing geometry regression tests
+// against the geos package.
+//
+// Golden compares the output of an operation with a WKB file stored under
+// testdata/golden. Run the tests with -update-golden to create or refresh the
+// files after an intended change, and review the diff like any other change:
+//
+//	func TestCoastlineSimplify(t *testing.T) {
+//		service, _ := geos.NewService()
+//		defer service.Close()
+//
+//		simplified, err := service.Simplify(coastline, 0.01)
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		geostest.Golden(t, service, "coastline_simplify", simplified, 1e-9)
+//	}
+package geostest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mehmetymw/gogeos/geos"
+)
+
+// update makes Golden rewrite golden files instead of comparing against them
+var update = flag.Bool("update-golden", false, "rewrite geostest golden files with the current results")
+
+// GoldenDir is the directory golden files are read from and written to,
+// relative to the package directory that go test runs in.
+var GoldenDir = filepath.Join("testdata", "golden")
+
+// Golden compares a geometry with the golden file GoldenDir/<name>.wkb and
+// reports a test error when they differ. Two geometries match when they have
+// the same type and their Hausdorff distance is at most tolerance, so results
+// that only differ by floating point noise, vertex order or starting point
+// still pass. A tolerance of 0 requires the same set of points.
+//
+// When the test binary runs with -update-golden, Golden writes the geometry
+// to the golden file instead, creating directories as needed. Names may use
+// slashes to group files in subdirectories.
+//
+// Parameters:
+//   - t: The test to report to
+//   - service: The service that created got
+//   - name: The golden file name without the .wkb extension
+//   - got: The geometry produced by the code under test
+//   - tolerance: The largest acceptable Hausdorff distance
+//
+// Example:
+//
+//	buffered, _ := service.Buffer(road, 5)
+//	geostest.Golden(t, service, "roads/buffer_5m", buffered, 1e-6)
+func Golden(t testing.TB, service *geos.Service, name string, got *geos.Geometry, tolerance float64) {
+	t.Helper()
+
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "..") {
+		t.Fatalf("geostest: invalid golden file name %q", name)
+		return
+	}
+	if tolerance < 0 {
+		t.Fatalf("geostest: tolerance must not be negative, got %g", tolerance)
+		return
+	}
+	path := filepath.Join(GoldenDir, filepath.FromSlash(name)+".wkb")
+
+	if *update {
+		if err := writeGolden(service, path, got); err != nil {
+			t.Fatalf("geostest: failed to update %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := readGolden(service, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("geostest: golden file %s does not exist; run the test with -update-golden to create it", path)
+		return
+	}
+	if err != nil {
+		t.Fatalf("geostest: failed to read %s: %v", path, err)
+		return
+	}
+
+	diff, err := compare(service, got, want, tolerance)
+	if err != nil {
+		t.Fatalf("geostest: failed to compare with %s: %v", path, err)
+		return
+	}
+	if diff != "" {
+		t.Errorf("geostest: result does not match %s: %s\n got: %s\nwant: %s", path, diff, describe(service, got), describe(service, want))
+	}
+}
+
+// writeGolden stores a geometry as WKB
+func writeGolden(service *geos.Service, path string, geom *geos.Geometry) error {
+	if geom == nil {
+		return errors.New("geometry is nil")
+	}
+	arr, err := service.ToWKBArray([]*geos.Geometry{geom})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, arr.Value(0), 0o644)
+}
+
+// readGolden loads a geometry stored as WKB
+func readGolden(service *geos.Service, path string) (*geos.Geometry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	geoms, err := service.FromWKBArray(geos.WKBArray{Offsets: []int32{0, int32(len(data))}, Data: data})
+	if err != nil {
+		return nil, err
+	}
+	return geoms[0], nil
+}
+
+// compare describes how got differs from want, or returns "" if they match
+func compare(service *geos.Service, got, want *geos.Geometry, tolerance float64) (string, error) {
+	if got == nil {
+		return "got a nil geometry", nil
+	}
+
+	gotType, err := geometryType(service, got)
+	if err != nil {
+		return "", err
+	}
+	wantType, err := geometryType(service, want)
+	if err != nil {
+		return "", err
+	}
+	if gotType != wantType {
+		return fmt.Sprintf("got a %s, want a %s", gotType, wantType), nil
+	}
+
+	gotEmpty, err := service.IsEmpty(got)
+	if err != nil {
+		return "", err
+	}
+	wantEmpty, err := service.IsEmpty(want)
+	if err != nil {
+		return "", err
+	}
+	if gotEmpty || wantEmpty {
+		if gotEmpty != wantEmpty {
+			return "exactly one of the geometries is empty", nil
+		}
+		return "", nil
+	}
+
+	distance, err := service.HausdorffDistance(got, want)
+	if err != nil {
+		return "", err
+	}
+	if distance > tolerance {
+		return fmt.Sprintf("Hausdorff distance %g exceeds tolerance %g", distance, tolerance), nil
+	}
+	return "", nil
+}
+
+// geometryType returns the WKT type keyword of a geometry, e.g. "POLYGON"
+func geometryType(service *geos.Service, geom *geos.Geometry) (string, error) {
+	wkt, err := service.ToWKT(geom)
+	if err != nil {
+		return "", err
+	}
+	if i := strings.IndexAny(wkt, " ("); i >= 0 {
+		wkt = wkt[:i]
+	}
+	return wkt, nil
+}
+
+// describe formats a geometry for a failure message
+func describe(service *geos.Service, geom *geos.Geometry) string {
+	if geom == nil {
+		return "<nil>"
+	}
+	wkt, err := service.ToWKTWithOptions(geom, geos.WKTOptions{Trim: true})
+	if err != nil {
+		return fmt.Sprintf("<%v>", err)
+	}
+	return wkt
+}
@@ -0,0 +1,102 @@
+package geostest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mehmetymw/gogeos/geos"
+)
+
+// recorder captures failures reported by Golden
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+	r.fatal = true
+}
+
+// TestGolden tests writing and comparing golden files
+func TestGolden(t *testing.T) {
+	service, err := geos.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	defer service.Close()
+
+	parse := func(wkt string) *geos.Geometry {
+		geom, err := service.ParseGeometry(geos.GeometryInput{WKT: wkt})
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", wkt, err)
+		}
+		return geom
+	}
+
+	defer func(dir string) { GoldenDir = dir }(GoldenDir)
+	GoldenDir = t.TempDir()
+
+	square := parse("POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))")
+
+	t.Run("Missing file", func(t *testing.T) {
+		r := &recorder{TB: t}
+		Golden(r, service, "square", square, 0)
+		if !r.fatal || !strings.Contains(r.errors[0], "-update-golden") {
+			t.Errorf("Expected a fatal error pointing at -update-golden, got %v", r.errors)
+		}
+	})
+
+	*update = true
+	Golden(t, service, "shapes/square", square, 0)
+	*update = false
+
+	if _, err := os.Stat(filepath.Join(GoldenDir, "shapes", "square.wkb")); err != nil {
+		t.Fatalf("Expected golden file to be written: %v", err)
+	}
+
+	testCases := []struct {
+		name      string
+		wkt       string
+		tolerance float64
+		match     bool
+	}{
+		{"Identical", "POLYGON((0 0, 10 0, 10 10, 0 10, 0 0))", 0, true},
+		{"Different start and orientation", "POLYGON((10 10, 10 0, 0 0, 0 10, 10 10))", 0, true},
+		{"Within tolerance", "POLYGON((0 0, 10 0, 10 10.0001, 0 10, 0 0))", 1e-3, true},
+		{"Beyond tolerance", "POLYGON((0 0, 10 0, 10 10.1, 0 10, 0 0))", 1e-3, false},
+		{"Different type", "LINESTRING(0 0, 10 0, 10 10, 0 10, 0 0)", 1, false},
+		{"Empty", "POLYGON EMPTY", 1, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			Golden(r, service, "shapes/square", parse(tc.wkt), tc.tolerance)
+			if r.fatal {
+				t.Fatalf("Unexpected fatal error: %v", r.errors)
+			}
+			if match := len(r.errors) == 0; match != tc.match {
+				t.Errorf("Expected match %v, got errors %v", tc.match, r.errors)
+			}
+		})
+	}
+
+	t.Run("Invalid name", func(t *testing.T) {
+		r := &recorder{TB: t}
+		Golden(r, service, "../escape", square, 0)
+		if !r.fatal {
+			t.Error("Expected a fatal error for a name leaving the golden directory")
+		}
+	})
+}
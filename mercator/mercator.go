@@ -0,0 +1,191 @@
+// Package mercator provides the standard Web Mercator (EPSG:3857) math used to
+// address map tiles: conversions between WGS84 longitude/latitude, projected
+// meters, global pixel coordinates and XYZ tile indexes, plus the ground
+// resolution of each zoom level.
+//
+// Pixel coordinates are global at a given zoom level, with the origin at the
+// top-left corner of the world (180°W, ~85.05°N) and Y pointing down, which
+// matches the XYZ tile scheme used by Leaflet and most tile servers.
+package mercator
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// EarthRadius is the WGS84 semi-major axis in meters used by Web Mercator
+	EarthRadius = 6378137.0
+
+	// OriginShift is half the circumference of the projected world in meters
+	OriginShift = math.Pi * EarthRadius
+
+	// MaxLatitude is the latitude at which the projected world becomes square
+	MaxLatitude = 85.05112877980659
+
+	// TileSize is the width and height of a tile in pixels
+	TileSize = 256
+
+	// MaxTiles is the largest number of tiles TilesInBounds enumerates
+	MaxTiles = 1 << 20
+)
+
+// LonLatToMeters projects WGS84 longitude and latitude in degrees to Web
+// Mercator meters. Latitudes are clamped to ±MaxLatitude.
+func LonLatToMeters(lon, lat float64) (x, y float64) {
+	lat = math.Max(-MaxLatitude, math.Min(MaxLatitude, lat))
+	x = lon * OriginShift / 180
+	y = math.Log(math.Tan((90+lat)*math.Pi/360)) * EarthRadius
+	return x, y
+}
+
+// MetersToLonLat converts Web Mercator meters to WGS84 longitude and latitude
+// in degrees.
+func MetersToLonLat(x, y float64) (lon, lat float64) {
+	lon = x / OriginShift * 180
+	lat = math.Atan(math.Sinh(y/EarthRadius)) * 180 / math.Pi
+	return lon, lat
+}
+
+// TransformFunc converts a single coordinate. Its TransformXY method converts
+// batches in place, so values of this type satisfy geos.Transformer and can
+// be passed to Service.Transform without this package depending on GEOS.
+type TransformFunc func(x, y float64) (float64, float64)
+
+// TransformXY applies f to every coordinate.
+func (f TransformFunc) TransformXY(x, y []float64) error {
+	if len(x) != len(y) {
+		return fmt.Errorf("mercator: %d X ordinates but %d Y ordinates", len(x), len(y))
+	}
+	for i := range x {
+		x[i], y[i] = f(x[i], y[i])
+	}
+	return nil
+}
+
+var (
+	// ToMeters projects longitude/latitude coordinates to Web Mercator meters
+	ToMeters TransformFunc = LonLatToMeters
+
+	// ToLonLat converts Web Mercator meters to longitude/latitude coordinates
+	ToLonLat TransformFunc = MetersToLonLat
+)
+
+// Resolution returns the ground resolution in meters per pixel at the equator
+// for a zoom level.
+func Resolution(zoom int) float64 {
+	return 2 * OriginShift / (TileSize * math.Exp2(float64(zoom)))
+}
+
+// MetersToPixel converts Web Mercator meters to global pixel coordinates at a
+// zoom level.
+func MetersToPixel(x, y float64, zoom int) (px, py float64) {
+	res := Resolution(zoom)
+	return (x + OriginShift) / res, (OriginShift - y) / res
+}
+
+// PixelToMeters converts global pixel coordinates at a zoom level to Web
+// Mercator meters.
+func PixelToMeters(px, py float64, zoom int) (x, y float64) {
+	res := Resolution(zoom)
+	return px*res - OriginShift, OriginShift - py*res
+}
+
+// LonLatToPixel converts WGS84 longitude and latitude to global pixel
+// coordinates at a zoom level.
+func LonLatToPixel(lon, lat float64, zoom int) (px, py float64) {
+	x, y := LonLatToMeters(lon, lat)
+	return MetersToPixel(x, y, zoom)
+}
+
+// PixelToLonLat converts global pixel coordinates at a zoom level to WGS84
+// longitude and latitude.
+func PixelToLonLat(px, py float64, zoom int) (lon, lat float64) {
+	x, y := PixelToMeters(px, py, zoom)
+	return MetersToLonLat(x, y)
+}
+
+// LonLatToTile returns the XYZ tile containing a WGS84 location at a zoom
+// level from 0 to 30. Locations outside the projected world are clamped to
+// the edge tiles.
+func LonLatToTile(lon, lat float64, zoom int) (x, y int, err error) {
+	if zoom < 0 || zoom > 30 {
+		return 0, 0, fmt.Errorf("invalid zoom level %d", zoom)
+	}
+	px, py := LonLatToPixel(lon, lat, zoom)
+	n := 1 << zoom
+	x = clampTile(int(math.Floor(px/TileSize)), n)
+	y = clampTile(int(math.Floor(py/TileSize)), n)
+	return x, y, nil
+}
+
+// Tile is the address of an XYZ tile
+type Tile struct {
+	Z, X, Y int
+}
+
+// TilesInBounds returns the tiles at a zoom level that overlap a WGS84
+// bounding box, row by row from the north-west corner. The box is clamped to
+// the projected world, and an inverted box overlaps no tiles. The number of
+// tiles grows fourfold with each zoom level, so large boxes should be covered
+// at low zoom levels only: boxes spanning more than MaxTiles tiles are
+// rejected rather than allocated.
+func TilesInBounds(minLon, minLat, maxLon, maxLat float64, zoom int) ([]Tile, error) {
+	if zoom < 0 || zoom > 30 {
+		return nil, fmt.Errorf("invalid zoom level %d", zoom)
+	}
+	if minLon > maxLon || minLat > maxLat {
+		return nil, nil
+	}
+	// The zoom level is valid, so these cannot fail
+	minX, minY, _ := LonLatToTile(minLon, maxLat, zoom)
+	maxX, maxY, _ := LonLatToTile(maxLon, minLat, zoom)
+
+	count := int64(maxX-minX+1) * int64(maxY-minY+1)
+	if count > MaxTiles {
+		return nil, fmt.Errorf("bounding box spans %d tiles at zoom level %d, more than %d", count, zoom, MaxTiles)
+	}
+
+	tiles := make([]Tile, 0, count)
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			tiles = append(tiles, Tile{Z: zoom, X: x, Y: y})
+		}
+	}
+	return tiles, nil
+}
+
+// TileBoundsMeters returns the Web Mercator extent of an XYZ tile.
+func TileBoundsMeters(z, x, y int) (minX, minY, maxX, maxY float64) {
+	minX, maxY = PixelToMeters(float64(x*TileSize), float64(y*TileSize), z)
+	maxX, minY = PixelToMeters(float64((x+1)*TileSize), float64((y+1)*TileSize), z)
+	return minX, minY, maxX, maxY
+}
+
+// TileBounds returns the WGS84 extent of an XYZ tile.
+func TileBounds(z, x, y int) (minLon, minLat, maxLon, maxLat float64) {
+	minX, minY, maxX, maxY := TileBoundsMeters(z, x, y)
+	minLon, minLat = MetersToLonLat(minX, minY)
+	maxLon, maxLat = MetersToLonLat(maxX, maxY)
+	return minLon, minLat, maxLon, maxLat
+}
+
+// ValidTile reports whether x and y address a tile that exists at zoom z.
+func ValidTile(z, x, y int) bool {
+	if z < 0 || z > 30 {
+		return false
+	}
+	n := 1 << z
+	return x >= 0 && x < n && y >= 0 && y < n
+}
+
+// clampTile limits a tile index to the tiles that exist at a zoom level
+func clampTile(i, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
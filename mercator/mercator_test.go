@@ -0,0 +1,179 @@
+package mercator
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestLonLatToMeters tests projecting to Web Mercator and back
+func TestLonLatToMeters(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lon, lat float64
+		x, y     float64
+	}{
+		{"Origin", 0, 0, 0, 0},
+		{"Antimeridian", 180, 0, OriginShift, 0},
+		{"World corner", -180, MaxLatitude, -OriginShift, OriginShift},
+		{"Istanbul", 28.9784, 41.0082, 3225860.7320, 5013551.2372},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			x, y := LonLatToMeters(tc.lon, tc.lat)
+			if math.Abs(x-tc.x) > 1e-3 || math.Abs(y-tc.y) > 1e-3 {
+				t.Errorf("Expected (%f %f), got (%f %f)", tc.x, tc.y, x, y)
+			}
+
+			lon, lat := MetersToLonLat(x, y)
+			if math.Abs(lon-tc.lon) > 1e-9 || math.Abs(lat-tc.lat) > 1e-9 {
+				t.Errorf("Round trip: expected (%g %g), got (%g %g)", tc.lon, tc.lat, lon, lat)
+			}
+		})
+	}
+}
+
+// TestTransformFunc tests batch conversion with ToMeters and ToLonLat
+func TestTransformFunc(t *testing.T) {
+	x := []float64{0, 180, 28.9784}
+	y := []float64{0, 0, 41.0082}
+
+	if err := ToMeters.TransformXY(x, y); err != nil {
+		t.Fatalf("Failed to project: %v", err)
+	}
+	if math.Abs(x[1]-OriginShift) > 1e-6 || math.Abs(x[2]-3225860.7320) > 1e-3 || math.Abs(y[2]-5013551.2372) > 1e-3 {
+		t.Errorf("Unexpected projected coordinates %v %v", x, y)
+	}
+
+	if err := ToLonLat.TransformXY(x, y); err != nil {
+		t.Fatalf("Failed to unproject: %v", err)
+	}
+	if math.Abs(x[2]-28.9784) > 1e-9 || math.Abs(y[2]-41.0082) > 1e-9 {
+		t.Errorf("Round trip: expected (28.9784 41.0082), got (%g %g)", x[2], y[2])
+	}
+
+	if err := ToMeters.TransformXY([]float64{0}, nil); err == nil {
+		t.Error("Expected error for mismatched ordinates")
+	}
+}
+
+// TestResolution tests the ground resolution per zoom level
+func TestResolution(t *testing.T) {
+	if res := Resolution(0); math.Abs(res-156543.03392804097) > 1e-6 {
+		t.Errorf("Expected 156543.034 m/px at zoom 0, got %f", res)
+	}
+	if ratio := Resolution(10) / Resolution(11); math.Abs(ratio-2) > 1e-12 {
+		t.Errorf("Expected resolution to halve per zoom level, got ratio %f", ratio)
+	}
+}
+
+// TestLonLatToTile tests finding the tile containing a location
+func TestLonLatToTile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lon, lat float64
+		zoom     int
+		x, y     int
+	}{
+		{"World", 28.9784, 41.0082, 0, 0, 0},
+		{"North-east quadrant", 28.9784, 41.0082, 1, 1, 0},
+		{"Istanbul zoom 14", 28.9784, 41.0082, 14, 9510, 6142},
+		{"Clamped corner", 180, -90, 3, 7, 7},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			x, y, err := LonLatToTile(tc.lon, tc.lat, tc.zoom)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if x != tc.x || y != tc.y {
+				t.Errorf("Expected tile %d/%d/%d, got %d/%d/%d", tc.zoom, tc.x, tc.y, tc.zoom, x, y)
+			}
+
+			minLon, minLat, maxLon, maxLat := TileBounds(tc.zoom, x, y)
+			if tc.lat > -MaxLatitude && (tc.lon < minLon || tc.lon > maxLon || tc.lat < minLat || tc.lat > maxLat) {
+				t.Errorf("Location (%g %g) outside its tile bounds (%g %g, %g %g)", tc.lon, tc.lat, minLon, minLat, maxLon, maxLat)
+			}
+		})
+	}
+
+	for _, zoom := range []int{-1, 31, 64} {
+		if _, _, err := LonLatToTile(0, 0, zoom); err == nil {
+			t.Errorf("Expected error for zoom level %d", zoom)
+		}
+	}
+}
+
+// TestTilesInBounds tests enumerating the tiles overlapping a bounding box
+func TestTilesInBounds(t *testing.T) {
+	testCases := []struct {
+		name                           string
+		minLon, minLat, maxLon, maxLat float64
+		zoom                           int
+		expected                       []Tile
+	}{
+		{"World", -180, -90, 180, 90, 1, []Tile{{1, 0, 0}, {1, 1, 0}, {1, 0, 1}, {1, 1, 1}}},
+		{"Point", 28.9784, 41.0082, 28.9784, 41.0082, 14, []Tile{{14, 9510, 6142}}},
+		{"North-east quadrant", 10, 10, 170, 80, 2, []Tile{{2, 2, 0}, {2, 3, 0}, {2, 2, 1}, {2, 3, 1}}},
+		{"Inverted box", 10, 10, 0, 0, 2, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tiles, err := TilesInBounds(tc.minLon, tc.minLat, tc.maxLon, tc.maxLat, tc.zoom)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tiles, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, tiles)
+			}
+		})
+	}
+
+	// Turkey at zoom 20 would take about 2.5 billion tiles
+	if _, err := TilesInBounds(26, 36, 45, 42, 20); err == nil {
+		t.Error("Expected error for a box spanning too many tiles")
+	}
+	if _, err := TilesInBounds(0, 0, 1, 1, 31); err == nil {
+		t.Error("Expected error for an invalid zoom level")
+	}
+}
+
+// TestTileBounds tests tile extents
+func TestTileBounds(t *testing.T) {
+	minX, minY, maxX, maxY := TileBoundsMeters(0, 0, 0)
+	if minX != -OriginShift || minY != -OriginShift || maxX != OriginShift || maxY != OriginShift {
+		t.Errorf("Expected the world extent, got (%f %f, %f %f)", minX, minY, maxX, maxY)
+	}
+
+	minLon, minLat, maxLon, maxLat := TileBounds(1, 1, 0)
+	if math.Abs(minLon) > 1e-9 || math.Abs(minLat) > 1e-9 || math.Abs(maxLon-180) > 1e-9 || math.Abs(maxLat-MaxLatitude) > 1e-9 {
+		t.Errorf("Unexpected bounds for tile 1/1/0: (%g %g, %g %g)", minLon, minLat, maxLon, maxLat)
+	}
+}
+
+// TestPixelRoundTrip tests conversions through pixel coordinates
+func TestPixelRoundTrip(t *testing.T) {
+	px, py := LonLatToPixel(0, 0, 2)
+	if px != 512 || py != 512 {
+		t.Errorf("Expected the map center at (512 512), got (%g %g)", px, py)
+	}
+
+	lon, lat := PixelToLonLat(px+100, py-100, 2)
+	px2, py2 := LonLatToPixel(lon, lat, 2)
+	if math.Abs(px2-px-100) > 1e-9 || math.Abs(py2-py+100) > 1e-9 {
+		t.Errorf("Round trip drifted to (%g %g)", px2, py2)
+	}
+}
+
+// TestValidTile tests tile address validation
+func TestValidTile(t *testing.T) {
+	if !ValidTile(0, 0, 0) || !ValidTile(3, 7, 7) {
+		t.Error("Expected valid tiles")
+	}
+	if ValidTile(3, 8, 0) || ValidTile(-1, 0, 0) || ValidTile(2, 0, -1) {
+		t.Error("Expected invalid tiles")
+	}
+}
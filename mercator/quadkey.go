@@ -0,0 +1,54 @@
+package mercator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TileToQuadkey returns the Bing Maps quadkey of an XYZ tile. Each digit
+// selects one quadrant per zoom level, so the quadkey length equals the zoom
+// level and tile 0/0/0 has an empty quadkey.
+func TileToQuadkey(z, x, y int) (string, error) {
+	if !ValidTile(z, x, y) {
+		return "", fmt.Errorf("invalid tile %d/%d/%d", z, x, y)
+	}
+
+	var b strings.Builder
+	b.Grow(z)
+	for i := z; i > 0; i-- {
+		digit := byte('0')
+		mask := 1 << (i - 1)
+		if x&mask != 0 {
+			digit++
+		}
+		if y&mask != 0 {
+			digit += 2
+		}
+		b.WriteByte(digit)
+	}
+	return b.String(), nil
+}
+
+// QuadkeyToTile returns the XYZ tile addressed by a Bing Maps quadkey.
+func QuadkeyToTile(quadkey string) (z, x, y int, err error) {
+	z = len(quadkey)
+	if z > 30 {
+		return 0, 0, 0, fmt.Errorf("quadkey %q is too long", quadkey)
+	}
+	for i := 0; i < z; i++ {
+		mask := 1 << (z - i - 1)
+		switch quadkey[i] {
+		case '0':
+		case '1':
+			x |= mask
+		case '2':
+			y |= mask
+		case '3':
+			x |= mask
+			y |= mask
+		default:
+			return 0, 0, 0, fmt.Errorf("invalid quadkey %q: unexpected digit %q", quadkey, quadkey[i])
+		}
+	}
+	return z, x, y, nil
+}
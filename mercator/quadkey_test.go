@@ -0,0 +1,46 @@
+package mercator
+
+import (
+	"testing"
+)
+
+// TestQuadkey tests conversions between tiles and quadkeys
+func TestQuadkey(t *testing.T) {
+	testCases := []struct {
+		z, x, y int
+		quadkey string
+	}{
+		{0, 0, 0, ""},
+		{1, 1, 0, "1"},
+		{1, 0, 1, "2"},
+		{3, 3, 5, "213"},
+		{14, 9510, 6142, "12032322322330"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.quadkey, func(t *testing.T) {
+			quadkey, err := TileToQuadkey(tc.z, tc.x, tc.y)
+			if err != nil {
+				t.Fatalf("Failed to convert tile: %v", err)
+			}
+			if quadkey != tc.quadkey {
+				t.Errorf("Expected quadkey %q, got %q", tc.quadkey, quadkey)
+			}
+
+			z, x, y, err := QuadkeyToTile(quadkey)
+			if err != nil {
+				t.Fatalf("Failed to convert quadkey: %v", err)
+			}
+			if z != tc.z || x != tc.x || y != tc.y {
+				t.Errorf("Expected tile %d/%d/%d, got %d/%d/%d", tc.z, tc.x, tc.y, z, x, y)
+			}
+		})
+	}
+
+	if _, err := TileToQuadkey(1, 2, 0); err == nil {
+		t.Error("Expected error for invalid tile")
+	}
+	if _, _, _, err := QuadkeyToTile("014"); err == nil {
+		t.Error("Expected error for invalid digit")
+	}
+}
@@ -0,0 +1,286 @@
+// Package quadtree provides an incremental quadtree index over bounding boxes.
+//
+// Unlike the GEOS STRtree behind geos.Index, which is packed once and rebuilt
+// after changes, a quadtree takes inserts and removals at any time at the same
+// cost, which suits dynamic datasets such as moving vehicles or features being
+// edited. It is pure Go and does not need GEOS.
+//
+// Entries are stored at the deepest node whose quadrant contains their box,
+// and the tree grows its root as needed, so no bounds have to be known in
+// advance. A Tree is not safe for concurrent use; guard it with a mutex when
+// it is shared.
+package quadtree
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// maxEntries is the number of entries a leaf holds before it is split
+	maxEntries = 8
+
+	// maxDepth limits splitting, e.g. when many entries share one location
+	maxDepth = 32
+)
+
+// Envelope is an axis-aligned bounding box. A point is an envelope with equal
+// minimum and maximum.
+type Envelope struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Intersects reports whether two envelopes share at least one point.
+func (e Envelope) Intersects(o Envelope) bool {
+	return e.MinX <= o.MaxX && o.MinX <= e.MaxX && e.MinY <= o.MaxY && o.MinY <= e.MaxY
+}
+
+// Contains reports whether o lies entirely within e.
+func (e Envelope) Contains(o Envelope) bool {
+	return e.MinX <= o.MinX && o.MaxX <= e.MaxX && e.MinY <= o.MinY && o.MaxY <= e.MaxY
+}
+
+// valid reports whether the envelope is finite and not inverted
+func (e Envelope) valid() bool {
+	for _, v := range [4]float64{e.MinX, e.MinY, e.MaxX, e.MaxY} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return e.MinX <= e.MaxX && e.MinY <= e.MaxY
+}
+
+// Tree is an incremental quadtree. The zero value is an empty tree ready to use.
+//
+// Example:
+//
+//	var tree quadtree.Tree
+//	tree.Insert(quadtree.Envelope{MinX: 0, MinY: 0, MaxX: 1, MaxY: 1}, "parcel-1")
+//	tree.Insert(quadtree.Envelope{MinX: 5, MinY: 5, MaxX: 6, MaxY: 6}, "parcel-2")
+//
+//	items := tree.Query(quadtree.Envelope{MinX: 0.5, MinY: 0.5, MaxX: 0.5, MaxY: 0.5})
+//	// items will be [parcel-1]
+type Tree struct {
+	root *node
+	size int
+	seq  uint64
+}
+
+// node is a square quadrant of the tree
+type node struct {
+	bounds   Envelope
+	entries  []entry
+	children *[4]*node
+}
+
+// entry is one indexed box and its item
+type entry struct {
+	env  Envelope
+	item any
+	seq  uint64 // insertion order, for deterministic query results
+}
+
+// Insert adds an item under a bounding box.
+func (t *Tree) Insert(env Envelope, item any) error {
+	if !env.valid() {
+		return fmt.Errorf("quadtree: invalid envelope %+v", env)
+	}
+
+	if t.root == nil {
+		t.root = &node{bounds: squareAround(env)}
+	}
+	for !t.root.bounds.Contains(env) {
+		t.grow(env)
+	}
+
+	t.seq++
+	t.root.insert(entry{env: env, item: item, seq: t.seq}, 0)
+	t.size++
+	return nil
+}
+
+// Remove deletes an item inserted under the given bounding box. Items are
+// compared with ==, so they must be comparable. It reports whether the item
+// was found.
+func (t *Tree) Remove(env Envelope, item any) bool {
+	if t.root == nil || !env.valid() {
+		return false
+	}
+	if !t.root.remove(env, item) {
+		return false
+	}
+	t.size--
+	if t.size == 0 {
+		t.root = nil
+	}
+	return true
+}
+
+// Query returns the items whose bounding boxes intersect env, in insertion
+// order.
+func (t *Tree) Query(env Envelope) []any {
+	var found []entry
+	if t.root != nil && env.valid() {
+		t.root.query(env, &found)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].seq < found[j].seq })
+
+	items := make([]any, len(found))
+	for i, e := range found {
+		items[i] = e.item
+	}
+	return items
+}
+
+// Len returns the number of items in the tree.
+func (t *Tree) Len() int {
+	return t.size
+}
+
+// grow doubles the root towards env, keeping the old root as a quadrant
+func (t *Tree) grow(env Envelope) {
+	old := t.root.bounds
+	size := old.MaxX - old.MinX
+
+	// Extend to the side of the missing envelope on each axis
+	minX, minY := old.MinX, old.MinY
+	if env.MinX < old.MinX {
+		minX -= size
+	}
+	if env.MinY < old.MinY {
+		minY -= size
+	}
+
+	root := &node{bounds: Envelope{minX, minY, minX + 2*size, minY + 2*size}}
+	if t.root.entries != nil || t.root.children != nil {
+		root.children = root.split()
+		root.children[root.quadrant(old)] = t.root
+	}
+	t.root = root
+}
+
+// insert adds an entry below n, splitting full leaves
+func (n *node) insert(e entry, depth int) {
+	if n.children != nil {
+		if child := n.childFor(e.env); child != nil {
+			child.insert(e, depth+1)
+			return
+		}
+		n.entries = append(n.entries, e)
+		return
+	}
+
+	n.entries = append(n.entries, e)
+	if len(n.entries) <= maxEntries || depth >= maxDepth {
+		return
+	}
+
+	// Split and push down the entries that fit in a quadrant
+	n.children = n.split()
+	kept := n.entries[:0]
+	for _, existing := range n.entries {
+		if child := n.childFor(existing.env); child != nil {
+			child.insert(existing, depth+1)
+		} else {
+			kept = append(kept, existing)
+		}
+	}
+	n.entries = kept
+}
+
+// remove deletes an entry below n and prunes empty quadrants
+func (n *node) remove(env Envelope, item any) bool {
+	for i, e := range n.entries {
+		if e.env == env && e.item == item {
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+			return true
+		}
+	}
+
+	if n.children == nil {
+		return false
+	}
+
+	// An entry on a midline may sit in either neighboring quadrant, e.g. when
+	// grow made the old root a quadrant, so every quadrant containing it is
+	// searched
+	removed := false
+	for _, child := range n.children {
+		if child.bounds.Contains(env) && child.remove(env, item) {
+			removed = true
+			break
+		}
+	}
+	if !removed {
+		return false
+	}
+
+	for _, c := range n.children {
+		if len(c.entries) > 0 || c.children != nil {
+			return true
+		}
+	}
+	n.children = nil
+	return true
+}
+
+// query collects the entries below n that intersect env
+func (n *node) query(env Envelope, found *[]entry) {
+	for _, e := range n.entries {
+		if e.env.Intersects(env) {
+			*found = append(*found, e)
+		}
+	}
+	if n.children == nil {
+		return
+	}
+	for _, child := range n.children {
+		if child.bounds.Intersects(env) {
+			child.query(env, found)
+		}
+	}
+}
+
+// split creates the four empty quadrants of n
+func (n *node) split() *[4]*node {
+	b := n.bounds
+	midX, midY := (b.MinX+b.MaxX)/2, (b.MinY+b.MaxY)/2
+	return &[4]*node{
+		{bounds: Envelope{b.MinX, b.MinY, midX, midY}},
+		{bounds: Envelope{midX, b.MinY, b.MaxX, midY}},
+		{bounds: Envelope{b.MinX, midY, midX, b.MaxY}},
+		{bounds: Envelope{midX, midY, b.MaxX, b.MaxY}},
+	}
+}
+
+// quadrant returns the index of the quadrant of n containing the center of env
+func (n *node) quadrant(env Envelope) int {
+	midX, midY := (n.bounds.MinX+n.bounds.MaxX)/2, (n.bounds.MinY+n.bounds.MaxY)/2
+	q := 0
+	if (env.MinX+env.MaxX)/2 >= midX {
+		q++
+	}
+	if (env.MinY+env.MaxY)/2 >= midY {
+		q += 2
+	}
+	return q
+}
+
+// childFor returns the quadrant that contains env entirely, or nil
+func (n *node) childFor(env Envelope) *node {
+	child := n.children[n.quadrant(env)]
+	if child.bounds.Contains(env) {
+		return child
+	}
+	return nil
+}
+
+// squareAround returns a square containing env, used as the first root
+func squareAround(env Envelope) Envelope {
+	size := math.Max(env.MaxX-env.MinX, env.MaxY-env.MinY)
+	if size == 0 {
+		size = 1
+	}
+	return Envelope{env.MinX, env.MinY, env.MinX + size, env.MinY + size}
+}
@@ -0,0 +1,141 @@
+package quadtree
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// TestTree tests inserting, querying and removing entries
+func TestTree(t *testing.T) {
+	var tree Tree
+
+	boxes := []Envelope{
+		{0, 0, 1, 1},
+		{5, 5, 6, 6},
+		{-10, -10, -9, -9},
+		{0.5, 0.5, 5.5, 5.5},
+		{100, 100, 100, 100},
+	}
+	for i, box := range boxes {
+		if err := tree.Insert(box, i); err != nil {
+			t.Fatalf("Failed to insert %v: %v", box, err)
+		}
+	}
+	if tree.Len() != len(boxes) {
+		t.Errorf("Expected %d entries, got %d", len(boxes), tree.Len())
+	}
+
+	testCases := []struct {
+		name     string
+		query    Envelope
+		expected []any
+	}{
+		{"Point in two boxes", Envelope{0.75, 0.75, 0.75, 0.75}, []any{0, 3}},
+		{"Negative quadrant", Envelope{-9.5, -9.5, -9.5, -9.5}, []any{2}},
+		{"Touching corner", Envelope{6, 6, 7, 7}, []any{1}},
+		{"Point entry", Envelope{99, 99, 101, 101}, []any{4}},
+		{"Nothing", Envelope{50, 50, 60, 60}, []any{}},
+		{"Everything", Envelope{-100, -100, 200, 200}, []any{0, 1, 2, 3, 4}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if items := tree.Query(tc.query); !reflect.DeepEqual(items, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, items)
+			}
+		})
+	}
+
+	if !tree.Remove(boxes[3], 3) {
+		t.Error("Expected to remove entry 3")
+	}
+	if tree.Remove(boxes[3], 3) {
+		t.Error("Expected a second removal to find nothing")
+	}
+	if tree.Remove(boxes[0], 1) {
+		t.Error("Expected removal with another item to find nothing")
+	}
+	if items := tree.Query(Envelope{0.75, 0.75, 0.75, 0.75}); !reflect.DeepEqual(items, []any{0}) {
+		t.Errorf("Expected only entry 0 after removal, got %v", items)
+	}
+
+	if err := tree.Insert(Envelope{1, 1, 0, 0}, "inverted"); err == nil {
+		t.Error("Expected error for an inverted envelope")
+	}
+}
+
+// TestTreeMatchesBruteForce tests many random updates against a linear scan
+func TestTreeMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomBox := func() Envelope {
+		x, y := rng.Float64()*1000-500, rng.Float64()*1000-500
+		return Envelope{x, y, x + rng.Float64()*20, y + rng.Float64()*20}
+	}
+
+	var tree Tree
+	live := map[int]Envelope{}
+	for i := 0; i < 2000; i++ {
+		box := randomBox()
+		if err := tree.Insert(box, i); err != nil {
+			t.Fatalf("Failed to insert: %v", err)
+		}
+		live[i] = box
+
+		// Remove about a third of the entries along the way
+		if i%3 == 0 {
+			victim := rng.Intn(i + 1)
+			if old, ok := live[victim]; ok {
+				if !tree.Remove(old, victim) {
+					t.Fatalf("Failed to remove entry %d", victim)
+				}
+				delete(live, victim)
+			}
+		}
+	}
+	if tree.Len() != len(live) {
+		t.Fatalf("Expected %d entries, got %d", len(live), tree.Len())
+	}
+
+	for q := 0; q < 200; q++ {
+		query := randomBox()
+		query.MaxX += 50
+		query.MaxY += 50
+
+		expected := []any{}
+		for i := 0; i < 2000; i++ {
+			if box, ok := live[i]; ok && box.Intersects(query) {
+				expected = append(expected, i)
+			}
+		}
+		if items := tree.Query(query); !reflect.DeepEqual(items, expected) {
+			t.Fatalf("Query %v: expected %v, got %v", query, expected, items)
+		}
+	}
+}
+
+// TestTreeRemoveAfterGrow tests removing an entry that grow left on the
+// midline of the new root
+func TestTreeRemoveAfterGrow(t *testing.T) {
+	var tree Tree
+
+	boxes := []Envelope{{0, 0, 0, 0}, {1, 1, 1, 1}, {3, 3, 3, 3}}
+	for i, box := range boxes {
+		if err := tree.Insert(box, i); err != nil {
+			t.Fatalf("Failed to insert %v: %v", box, err)
+		}
+	}
+	if items := tree.Query(boxes[1]); !reflect.DeepEqual(items, []any{1}) {
+		t.Fatalf("Expected [1], got %v", items)
+	}
+
+	if !tree.Remove(boxes[1], 1) {
+		t.Fatal("Expected to remove the entry on the midline")
+	}
+	if tree.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", tree.Len())
+	}
+	if items := tree.Query(boxes[1]); len(items) != 0 {
+		t.Errorf("Expected the entry to be gone, got %v", items)
+	}
+}
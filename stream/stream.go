@@ -0,0 +1,329 @@
+// Package stream processes geometry messages from a message broker such as
+// Kafka or NATS. The broker client is kept outside the package: a consumer
+// loop sends each message to a channel, Run parses and processes the messages
+// in batches, and the results come back on another channel, ready to be
+// published and acknowledged.
+//
+// Every message goes through the same pipeline:
+//
+//  1. validate: GeoJSON messages are checked against Config.Rules, and
+//     geometries that are not valid are rejected unless Clean is set
+//  2. clean: invalid geometries are repaired with MakeValid
+//  3. operation: Config.Operation computes the result, e.g. a geofence test
+//
+// Backpressure comes from the channels. Run stops reading input while a batch
+// waits to be delivered on the output channel, so a slow publisher slows down
+// consumption instead of growing an unbounded buffer.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mehmetymw/gogeos/geos"
+)
+
+// Format is the encoding of a message payload
+type Format int
+
+const (
+	// FormatAuto detects GeoJSON by a leading '{' and treats anything else as WKB
+	FormatAuto Format = iota
+	// FormatGeoJSON is a GeoJSON geometry object
+	FormatGeoJSON
+	// FormatWKB is a WKB or EWKB geometry
+	FormatWKB
+)
+
+// Message is one geometry message read from a broker
+type Message struct {
+	// Key identifies the message for the caller, e.g. a Kafka key or NATS subject
+	Key string
+
+	// Value is the encoded geometry
+	Value []byte
+
+	// Format is the encoding of Value
+	Format Format
+
+	// Metadata is passed through untouched, e.g. to acknowledge the message
+	// or commit its offset once the result is published
+	Metadata any
+}
+
+// Result is the outcome of processing one message. Exactly one of Geometry
+// and Err is set.
+type Result struct {
+	Message  Message
+	Geometry *geos.Geometry
+	Err      error
+}
+
+// Operation computes the result for one parsed and cleaned geometry. The
+// pipeline destroys geom once the operation returns, unless it is returned
+// as the result, so the operation must not keep it.
+type Operation func(service *geos.Service, geom *geos.Geometry) (*geos.Geometry, error)
+
+// Config configures the pipeline run by Run
+type Config struct {
+	// Service performs the geometry work. It is required.
+	Service *geos.Service
+
+	// Rules, if set, are checked on GeoJSON messages before they are parsed
+	Rules *geos.GeoJSONRules
+
+	// Clean repairs invalid geometries with MakeValid instead of rejecting them
+	Clean bool
+
+	// Operation, if set, is applied to every geometry after cleaning
+	Operation Operation
+
+	// BatchSize is the largest number of results delivered at once (default 100)
+	BatchSize int
+
+	// FlushInterval is the longest a message waits for its batch to fill up
+	// before a partial batch is delivered. Zero waits for a full batch or the
+	// end of the input.
+	FlushInterval time.Duration
+}
+
+// defaultBatchSize is used when Config.BatchSize is zero
+const defaultBatchSize = 100
+
+// Run reads messages from in until it is closed or ctx is done, and delivers
+// one result per message on out, in input order and grouped into batches.
+// Failures of individual messages are reported in their Result and do not
+// stop the stream. Run closes out when it returns.
+//
+// Parameters:
+//   - ctx: Stops the stream when done; pending messages are dropped
+//   - cfg: The pipeline configuration
+//   - in: The messages to process
+//   - out: Receives the results in batches
+//
+// Returns:
+//   - error: nil once in is closed and every result is delivered, ctx.Err()
+//     if the context ends first, or an error if cfg is invalid
+//
+// Example:
+//
+//	in := make(chan stream.Message)
+//	out := make(chan []stream.Result)
+//
+//	go func() {
+//		defer close(in)
+//		for record := range kafkaRecords {
+//			in <- stream.Message{Key: string(record.Key), Value: record.Value, Metadata: record}
+//		}
+//	}()
+//
+//	go stream.Run(ctx, stream.Config{
+//		Service:       service,
+//		Clean:         true,
+//		Operation:     func(s *geos.Service, g *geos.Geometry) (*geos.Geometry, error) { return s.Difference(g, restricted) },
+//		BatchSize:     500,
+//		FlushInterval: 50 * time.Millisecond,
+//	}, in, out)
+//
+//	for batch := range out {
+//		publish(batch)
+//	}
+func Run(ctx context.Context, cfg Config, in <-chan Message, out chan<- []Result) error {
+	defer close(out)
+
+	if cfg.Service == nil {
+		return errors.New("stream: a service is required")
+	}
+	if cfg.BatchSize < 0 || cfg.FlushInterval < 0 {
+		return errors.New("stream: batch size and flush interval must not be negative")
+	}
+	size := cfg.BatchSize
+	if size == 0 {
+		size = defaultBatchSize
+	}
+
+	batch := make([]Result, 0, size)
+	var timer *time.Timer
+	var timeout <-chan time.Time
+
+	flush := func() error {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		select {
+		case out <- batch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		batch = make([]Result, 0, size)
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-timeout:
+			if err := flush(); err != nil {
+				return err
+			}
+
+		case msg, ok := <-in:
+			if !ok {
+				return flush()
+			}
+			batch = append(batch, cfg.process(msg))
+			if len(batch) >= size {
+				if err := flush(); err != nil {
+					return err
+				}
+			} else if len(batch) == 1 && cfg.FlushInterval > 0 {
+				timer = time.NewTimer(cfg.FlushInterval)
+				timeout = timer.C
+			}
+		}
+	}
+}
+
+// process runs the pipeline on one message
+func (cfg *Config) process(msg Message) Result {
+	geom, err := cfg.pipeline(msg)
+	if err != nil {
+		return Result{Message: msg, Err: err}
+	}
+	return Result{Message: msg, Geometry: geom}
+}
+
+// pipeline validates, cleans and processes one message
+func (cfg *Config) pipeline(msg Message) (*geos.Geometry, error) {
+	format := msg.Format
+	if format == FormatAuto {
+		format = detectFormat(msg.Value)
+	}
+
+	var geom *geos.Geometry
+	var err error
+	switch format {
+	case FormatGeoJSON:
+		geom, err = cfg.parseGeoJSON(msg.Value)
+	case FormatWKB:
+		geom, err = cfg.parseWKB(msg.Value)
+	default:
+		return nil, fmt.Errorf("unknown message format: %d", int(format))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Clean {
+		valid, err := cfg.Service.MakeValid(geom)
+		replace(geom, valid)
+		if err != nil {
+			return nil, fmt.Errorf("clean: %w", err)
+		}
+		geom = valid
+	}
+
+	if cfg.Operation != nil {
+		result, err := cfg.Operation(cfg.Service, geom)
+		replace(geom, result)
+		if err != nil {
+			return nil, fmt.Errorf("operation: %w", err)
+		}
+		if result == nil {
+			return nil, errors.New("operation: no geometry returned")
+		}
+		geom = result
+	}
+
+	return geom, nil
+}
+
+// replace destroys a geometry superseded by the next step of the pipeline,
+// unless the step handed the same geometry back
+func replace(old, next *geos.Geometry) {
+	if old != next {
+		old.Destroy()
+	}
+}
+
+// parseGeoJSON checks a GeoJSON payload against the rules and parses it
+func (cfg *Config) parseGeoJSON(data []byte) (*geos.Geometry, error) {
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("validate: invalid GeoJSON: %w", err)
+	}
+	input := geos.GeometryInput{GeoJSON: obj, AllowInvalid: cfg.Clean}
+
+	if cfg.Rules != nil {
+		if violations := cfg.Service.ValidateGeoJSON(input, *cfg.Rules); len(violations) > 0 {
+			return nil, &ValidationError{Violations: violations}
+		}
+	}
+
+	geom, err := cfg.Service.ParseGeometry(input)
+	if err != nil {
+		return nil, fmt.Errorf("validate: %w", err)
+	}
+	return geom, nil
+}
+
+// parseWKB parses a WKB payload, rejecting invalid geometries unless they
+// will be cleaned
+func (cfg *Config) parseWKB(data []byte) (*geos.Geometry, error) {
+	geoms, err := cfg.Service.FromWKBArray(geos.WKBArray{Offsets: []int32{0, int32(len(data))}, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("validate: %w", err)
+	}
+	geom := geoms[0]
+
+	if !cfg.Clean {
+		valid, err := cfg.Service.IsValid(geom)
+		if err != nil {
+			return nil, fmt.Errorf("validate: %w", err)
+		}
+		if !valid {
+			return nil, errors.New("validate: invalid geometry")
+		}
+	}
+	return geom, nil
+}
+
+// detectFormat tells GeoJSON and WKB payloads apart
+func detectFormat(data []byte) Format {
+	for _, b := range data {
+		if b == ' ' || b == '\t' || b == '\r' || b == '\n' {
+			continue
+		}
+		if b == '{' {
+			return FormatGeoJSON
+		}
+		return FormatWKB
+	}
+	return FormatWKB
+}
+
+// ValidationError reports the rule violations that rejected a GeoJSON message
+type ValidationError struct {
+	Violations []geos.Violation
+}
+
+// Error lists the violations
+func (e *ValidationError) Error() string {
+	msg := "validate: "
+	for i, v := range e.Violations {
+		if i > 0 {
+			msg += "; "
+		}
+		msg += v.String()
+	}
+	return msg
+}
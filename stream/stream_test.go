@@ -0,0 +1,241 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mehmetymw/gogeos/geos"
+)
+
+// newService creates a service closed at the end of the test
+func newService(t *testing.T) *geos.Service {
+	t.Helper()
+	service, err := geos.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	t.Cleanup(service.Close)
+	return service
+}
+
+// runAll sends messages through Run and collects every batch
+func runAll(t *testing.T, cfg Config, messages []Message) [][]Result {
+	t.Helper()
+	in := make(chan Message)
+	out := make(chan []Result)
+	errc := make(chan error, 1)
+
+	go func() { errc <- Run(context.Background(), cfg, in, out) }()
+	go func() {
+		defer close(in)
+		for _, msg := range messages {
+			in <- msg
+		}
+	}()
+
+	var batches [][]Result
+	for batch := range out {
+		batches = append(batches, batch)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	return batches
+}
+
+// pointMessage returns a GeoJSON point message
+func pointMessage(key string, x, y float64) Message {
+	return Message{Key: key, Value: []byte(fmt.Sprintf(`{"type":"Point","coordinates":[%g,%g]}`, x, y))}
+}
+
+// TestRunBatches tests batching and ordering of results
+func TestRunBatches(t *testing.T) {
+	service := newService(t)
+
+	messages := make([]Message, 5)
+	for i := range messages {
+		messages[i] = pointMessage(fmt.Sprint(i), float64(i), 0)
+	}
+
+	batches := runAll(t, Config{Service: service, BatchSize: 2}, messages)
+
+	if len(batches) != 3 || len(batches[0]) != 2 || len(batches[1]) != 2 || len(batches[2]) != 1 {
+		t.Fatalf("Expected batches of 2, 2 and 1 results, got %d batches", len(batches))
+	}
+	i := 0
+	for _, batch := range batches {
+		for _, result := range batch {
+			if result.Err != nil {
+				t.Errorf("Message %d: unexpected error %v", i, result.Err)
+			}
+			if result.Message.Key != fmt.Sprint(i) {
+				t.Errorf("Expected key %d, got %s", i, result.Message.Key)
+			}
+			i++
+		}
+	}
+}
+
+// TestRunPipeline tests validation, cleaning and the operation stage
+func TestRunPipeline(t *testing.T) {
+	service := newService(t)
+
+	bowtie := Message{Key: "bowtie", Value: []byte(`{"type":"Polygon","coordinates":[[[0,0],[2,2],[2,0],[0,2],[0,0]]]}`)}
+	square, err := service.ParseGeometry(geos.GeometryInput{WKT: "POLYGON((0 0, 4 0, 4 4, 0 4, 0 0))"})
+	if err != nil {
+		t.Fatalf("Failed to parse square: %v", err)
+	}
+	arr, err := service.ToWKBArray([]*geos.Geometry{square})
+	if err != nil {
+		t.Fatalf("Failed to encode WKB: %v", err)
+	}
+	wkb := Message{Key: "wkb", Value: arr.Value(0)}
+	outside := pointMessage("outside", 500, 0)
+	broken := Message{Key: "broken", Value: []byte(`{"type":`), Format: FormatGeoJSON}
+
+	rules := &geos.GeoJSONRules{BBox: []float64{-180, -90, 180, 90}}
+	buffer := func(s *geos.Service, g *geos.Geometry) (*geos.Geometry, error) {
+		return s.Buffer(g, 1)
+	}
+
+	testCases := []struct {
+		name    string
+		cfg     Config
+		msg     Message
+		wantErr bool
+	}{
+		{"Invalid rejected", Config{Service: service}, bowtie, true},
+		{"Invalid cleaned", Config{Service: service, Clean: true}, bowtie, false},
+		{"WKB", Config{Service: service}, wkb, false},
+		{"Rule violation", Config{Service: service, Rules: rules}, outside, true},
+		{"Malformed JSON", Config{Service: service}, broken, true},
+		{"Operation", Config{Service: service, Operation: buffer}, outside, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			batches := runAll(t, tc.cfg, []Message{tc.msg})
+			if len(batches) != 1 || len(batches[0]) != 1 {
+				t.Fatalf("Expected one result, got %d batches", len(batches))
+			}
+			result := batches[0][0]
+			if tc.wantErr {
+				if result.Err == nil {
+					t.Error("Expected an error")
+				}
+				return
+			}
+			if result.Err != nil {
+				t.Fatalf("Unexpected error: %v", result.Err)
+			}
+			valid, err := service.IsValid(result.Geometry)
+			if err != nil || !valid {
+				t.Errorf("Expected a valid result geometry, got %v (%v)", valid, err)
+			}
+		})
+	}
+
+	t.Run("Intermediates destroyed", func(t *testing.T) {
+		var inputs []*geos.Geometry
+		keep := func(s *geos.Service, g *geos.Geometry) (*geos.Geometry, error) {
+			inputs = append(inputs, g)
+			return g, nil
+		}
+		grow := func(s *geos.Service, g *geos.Geometry) (*geos.Geometry, error) {
+			inputs = append(inputs, g)
+			return s.Buffer(g, 1)
+		}
+
+		batches := runAll(t, Config{Service: service, Operation: keep}, []Message{outside})
+		if result := batches[0][0]; result.Err != nil || result.Geometry != inputs[0] {
+			t.Fatalf("Expected the operation input back, got %v (%v)", result.Geometry, result.Err)
+		}
+		if _, err := service.ToWKT(inputs[0]); err != nil {
+			t.Errorf("Expected a returned input to stay usable, got %v", err)
+		}
+
+		batches = runAll(t, Config{Service: service, Operation: grow}, []Message{outside})
+		if batches[0][0].Err != nil {
+			t.Fatalf("Unexpected error: %v", batches[0][0].Err)
+		}
+		if _, err := service.ToWKT(inputs[1]); err == nil {
+			t.Error("Expected the replaced input to be destroyed")
+		}
+	})
+
+	t.Run("Violations reported", func(t *testing.T) {
+		batches := runAll(t, Config{Service: service, Rules: rules}, []Message{outside})
+		var verr *ValidationError
+		if !errors.As(batches[0][0].Err, &verr) || len(verr.Violations) != 1 {
+			t.Errorf("Expected a ValidationError with one violation, got %v", batches[0][0].Err)
+		}
+	})
+}
+
+// TestRunFlushInterval tests delivery of partial batches
+func TestRunFlushInterval(t *testing.T) {
+	service := newService(t)
+
+	in := make(chan Message)
+	out := make(chan []Result)
+	go Run(context.Background(), Config{Service: service, BatchSize: 10, FlushInterval: 10 * time.Millisecond}, in, out)
+	defer close(in)
+
+	in <- pointMessage("a", 1, 1)
+	select {
+	case batch := <-out:
+		if len(batch) != 1 {
+			t.Errorf("Expected a partial batch of 1 result, got %d", len(batch))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the partial batch to be flushed")
+	}
+}
+
+// TestRunCancel tests stopping the stream with a context
+func TestRunCancel(t *testing.T) {
+	service := newService(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan Message)
+	out := make(chan []Result)
+	errc := make(chan error, 1)
+	go func() { errc <- Run(ctx, Config{Service: service}, in, out) }()
+
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if _, ok := <-out; ok {
+		t.Error("Expected the output channel to be closed")
+	}
+
+	if err := Run(context.Background(), Config{}, in, make(chan []Result)); err == nil {
+		t.Error("Expected error for missing service")
+	}
+}
+
+// TestDetectFormat tests telling GeoJSON and WKB payloads apart
+func TestDetectFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected Format
+	}{
+		{"GeoJSON", []byte(`{"type":"Point"}`), FormatGeoJSON},
+		{"GeoJSON with whitespace", []byte(" \n{}"), FormatGeoJSON},
+		{"WKB", []byte{1, 1, 0, 0, 0}, FormatWKB},
+		{"Empty", nil, FormatWKB},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := detectFormat(tc.data); got != tc.expected {
+				t.Errorf("Expected format %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Package transform reprojects coordinates between common coordinate
+// reference systems identified by EPSG codes, in pure Go and without PROJ.
+// It covers the systems most planar work needs:
+//
+//   - EPSG:4326, WGS84 longitude/latitude in degrees
+//   - EPSG:3857, Web Mercator meters
+//   - EPSG:32601 to 32660 and 32701 to 32760, the WGS84 UTM zones of the
+//     northern and southern hemisphere
+//
+// Geographic coordinates are always in longitude/latitude (X/Y) order, the
+// traditional GIS axis order, even though EPSG:4326 formally lists latitude
+// first. Transformations between the supported systems go through WGS84
+// longitude/latitude and need no datum shift.
+//
+// Transformers satisfy geos.Transformer, so they can be passed to
+// Service.Transform without this package depending on GEOS.
+package transform
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mehmetymw/gogeos/mercator"
+)
+
+const (
+	// WGS84 is the EPSG code of WGS84 longitude/latitude
+	WGS84 = 4326
+
+	// WebMercator is the EPSG code of Web Mercator
+	WebMercator = 3857
+)
+
+// projection converts between WGS84 longitude/latitude in degrees and the
+// coordinates of a reference system
+type projection interface {
+	forward(lon, lat float64) (x, y float64)
+	inverse(x, y float64) (lon, lat float64)
+}
+
+// Transformer converts coordinates from one reference system to another. It
+// is safe for concurrent use.
+type Transformer struct {
+	from, to       int
+	source, target projection
+}
+
+// New returns a transformer from one EPSG reference system to another.
+//
+// Example:
+//
+//	t, err := transform.New(transform.WGS84, 32635)
+//	projected, err := service.Transform(geom, t)
+//	buffered, err := service.Buffer(projected, 500) // 500 meters
+func New(fromEPSG, toEPSG int) (*Transformer, error) {
+	source, err := lookup(fromEPSG)
+	if err != nil {
+		return nil, err
+	}
+	target, err := lookup(toEPSG)
+	if err != nil {
+		return nil, err
+	}
+	return &Transformer{from: fromEPSG, to: toEPSG, source: source, target: target}, nil
+}
+
+// Supported reports whether an EPSG code names a reference system this
+// package can transform.
+func Supported(epsg int) bool {
+	_, err := lookup(epsg)
+	return err == nil
+}
+
+// UTMZone returns the EPSG code of the WGS84 UTM zone containing a
+// longitude/latitude location: 326zz north of the equator and 327zz south of
+// it. Longitudes outside ±180° are wrapped. The special zones of Norway and
+// Svalbard are not applied.
+//
+// Example:
+//
+//	epsg := transform.UTMZone(28.9784, 41.0082)
+//	// epsg will be 32635
+func UTMZone(lon, lat float64) int {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	zone := int(lon/6) + 1
+	if lat < 0 {
+		return 32700 + zone
+	}
+	return 32600 + zone
+}
+
+// From returns the EPSG code of the source reference system.
+func (t *Transformer) From() int {
+	return t.from
+}
+
+// To returns the EPSG code of the target reference system.
+func (t *Transformer) To() int {
+	return t.to
+}
+
+// Inverse returns the transformer for the opposite direction.
+func (t *Transformer) Inverse() *Transformer {
+	return &Transformer{from: t.to, to: t.from, source: t.target, target: t.source}
+}
+
+// Transform converts a single coordinate.
+func (t *Transformer) Transform(x, y float64) (float64, float64) {
+	if t.from == t.to {
+		return x, y
+	}
+	lon, lat := t.source.inverse(x, y)
+	return t.target.forward(lon, lat)
+}
+
+// TransformXY converts coordinates in place.
+func (t *Transformer) TransformXY(x, y []float64) error {
+	if len(x) != len(y) {
+		return fmt.Errorf("transform: %d X ordinates but %d Y ordinates", len(x), len(y))
+	}
+	for i := range x {
+		x[i], y[i] = t.Transform(x[i], y[i])
+	}
+	return nil
+}
+
+// lookup returns the projection of an EPSG code
+func lookup(epsg int) (projection, error) {
+	switch {
+	case epsg == WGS84:
+		return geographic{}, nil
+	case epsg == WebMercator:
+		return webMercator{}, nil
+	case epsg >= 32601 && epsg <= 32660:
+		return newUTM(epsg-32600, false), nil
+	case epsg >= 32701 && epsg <= 32760:
+		return newUTM(epsg-32700, true), nil
+	}
+	return nil, fmt.Errorf("transform: unsupported reference system EPSG:%d", epsg)
+}
+
+// geographic is WGS84 longitude/latitude itself
+type geographic struct{}
+
+func (geographic) forward(lon, lat float64) (float64, float64) { return lon, lat }
+func (geographic) inverse(x, y float64) (float64, float64)     { return x, y }
+
+// webMercator is EPSG:3857, see the mercator package
+type webMercator struct{}
+
+func (webMercator) forward(lon, lat float64) (float64, float64) {
+	return mercator.LonLatToMeters(lon, lat)
+}
+
+func (webMercator) inverse(x, y float64) (float64, float64) {
+	return mercator.MetersToLonLat(x, y)
+}
+
+// radians converts degrees to radians
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// degrees converts radians to degrees
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,124 @@
+package transform
+
+import (
+	"math"
+	"testing"
+)
+
+// TestTransform tests converting single coordinates between reference systems
+func TestTransform(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from, to int
+		x, y     float64
+		ex, ey   float64
+		accuracy float64
+	}{
+		{"Identity", WGS84, WGS84, 28.9784, 41.0082, 28.9784, 41.0082, 0},
+		{"Web Mercator", WGS84, WebMercator, 28.9784, 41.0082, 3225860.7320, 5013551.2372, 1e-3},
+		{"UTM north (CN Tower)", WGS84, 32617, -79.387139, 43.642567, 630084.30, 4833438.59, 0.01},
+		{"UTM south equator", WGS84, 32735, 27, 0, 500000, 10000000, 1e-6},
+		{"UTM central meridian origin", WGS84, 32631, 3, 0, 500000, 0, 1e-6},
+		{"Web Mercator to UTM", WebMercator, 32617, -8837335.8890, 5410294.1949, 630084.30, 4833438.59, 0.01},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tr, err := New(tc.from, tc.to)
+			if err != nil {
+				t.Fatalf("Failed to create transformer: %v", err)
+			}
+			x, y := tr.Transform(tc.x, tc.y)
+			if math.Abs(x-tc.ex) > tc.accuracy || math.Abs(y-tc.ey) > tc.accuracy {
+				t.Errorf("Expected (%f %f), got (%f %f)", tc.ex, tc.ey, x, y)
+			}
+
+			// The inverse brings the coordinate back
+			bx, by := tr.Inverse().Transform(x, y)
+			if math.Abs(bx-tc.x) > 1e-6 || math.Abs(by-tc.y) > 1e-6 {
+				t.Errorf("Round trip: expected (%f %f), got (%f %f)", tc.x, tc.y, bx, by)
+			}
+		})
+	}
+}
+
+// TestUTMRoundTrip tests that UTM projections invert precisely across a zone
+func TestUTMRoundTrip(t *testing.T) {
+	for _, epsg := range []int{32601, 32635, 32660, 32701, 32735, 32760} {
+		tr, err := New(WGS84, epsg)
+		if err != nil {
+			t.Fatalf("Failed to create transformer: %v", err)
+		}
+		zone := epsg % 100
+		central := float64(zone*6 - 183)
+		for _, lat := range []float64{-79.5, -45, -0.5, 0.5, 45, 83.5} {
+			for _, offset := range []float64{-3, -1.5, 0, 1.5, 3} {
+				lon := central + offset
+				x, y := tr.Transform(lon, lat)
+				backLon, backLat := tr.Inverse().Transform(x, y)
+				// 1e-9 degrees is about 0.1 mm
+				if math.Abs(backLon-lon) > 1e-9 || math.Abs(backLat-lat) > 1e-9 {
+					t.Errorf("EPSG:%d (%g %g): round trip gave (%.12f %.12f)", epsg, lon, lat, backLon, backLat)
+				}
+			}
+		}
+	}
+}
+
+// TestUTMZone tests picking the UTM zone of a location
+func TestUTMZone(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lon, lat float64
+		expected int
+	}{
+		{"Istanbul", 28.9784, 41.0082, 32635},
+		{"Toronto", -79.3871, 43.6426, 32617},
+		{"Sydney", 151.2093, -33.8688, 32756},
+		{"Zone boundary", 0, 10, 32631},
+		{"Equator", 3, 0, 32631},
+		{"Western edge", -180, 10, 32601},
+		{"Eastern edge", 180, -10, 32701},
+		{"Wrapped", 208.9784, 41.0082, 32605},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if epsg := UTMZone(tc.lon, tc.lat); epsg != tc.expected {
+				t.Errorf("Expected EPSG:%d, got EPSG:%d", tc.expected, epsg)
+			}
+		})
+	}
+}
+
+// TestTransformXY tests batch conversion and error handling
+func TestTransformXY(t *testing.T) {
+	tr, err := New(WGS84, WebMercator)
+	if err != nil {
+		t.Fatalf("Failed to create transformer: %v", err)
+	}
+	if tr.From() != WGS84 || tr.To() != WebMercator {
+		t.Errorf("Expected EPSG:%d to EPSG:%d, got %d to %d", WGS84, WebMercator, tr.From(), tr.To())
+	}
+
+	x := []float64{0, 28.9784}
+	y := []float64{0, 41.0082}
+	if err := tr.TransformXY(x, y); err != nil {
+		t.Fatalf("Failed to transform: %v", err)
+	}
+	if x[0] != 0 || y[0] != 0 || math.Abs(x[1]-3225860.7320) > 1e-3 || math.Abs(y[1]-5013551.2372) > 1e-3 {
+		t.Errorf("Unexpected coordinates %v %v", x, y)
+	}
+	if err := tr.TransformXY([]float64{0}, nil); err == nil {
+		t.Error("Expected error for mismatched lengths")
+	}
+
+	for _, epsg := range []int{0, 4269, 32600, 32661, 32700, 32761} {
+		if Supported(epsg) {
+			t.Errorf("Expected EPSG:%d to be unsupported", epsg)
+		}
+		if _, err := New(WGS84, epsg); err == nil {
+			t.Errorf("Expected error for EPSG:%d", epsg)
+		}
+	}
+}
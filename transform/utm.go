@@ -0,0 +1,130 @@
+package transform
+
+import (
+	"math"
+)
+
+const (
+	// semiMajor is the WGS84 semi-major axis in meters
+	semiMajor = 6378137.0
+
+	// flattening is the WGS84 flattening
+	flattening = 1 / 298.257223563
+
+	// utmScale is the scale factor on the central meridian of a UTM zone
+	utmScale = 0.9996
+
+	// utmFalseEasting is the easting of the central meridian
+	utmFalseEasting = 500000.0
+
+	// utmFalseNorthing is the northing of the equator in the southern zones
+	utmFalseNorthing = 10000000.0
+)
+
+// Series coefficients of the transverse Mercator projection in the third
+// flattening n, to sixth order (Karney, "Transverse Mercator with an
+// accuracy of a few nanometers", 2011). They are exact to well below a
+// millimeter within a UTM zone.
+var (
+	thirdFlattening = flattening / (2 - flattening)
+
+	// rectifyingRadius is the radius A of the rectifying sphere
+	rectifyingRadius = semiMajor / (1 + thirdFlattening) * series(thirdFlattening, 1, 0, 1.0/4, 0, 1.0/64, 0, 1.0/256)
+
+	// alpha maps conformal to projected coordinates
+	alpha = [6]float64{
+		series(thirdFlattening, 0, 1.0/2, -2.0/3, 5.0/16, 41.0/180, -127.0/288, 7891.0/37800),
+		series(thirdFlattening, 0, 0, 13.0/48, -3.0/5, 557.0/1440, 281.0/630, -1983433.0/1935360),
+		series(thirdFlattening, 0, 0, 0, 61.0/240, -103.0/140, 15061.0/26880, 167603.0/181440),
+		series(thirdFlattening, 0, 0, 0, 0, 49561.0/161280, -179.0/168, 6601661.0/7257600),
+		series(thirdFlattening, 0, 0, 0, 0, 0, 34729.0/80640, -3418889.0/1995840),
+		series(thirdFlattening, 0, 0, 0, 0, 0, 0, 212378941.0/319334400),
+	}
+
+	// beta maps projected to conformal coordinates
+	beta = [6]float64{
+		series(thirdFlattening, 0, 1.0/2, -2.0/3, 37.0/96, -1.0/360, -81.0/512, 96199.0/604800),
+		series(thirdFlattening, 0, 0, 1.0/48, 1.0/15, -437.0/1440, 46.0/105, -1118711.0/3870720),
+		series(thirdFlattening, 0, 0, 0, 17.0/480, -37.0/840, -209.0/4480, 5569.0/90720),
+		series(thirdFlattening, 0, 0, 0, 0, 4397.0/161280, -11.0/504, -830251.0/7257600),
+		series(thirdFlattening, 0, 0, 0, 0, 0, 4583.0/161280, -108847.0/3991680),
+		series(thirdFlattening, 0, 0, 0, 0, 0, 0, 20648693.0/638668800),
+	}
+
+	// delta maps conformal to geodetic latitude
+	delta = [6]float64{
+		series(thirdFlattening, 0, 2, -2.0/3, -2, 116.0/45, 26.0/45, -2854.0/675),
+		series(thirdFlattening, 0, 0, 7.0/3, -8.0/5, -227.0/45, 2704.0/315, 2323.0/945),
+		series(thirdFlattening, 0, 0, 0, 56.0/15, -136.0/35, -1262.0/105, 73814.0/2835),
+		series(thirdFlattening, 0, 0, 0, 0, 4279.0/630, -332.0/35, -399572.0/14175),
+		series(thirdFlattening, 0, 0, 0, 0, 0, 4174.0/315, -144838.0/6237),
+		series(thirdFlattening, 0, 0, 0, 0, 0, 0, 601676.0/22275),
+	}
+)
+
+// series evaluates the polynomial with the given coefficients, lowest order
+// first, at x
+func series(x float64, coefficients ...float64) float64 {
+	sum := 0.0
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		sum = sum*x + coefficients[i]
+	}
+	return sum
+}
+
+// utm is a WGS84 UTM zone
+type utm struct {
+	centralMeridian float64 // radians
+	falseNorthing   float64
+}
+
+// newUTM returns the projection of a UTM zone from 1 to 60
+func newUTM(zone int, south bool) utm {
+	u := utm{centralMeridian: radians(float64(zone*6 - 183))}
+	if south {
+		u.falseNorthing = utmFalseNorthing
+	}
+	return u
+}
+
+func (u utm) forward(lon, lat float64) (float64, float64) {
+	phi := radians(lat)
+	lambda := radians(lon) - u.centralMeridian
+
+	// Conformal latitude, as its tangent
+	e := 2 * math.Sqrt(thirdFlattening) / (1 + thirdFlattening)
+	tau := math.Sinh(math.Atanh(math.Sin(phi)) - e*math.Atanh(e*math.Sin(phi)))
+
+	xi := math.Atan2(tau, math.Cos(lambda))
+	eta := math.Atanh(math.Sin(lambda) / math.Sqrt(1+tau*tau))
+
+	x, y := eta, xi
+	for j, a := range alpha {
+		k := float64(2 * (j + 1))
+		x += a * math.Cos(k*xi) * math.Sinh(k*eta)
+		y += a * math.Sin(k*xi) * math.Cosh(k*eta)
+	}
+
+	return utmFalseEasting + utmScale*rectifyingRadius*x, u.falseNorthing + utmScale*rectifyingRadius*y
+}
+
+func (u utm) inverse(x, y float64) (float64, float64) {
+	xi := (y - u.falseNorthing) / (utmScale * rectifyingRadius)
+	eta := (x - utmFalseEasting) / (utmScale * rectifyingRadius)
+
+	xiPrime, etaPrime := xi, eta
+	for j, b := range beta {
+		k := float64(2 * (j + 1))
+		xiPrime -= b * math.Sin(k*xi) * math.Cosh(k*eta)
+		etaPrime -= b * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+
+	chi := math.Asin(math.Sin(xiPrime) / math.Cosh(etaPrime))
+	phi := chi
+	for j, d := range delta {
+		phi += d * math.Sin(float64(2*(j+1))*chi)
+	}
+	lambda := math.Atan2(math.Sinh(etaPrime), math.Cos(xiPrime))
+
+	return degrees(u.centralMeridian + lambda), degrees(phi)
+}